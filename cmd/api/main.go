@@ -42,18 +42,64 @@ func main() {
 		logger.Fatal(fmt.Sprintf("failed to initialize producer: %+v", err))
 	}
 
+	mailer, err := config.NewMailer(env, logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize mailer: %+v", err))
+	}
+
+	jwtToken, err := config.NewJWTToken(env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize jwt token: %+v", err))
+	}
+
+	passwordHasher, err := config.NewPasswordHasher(env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize password hasher: %+v", err))
+	}
+
+	passwordPolicy, err := config.NewPasswordPolicy(env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize password policy: %+v", err))
+	}
+
+	oidcProviders, err := config.NewOIDCProviders(env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize oidc providers: %+v", err))
+	}
+
+	oauthClients, err := config.NewOAuthClients(env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize oauth clients: %+v", err))
+	}
+
+	cookieConfig, err := config.NewCookieConfig(env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize auth cookies: %+v", err))
+	}
+
 	tx := db.NewTransactioner(database)
 	validate := config.NewValidator()
 	app := config.NewGin(logger)
+	err = app.SetTrustedProxies(env.AppTrustedProxies)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to set trusted proxies: %+v", err))
+	}
 
 	config.NewApi(&config.ApiConfig{
-		DB:       database,
-		TX:       tx,
-		App:      app,
-		Log:      logger,
-		Validate: validate,
-		Config:   env,
-		Producer: producer,
+		DB:             database,
+		TX:             tx,
+		App:            app,
+		Log:            logger,
+		Validate:       validate,
+		Config:         env,
+		Producer:       producer,
+		Mailer:         mailer,
+		JWTToken:       jwtToken,
+		PasswordHasher: passwordHasher,
+		PasswordPolicy: passwordPolicy,
+		OIDCProviders:  oidcProviders,
+		OAuthClients:   oauthClients,
+		Cookie:         cookieConfig,
 	})
 
 	serverAddr := fmt.Sprintf(":%d", env.AppPort)
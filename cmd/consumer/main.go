@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-api-example/internal/config"
 	"go-api-example/internal/delivery/messaging"
+	internalMessaging "go-api-example/internal/messaging"
 	"go-api-example/internal/repository"
 	"go-api-example/internal/usecase"
 	"log"
@@ -36,39 +37,74 @@ func main() {
 		logger.Fatal(fmt.Sprintf("failed to initialize database: %+v", err))
 	}
 
-	todoRepository := repository.NewTodoRepository(database)
-	todoUsecase := usecase.NewTodoUsecase(logger, todoRepository)
-
-	kafkaConsumer, err := config.NewKafkaConsumer(env, logger)
+	producer, err := config.NewKafkaProducer(env, logger)
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("failed to initialize user consumer: %+v", err))
+		logger.Fatal(fmt.Sprintf("failed to initialize producer: %+v", err))
 	}
 
+	todoProducer := internalMessaging.NewTodoProducer(logger, producer, env.KafkaTopicTodoAssigned)
+
+	userRepository := repository.NewUserRepository(database)
+	todoRepository := repository.NewTodoRepository(database)
+	todoUsecase := usecase.NewTodoUsecase(logger, todoProducer, todoRepository, userRepository)
+
+	notificationRepository := repository.NewNotificationRepository(database)
+	notificationUsecase := usecase.NewNotificationUsecase(logger, notificationRepository)
+
+	securityEventRepository := repository.NewSecurityEventRepository(database)
+	securityEventUsecase := usecase.NewSecurityEventUsecase(logger, securityEventRepository)
+
 	userHandler := messaging.NewUserHandler(logger, todoUsecase)
+	notificationHandler := messaging.NewNotificationHandler(logger, notificationUsecase)
+	securityEventHandler := messaging.NewSecurityEventHandler(logger, securityEventUsecase)
+
+	consumerSpecs := []struct {
+		name    string
+		groupID string
+		topic   string
+		handler messaging.Handler
+	}{
+		{"user", env.KafkaConsumerGroup, env.KafkaTopicUserRegistered, userHandler.Consume},
+		{"user registered notification", env.KafkaNotificationConsumerGroup, env.KafkaTopicUserRegistered,
+			notificationHandler.ConsumeUserRegistered},
+		{"todo assigned notification", env.KafkaNotificationConsumerGroup, env.KafkaTopicTodoAssigned,
+			notificationHandler.ConsumeTodoAssigned},
+		{"security event", env.KafkaSecurityEventConsumerGroup, env.KafkaTopicSecurityEvent, securityEventHandler.Consume},
+	}
 
-	consumerCfg := &messaging.ConsumerConfig{
-		Topic:              env.KafkaTopicUserRegistered,
-		MaxRetries:         3,
-		BackoffDuration:    1 * time.Second,
-		MaxExecuteDuration: 10 * time.Second,
+	consumers := make([]messaging.Consumer, 0, len(consumerSpecs))
+	for _, spec := range consumerSpecs {
+		kafkaConsumer, err := config.NewKafkaConsumer(env, spec.groupID, logger)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("failed to initialize %s consumer: %+v", spec.name, err))
+		}
+
+		consumerCfg := &messaging.ConsumerConfig{
+			Topic:              spec.topic,
+			MaxRetries:         3,
+			BackoffDuration:    1 * time.Second,
+			MaxExecuteDuration: 10 * time.Second,
+		}
+		consumers = append(consumers, messaging.NewConsumer(logger, kafkaConsumer, consumerCfg, spec.handler))
 	}
-	userConsumer := messaging.NewConsumer(logger, kafkaConsumer, consumerCfg, userHandler.Consume)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	errCh := make(chan error, 1)
+	errCh := make(chan error, len(consumers))
 
 	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-		err := userConsumer.Consume(ctx)
-		if err != nil {
-			errCh <- err
-		}
-	}()
+	for _, c := range consumers {
+		wg.Add(1)
+
+		go func(c messaging.Consumer) {
+			defer wg.Done()
+			err := c.Consume(ctx)
+			if err != nil {
+				errCh <- err
+			}
+		}(c)
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
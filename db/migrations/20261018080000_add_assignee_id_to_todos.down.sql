@@ -0,0 +1,3 @@
+ALTER TABLE todos
+    DROP INDEX index_todos_on_assigneeid_status,
+    DROP COLUMN assignee_id;
@@ -0,0 +1,3 @@
+ALTER TABLE todos
+    ADD COLUMN assignee_id BIGINT UNSIGNED NULL AFTER user_id,
+    ADD INDEX index_todos_on_assigneeid_status (assignee_id, `status`);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notifications (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	user_id BIGINT UNSIGNED NOT NULL,
+	`type` VARCHAR(50) NOT NULL,
+	event_key VARCHAR(255) NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	body TEXT,
+	read_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE INDEX unique_notifications_on_userid_type_eventkey (user_id, `type`, event_key),
+	INDEX index_notifications_on_userid_readat (user_id, read_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
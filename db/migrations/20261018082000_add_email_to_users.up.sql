@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN email VARCHAR(255) NULL AFTER username,
+    ADD COLUMN email_verified_at TIMESTAMP NULL AFTER email,
+    ADD UNIQUE INDEX unique_users_on_email (email);
\ No newline at end of file
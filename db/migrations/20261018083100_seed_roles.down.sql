@@ -0,0 +1 @@
+DELETE FROM roles WHERE `name` IN ('user', 'admin');
\ No newline at end of file
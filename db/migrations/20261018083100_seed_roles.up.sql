@@ -0,0 +1,3 @@
+INSERT INTO roles (`name`, permissions, created_at, updated_at) VALUES
+	('user', '["todos:read", "todos:write", "notifications:read", "notifications:write"]', NOW(), NOW()),
+	('admin', '["*"]', NOW(), NOW());
\ No newline at end of file
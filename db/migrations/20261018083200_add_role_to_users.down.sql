@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP INDEX index_users_on_role,
+    DROP COLUMN `role`;
\ No newline at end of file
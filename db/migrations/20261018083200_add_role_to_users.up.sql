@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN `role` VARCHAR(64) NOT NULL DEFAULT 'user' AFTER password,
+    ADD INDEX index_users_on_role (`role`);
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS user_totps (
+	user_id BIGINT UNSIGNED NOT NULL,
+	secret VARCHAR(64) NOT NULL,
+	confirmed_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
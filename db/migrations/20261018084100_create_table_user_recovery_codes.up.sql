@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	user_id BIGINT UNSIGNED NOT NULL,
+	code_hash CHAR(64) NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE INDEX unique_user_recovery_codes_on_userid_codehash (user_id, code_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
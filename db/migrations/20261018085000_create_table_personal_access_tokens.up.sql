@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	user_id BIGINT UNSIGNED NOT NULL,
+	`name` VARCHAR(100) NOT NULL,
+	token_hash CHAR(64) NOT NULL,
+	scopes JSON NOT NULL,
+	last_used_at TIMESTAMP NULL,
+	expires_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE INDEX unique_personal_access_tokens_on_tokenhash (token_hash),
+	INDEX index_personal_access_tokens_on_userid (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
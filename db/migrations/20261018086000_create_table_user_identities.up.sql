@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	user_id BIGINT UNSIGNED NOT NULL,
+	provider VARCHAR(50) NOT NULL,
+	subject VARCHAR(255) NOT NULL,
+	email VARCHAR(255) NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE INDEX unique_user_identities_on_provider_subject (provider, subject),
+	UNIQUE INDEX unique_user_identities_on_userid_provider (user_id, provider)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN avatar_key,
+    DROP COLUMN locale,
+    DROP COLUMN timezone,
+    DROP COLUMN bio,
+    DROP COLUMN display_name;
\ No newline at end of file
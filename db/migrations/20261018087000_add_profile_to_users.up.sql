@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN display_name VARCHAR(100) NULL AFTER username,
+    ADD COLUMN bio VARCHAR(500) NULL AFTER display_name,
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC' AFTER bio,
+    ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT 'en' AFTER timezone,
+    ADD COLUMN avatar_key VARCHAR(64) NULL AFTER locale;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN token_version INT UNSIGNED NOT NULL DEFAULT 0 AFTER role;
\ No newline at end of file
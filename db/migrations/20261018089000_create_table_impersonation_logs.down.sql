@@ -0,0 +1 @@
+DROP TABLE IF EXISTS impersonation_logs;
\ No newline at end of file
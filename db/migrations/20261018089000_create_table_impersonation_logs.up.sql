@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS impersonation_logs (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	actor_id BIGINT UNSIGNED NOT NULL,
+	user_id BIGINT UNSIGNED NOT NULL,
+	token_id VARCHAR(36) NOT NULL,
+	method VARCHAR(10) NOT NULL,
+	path VARCHAR(2048) NOT NULL,
+	ip VARCHAR(45) NOT NULL,
+	request_id VARCHAR(64) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	INDEX index_impersonation_logs_on_actorid (actor_id),
+	INDEX index_impersonation_logs_on_userid (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN discoverability;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN discoverability VARCHAR(20) NOT NULL DEFAULT 'contacts' AFTER locale;
\ No newline at end of file
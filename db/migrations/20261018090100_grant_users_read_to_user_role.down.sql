@@ -0,0 +1,2 @@
+UPDATE roles SET permissions = JSON_REMOVE(permissions, JSON_UNQUOTE(JSON_SEARCH(permissions, 'one', 'users:read'))), updated_at = NOW()
+    WHERE `name` = 'user' AND JSON_CONTAINS(permissions, '"users:read"');
\ No newline at end of file
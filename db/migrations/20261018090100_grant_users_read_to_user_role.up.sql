@@ -0,0 +1,2 @@
+UPDATE roles SET permissions = JSON_ARRAY_APPEND(permissions, '$', 'users:read'), updated_at = NOW()
+    WHERE `name` = 'user' AND NOT JSON_CONTAINS(permissions, '"users:read"');
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP COLUMN password_reset_required_at,
+    DROP COLUMN suspended_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN suspended_at TIMESTAMP NULL AFTER token_version,
+    ADD COLUMN password_reset_required_at TIMESTAMP NULL AFTER suspended_at;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	actor_id BIGINT UNSIGNED NOT NULL,
+	user_id BIGINT UNSIGNED NOT NULL,
+	action VARCHAR(50) NOT NULL,
+	reason VARCHAR(500) NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	INDEX index_admin_audit_logs_on_actorid (actor_id),
+	INDEX index_admin_audit_logs_on_userid (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS security_events (
+	id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+	event_id VARCHAR(36) NOT NULL,
+	user_id BIGINT UNSIGNED NOT NULL,
+	`type` VARCHAR(50) NOT NULL,
+	session_id VARCHAR(36) NOT NULL,
+	ip VARCHAR(45) NOT NULL,
+	user_agent VARCHAR(512) NOT NULL,
+	request_id VARCHAR(64) NOT NULL,
+	occurred_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE INDEX unique_security_events_on_eventid (event_id),
+	INDEX index_security_events_on_userid_occurredat (user_id, occurred_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
\ No newline at end of file
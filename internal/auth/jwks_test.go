@@ -0,0 +1,71 @@
+package auth_test
+
+import (
+	"crypto"
+	"go-api-example/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONWebKey_PublicKey(t *testing.T) {
+	keys := []crypto.Signer{newRSAKey(t), newECKey(t), newEd25519Key(t)}
+
+	for _, key := range keys {
+		signingKey := newSigningKey(t, "key-1", key, time.Now())
+		jwks := auth.NewJSONWebKeySet([]*auth.SigningKey{signingKey})
+
+		publicKey, err := jwks.Keys[0].PublicKey()
+
+		assert.Nil(t, err)
+		assert.True(t, key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(publicKey), signingKey.Method.Alg())
+	}
+
+	tests := []struct {
+		name       string
+		key        auth.JSONWebKey
+		wantErrMsg string
+	}{
+		{
+			name:       "unsupported key type",
+			key:        auth.JSONWebKey{Kty: "oct"},
+			wantErrMsg: "unsupported key type oct",
+		},
+		{
+			name:       "unsupported curve",
+			key:        auth.JSONWebKey{Kty: "EC", Crv: "secp256k1"},
+			wantErrMsg: "unsupported ec curve secp256k1",
+		},
+		{
+			name:       "invalid encoding",
+			key:        auth.JSONWebKey{Kty: "RSA", N: "not base64!", E: "AQAB"},
+			wantErrMsg: "invalid jwk value: illegal base64 data at input byte 3",
+		},
+		{
+			name:       "short ed25519 key",
+			key:        auth.JSONWebKey{Kty: "OKP", Crv: "Ed25519", X: "AQAB"},
+			wantErrMsg: "invalid ed25519 key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicKey, err := tt.key.PublicKey()
+
+			assert.Nil(t, publicKey)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestJSONWebKeySet_Key(t *testing.T) {
+	single := &auth.JSONWebKeySet{Keys: []auth.JSONWebKey{{Kid: "key-1"}}}
+	multiple := &auth.JSONWebKeySet{Keys: []auth.JSONWebKey{{Kid: "key-1"}, {Kid: "key-2"}}}
+
+	assert.Equal(t, "key-2", multiple.Key("key-2").Kid)
+	assert.Equal(t, "key-1", single.Key("").Kid)
+	assert.Nil(t, multiple.Key(""))
+	assert.Nil(t, multiple.Key("key-3"))
+	assert.Nil(t, (*auth.JSONWebKeySet)(nil).Key("key-1"))
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -10,49 +11,122 @@ import (
 
 const (
 	PrefixRevokeKey = "revoke-jwt-token"
+
+	// PrefixTokenVersionKey caches the token version of a user, so the auth middleware rarely reads it from the database
+	PrefixTokenVersionKey = "user-token-version"
+	TokenVersionCacheTTL  = 15 * time.Minute
 )
 
 type JWTClaims struct {
-	UserID string `json:"user_id"`
+	UserID      string   `json:"user_id"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	SessionID   string   `json:"sid,omitempty"`
+	// TokenVersion is the token version of the user at issue time, a password change bumps it and invalidates the token
+	TokenVersion uint64 `json:"token_version"`
+	// Actor is set on impersonation tokens and names the admin acting as the user
+	Actor *Actor `json:"act,omitempty"`
+	// PersonalAccessTokenID is never signed into a jwt, it is only set on claims resolved from a personal access token
+	PersonalAccessTokenID uint64 `json:"-"`
 	jwt.RegisteredClaims
 }
 
+// Actor is the act claim of RFC 8693, the party acting on behalf of the subject
+type Actor struct {
+	UserID string `json:"sub"`
+}
+
 //go:generate mockery --name=JWTToken --structname JWTToken --outpkg=mocks --output=./../mocks
 type JWTToken interface {
-	Create(userID string) (string, error)
+	Create(subject *Subject) (string, *JWTClaims, error)
 	Parse(jwtToken string) (*JWTClaims, error)
+	JWKS() *JSONWebKeySet
 }
 
 type jwtToken struct {
-	SecretKey      string
+	KeySet         *KeySet
 	ExpireDuration time.Duration
 }
 
-func NewJWTToken(secretKey string, expireDuration time.Duration) JWTToken {
+// NewJWTToken signs with a single HS256 secret, tokens of such a key carry no kid
+func NewJWTToken(secretKey string, expireDuration time.Duration) (JWTToken, error) {
+	key, err := NewHMACSigningKey(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
 	return &jwtToken{
-		SecretKey:      secretKey,
+		KeySet:         &KeySet{Keys: []*SigningKey{key}},
+		ExpireDuration: expireDuration,
+	}, nil
+}
+
+func NewKeySetJWTToken(keySet *KeySet, expireDuration time.Duration) JWTToken {
+	return &jwtToken{
+		KeySet:         keySet,
 		ExpireDuration: expireDuration,
 	}
 }
 
-func (j *jwtToken) Create(userID string) (string, error) {
+func (j *jwtToken) Create(subject *Subject) (string, *JWTClaims, error) {
 	now := time.Now()
-	claims := JWTClaims{
-		UserID: userID,
+	key := j.KeySet.SigningKey(now)
+	if key == nil {
+		return "", nil, errors.New("no active jwt signing key")
+	}
+
+	expireDuration := j.ExpireDuration
+	if subject.ExpireDuration > 0 && subject.ExpireDuration < expireDuration {
+		expireDuration = subject.ExpireDuration
+	}
+
+	claims := &JWTClaims{
+		UserID:       subject.UserID,
+		Role:         subject.Role,
+		Permissions:  subject.Permissions,
+		SessionID:    subject.SessionID,
+		TokenVersion: subject.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(j.ExpireDuration)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expireDuration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ID:        uuid.NewString(),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.SecretKey))
+	if subject.ActorID != "" {
+		claims.Actor = &Actor{UserID: subject.ActorID}
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, claims, nil
 }
 
 func (j *jwtToken) Parse(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(t *jwt.Token) (any, error) {
-		return []byte(j.SecretKey), nil
+		kid, _ := t.Header["kid"].(string)
+		key := j.KeySet.VerificationKey(kid, time.Now())
+		if key == nil {
+			return nil, fmt.Errorf("unknown jwt key %q", kid)
+		}
+
+		// the algorithm of the header is only trusted when it matches the key
+		if t.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected jwt signing method %s", t.Method.Alg())
+		}
+
+		if key.PublicKey != nil {
+			return key.PublicKey, nil
+		}
+		return key.PrivateKey, nil
 	})
 	if err != nil {
 		return nil, err
@@ -73,3 +147,7 @@ func (j *jwtToken) Parse(tokenString string) (*JWTClaims, error) {
 
 	return claims, nil
 }
+
+func (j *jwtToken) JWKS() *JSONWebKeySet {
+	return NewJSONWebKeySet(j.KeySet.PublicKeys(time.Now()))
+}
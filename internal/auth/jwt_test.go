@@ -1,11 +1,13 @@
 package auth_test
 
 import (
+	"crypto/x509"
 	"go-api-example/internal/auth"
 	"log"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,20 +25,27 @@ func TestJWTToken_Create(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "sucesss with empty secret",
+			name:      "error with empty secret",
 			userID:    "1",
 			secretKey: "",
-			wantErr:   false,
+			wantErr:   true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			jwt := auth.NewJWTToken(tt.secretKey, time.Second)
-			token, err := jwt.Create(tt.userID)
+			jwt, err := auth.NewJWTToken(tt.secretKey, time.Second)
+			if tt.wantErr {
+				assert.EqualError(t, err, "empty jwt secret key")
+				return
+			}
 
-			assert.Equal(t, tt.wantErr, err != nil)
+			token, claims, err := jwt.Create(&auth.Subject{UserID: tt.userID})
+
+			assert.Nil(t, err)
 			assert.NotEmpty(t, token)
+			assert.Equal(t, tt.userID, claims.UserID)
+			assert.NotEmpty(t, claims.ID)
 		})
 	}
 }
@@ -44,8 +53,13 @@ func TestJWTToken_Create(t *testing.T) {
 func TestJWTToken_Parse(t *testing.T) {
 	validSecret := "valid-secret"
 	invalidSecret := "invalid-secret"
-	jwt := auth.NewJWTToken(validSecret, 10*time.Second)
-	validToken, _ := jwt.Create("1")
+	jwt, _ := auth.NewJWTToken(validSecret, 10*time.Second)
+	validToken, _, _ := jwt.Create(&auth.Subject{
+		UserID:       "1",
+		Role:         auth.RoleUser,
+		Permissions:  []string{auth.PermissionTodoRead},
+		TokenVersion: 3,
+	})
 
 	tests := []struct {
 		name       string
@@ -62,9 +76,8 @@ func TestJWTToken_Parse(t *testing.T) {
 		{
 			name: "invalid token",
 			token: func() string {
-				invToken, _ := auth.
-					NewJWTToken(invalidSecret, 10*time.Second).
-					Create("1")
+				invJWT, _ := auth.NewJWTToken(invalidSecret, 10*time.Second)
+				invToken, _, _ := invJWT.Create(&auth.Subject{UserID: "1"})
 				return invToken
 			}(),
 			wantErrMsg: "token signature is invalid: signature is invalid",
@@ -72,9 +85,8 @@ func TestJWTToken_Parse(t *testing.T) {
 		{
 			name: "expired token",
 			token: func() string {
-				expToken, _ := auth.
-					NewJWTToken(validSecret, 10*time.Millisecond).
-					Create("1")
+				expJWT, _ := auth.NewJWTToken(validSecret, 10*time.Millisecond)
+				expToken, _, _ := expJWT.Create(&auth.Subject{UserID: "1"})
 				// wait token expired
 				time.Sleep(20 * time.Millisecond)
 				return expToken
@@ -94,8 +106,161 @@ func TestJWTToken_Parse(t *testing.T) {
 				assert.Equal(t, tt.wantErrMsg, err.Error())
 			} else {
 				assert.Equal(t, tt.wantUser, claims.UserID)
+				assert.Equal(t, auth.RoleUser, claims.Role)
+				assert.Equal(t, []string{auth.PermissionTodoRead}, claims.Permissions)
+				assert.Equal(t, uint64(3), claims.TokenVersion)
 				assert.Nil(t, err)
 			}
 		})
 	}
 }
+
+func TestJWTToken_Impersonation(t *testing.T) {
+	jwt, _ := auth.NewJWTToken("dummy-secret", time.Hour)
+
+	token, claims, err := jwt.Create(&auth.Subject{
+		UserID:         "2",
+		ActorID:        "1",
+		ExpireDuration: time.Minute,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &auth.Actor{UserID: "1"}, claims.Actor)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), claims.ExpiresAt.Time, 2*time.Second)
+
+	parsed, err := jwt.Parse(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "2", parsed.UserID)
+	assert.True(t, parsed.IsImpersonated())
+	assert.Equal(t, "1", parsed.Actor.UserID)
+
+	_, claims, err = jwt.Create(&auth.Subject{UserID: "2", ExpireDuration: 2 * time.Hour})
+	assert.Nil(t, err)
+	assert.Nil(t, claims.Actor)
+	assert.False(t, claims.IsImpersonated())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, 2*time.Second)
+}
+
+func TestKeySetJWTToken(t *testing.T) {
+	now := time.Now()
+	rsaKey := newSigningKey(t, "rsa-1", newRSAKey(t), now.Add(-3*time.Hour))
+	ecKey := newSigningKey(t, "ec-1", newECKey(t), now.Add(-2*time.Hour))
+	edKey := newSigningKey(t, "ed-1", newEd25519Key(t), now.Add(-time.Minute))
+	nextKey := newSigningKey(t, "ed-2", newEd25519Key(t), now.Add(time.Hour))
+
+	tests := []struct {
+		name       string
+		signKeys   []*auth.SigningKey
+		verifyKeys []*auth.SigningKey
+		wantAlg    string
+		wantErrMsg string
+	}{
+		{
+			name:       "rsa",
+			signKeys:   []*auth.SigningKey{rsaKey},
+			verifyKeys: []*auth.SigningKey{rsaKey},
+			wantAlg:    "RS256",
+		},
+		{
+			name:       "ecdsa",
+			signKeys:   []*auth.SigningKey{ecKey},
+			verifyKeys: []*auth.SigningKey{ecKey},
+			wantAlg:    "ES256",
+		},
+		{
+			name:       "newest active key signs",
+			signKeys:   []*auth.SigningKey{rsaKey, ecKey, edKey, nextKey},
+			verifyKeys: []*auth.SigningKey{edKey},
+			wantAlg:    "EdDSA",
+		},
+		{
+			name:       "old key within grace period",
+			signKeys:   []*auth.SigningKey{ecKey},
+			verifyKeys: []*auth.SigningKey{ecKey, edKey},
+			wantAlg:    "ES256",
+		},
+		{
+			name:       "old key after grace period",
+			signKeys:   []*auth.SigningKey{rsaKey},
+			verifyKeys: []*auth.SigningKey{rsaKey, ecKey},
+			wantErrMsg: `token is unverifiable: error while executing keyfunc: unknown jwt key "rsa-1"`,
+		},
+		{
+			name:       "unknown key",
+			signKeys:   []*auth.SigningKey{rsaKey},
+			verifyKeys: []*auth.SigningKey{ecKey},
+			wantErrMsg: `token is unverifiable: error while executing keyfunc: unknown jwt key "rsa-1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signKeySet, _ := auth.NewKeySet(tt.signKeys, 10*time.Minute)
+			verifyKeySet, _ := auth.NewKeySet(tt.verifyKeys, 10*time.Minute)
+
+			token, _, err := auth.NewKeySetJWTToken(signKeySet, time.Minute).Create(&auth.Subject{UserID: "1"})
+			assert.Nil(t, err)
+
+			claims, err := auth.NewKeySetJWTToken(verifyKeySet, time.Minute).Parse(token)
+			if tt.wantErrMsg != "" {
+				assert.Nil(t, claims)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "1", claims.UserID)
+				assert.Equal(t, tt.wantAlg, tokenHeader(t, token)["alg"])
+				assert.Equal(t, tt.verifyKeys[0].ID, tokenHeader(t, token)["kid"])
+			}
+		})
+	}
+}
+
+func TestKeySetJWTToken_AlgorithmConfusion(t *testing.T) {
+	rsaKey := newSigningKey(t, "rsa-1", newRSAKey(t), time.Time{})
+	keySet, _ := auth.NewKeySet([]*auth.SigningKey{rsaKey}, time.Minute)
+	publicDER, _ := x509.MarshalPKIXPublicKey(rsaKey.PublicKey)
+
+	// an HS256 token signed with the public key must not be accepted for an RSA key
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.JWTClaims{
+		UserID: "1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	token.Header["kid"] = "rsa-1"
+	forged, _ := token.SignedString(publicDER)
+
+	claims, err := auth.NewKeySetJWTToken(keySet, time.Minute).Parse(forged)
+
+	assert.Nil(t, claims)
+	assert.EqualError(t, err, "token is unverifiable: error while executing keyfunc: unexpected jwt signing method HS256")
+}
+
+func TestJWTToken_JWKS(t *testing.T) {
+	hmac, _ := auth.NewJWTToken("dummy-secret", time.Minute)
+	assert.Equal(t, &auth.JSONWebKeySet{Keys: []auth.JSONWebKey{}}, hmac.JWKS())
+
+	now := time.Now()
+	keySet, _ := auth.NewKeySet([]*auth.SigningKey{
+		newSigningKey(t, "rsa-1", newRSAKey(t), now.Add(-time.Hour)),
+		newSigningKey(t, "ec-1", newECKey(t), now.Add(-time.Minute)),
+		newSigningKey(t, "ed-1", newEd25519Key(t), now.Add(time.Hour)),
+	}, 10*time.Minute)
+
+	jwks := auth.NewKeySetJWTToken(keySet, time.Minute).JWKS()
+
+	assert.Len(t, jwks.Keys, 3)
+	assert.Equal(t, []string{"rsa-1", "ec-1", "ed-1"}, []string{jwks.Keys[0].Kid, jwks.Keys[1].Kid, jwks.Keys[2].Kid})
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.Equal(t, "EC", jwks.Keys[1].Kty)
+	assert.Equal(t, "P-256", jwks.Keys[1].Crv)
+	assert.Len(t, jwks.Keys[1].X, 43)
+	assert.Equal(t, "OKP", jwks.Keys[2].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[2].Alg)
+}
+
+func tokenHeader(t *testing.T, token string) map[string]any {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &auth.JWTClaims{})
+	assert.Nil(t, err)
+	return parsed.Header
+}
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is one key of the key set. A key signs new tokens from ActiveFrom until the next key becomes active,
+// after that it only verifies the tokens it already signed for the grace period of the key set
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey any
+	PublicKey  any
+	ActiveFrom time.Time
+}
+
+type KeySet struct {
+	Keys        []*SigningKey
+	GracePeriod time.Duration
+}
+
+func NewKeySet(keys []*SigningKey, gracePeriod time.Duration) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no keys")
+	}
+
+	ids := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if ids[key.ID] {
+			return nil, fmt.Errorf("duplicate key id %s", key.ID)
+		}
+		ids[key.ID] = true
+	}
+
+	sorted := slices.Clone(keys)
+	slices.SortStableFunc(sorted, func(a, b *SigningKey) int {
+		return a.ActiveFrom.Compare(b.ActiveFrom)
+	})
+
+	return &KeySet{
+		Keys:        sorted,
+		GracePeriod: gracePeriod,
+	}, nil
+}
+
+// SigningKey returns the key that signs tokens at the given time
+func (k *KeySet) SigningKey(now time.Time) *SigningKey {
+	var current *SigningKey
+	for _, key := range k.Keys {
+		if key.ActiveFrom.After(now) {
+			break
+		}
+		current = key
+	}
+
+	return current
+}
+
+// VerificationKey returns the key with the given id when it may still verify tokens at the given time
+func (k *KeySet) VerificationKey(id string, now time.Time) *SigningKey {
+	for i, key := range k.Keys {
+		if key.ID != id {
+			continue
+		}
+
+		if key.ActiveFrom.After(now) {
+			return nil
+		}
+
+		if i+1 < len(k.Keys) && !k.Keys[i+1].ActiveFrom.After(now) &&
+			now.Sub(k.Keys[i+1].ActiveFrom) > k.GracePeriod {
+			return nil
+		}
+
+		return key
+	}
+
+	return nil
+}
+
+// PublicKeys returns the keys to publish at the given time, keys scheduled for later are included so that
+// verifiers already know them when they start signing
+func (k *KeySet) PublicKeys(now time.Time) []*SigningKey {
+	keys := make([]*SigningKey, 0, len(k.Keys))
+	for _, key := range k.Keys {
+		if key.PublicKey == nil {
+			continue
+		}
+
+		if key.ActiveFrom.After(now) || k.VerificationKey(key.ID, now) != nil {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func NewHMACSigningKey(secretKey string) (*SigningKey, error) {
+	if secretKey == "" {
+		return nil, errors.New("empty jwt secret key")
+	}
+
+	return &SigningKey{
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: []byte(secretKey),
+	}, nil
+}
+
+func LoadSigningKey(id string, path string, activeFrom time.Time) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", id, err)
+	}
+
+	return ParseSigningKey(id, data, activeFrom)
+}
+
+// ParseSigningKey reads a PEM encoded RSA, ECDSA or Ed25519 private key, the signing method follows from the key type
+func ParseSigningKey(id string, data []byte, activeFrom time.Time) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s is not PEM encoded", id)
+	}
+
+	var privateKey any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s has unsupported PEM type %s", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %s: %w", id, err)
+	}
+
+	method, err := signingMethod(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	return &SigningKey{
+		ID:         id,
+		Method:     method,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.(crypto.Signer).Public(),
+		ActiveFrom: activeFrom,
+	}, nil
+}
+
+func signingMethod(privateKey any) (jwt.SigningMethod, error) {
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, errors.New("unsupported ecdsa curve")
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %T", privateKey)
+}
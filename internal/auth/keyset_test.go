@@ -0,0 +1,194 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"go-api-example/internal/auth"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRSAKey(t *testing.T) crypto.Signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	return key
+}
+
+func newECKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T) crypto.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	return key
+}
+
+func encodePKCS8(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func newSigningKey(t *testing.T, id string, key crypto.Signer, activeFrom time.Time) *auth.SigningKey {
+	signingKey, err := auth.ParseSigningKey(id, encodePKCS8(t, key), activeFrom)
+	assert.Nil(t, err)
+	return signingKey
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaKey := newRSAKey(t).(*rsa.PrivateKey)
+	ecKey := newECKey(t).(*ecdsa.PrivateKey)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantAlg    string
+		wantErrMsg string
+	}{
+		{
+			name:    "pkcs1 rsa",
+			data:    pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			wantAlg: "RS256",
+		},
+		{
+			name:    "sec1 ecdsa",
+			data:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			wantAlg: "ES256",
+		},
+		{
+			name:    "pkcs8 ecdsa p-384",
+			data:    encodePKCS8(t, p384Key),
+			wantAlg: "ES384",
+		},
+		{
+			name:    "pkcs8 ed25519",
+			data:    encodePKCS8(t, newEd25519Key(t)),
+			wantAlg: "EdDSA",
+		},
+		{
+			name:       "not pem",
+			data:       []byte("dummy"),
+			wantErrMsg: "key key-1 is not PEM encoded",
+		},
+		{
+			name:       "public key",
+			data:       pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("dummy")}),
+			wantErrMsg: "key key-1 has unsupported PEM type PUBLIC KEY",
+		},
+		{
+			name:       "malformed key",
+			data:       pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("dummy")}),
+			wantErrMsg: "failed to parse key key-1: asn1: structure error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := auth.ParseSigningKey("key-1", tt.data, time.Time{})
+
+			if tt.wantErrMsg != "" {
+				assert.Nil(t, key)
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "key-1", key.ID)
+				assert.Equal(t, tt.wantAlg, key.Method.Alg())
+				assert.NotNil(t, key.PublicKey)
+			}
+		})
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	assert.Nil(t, os.WriteFile(path, encodePKCS8(t, newEd25519Key(t)), 0o600))
+
+	key, err := auth.LoadSigningKey("key-1", path, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, "EdDSA", key.Method.Alg())
+
+	_, err = auth.LoadSigningKey("key-2", filepath.Join(t.TempDir(), "missing.pem"), time.Time{})
+	assert.ErrorContains(t, err, "failed to read key key-2")
+}
+
+func TestNewKeySet(t *testing.T) {
+	key := newSigningKey(t, "key-1", newEd25519Key(t), time.Time{})
+
+	_, err := auth.NewKeySet(nil, time.Minute)
+	assert.EqualError(t, err, "key set has no keys")
+
+	_, err = auth.NewKeySet([]*auth.SigningKey{key, key}, time.Minute)
+	assert.EqualError(t, err, "duplicate key id key-1")
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	now := time.Now()
+	first := newSigningKey(t, "key-1", newEd25519Key(t), time.Time{})
+	second := newSigningKey(t, "key-2", newEd25519Key(t), now.Add(time.Hour))
+	keySet, _ := auth.NewKeySet([]*auth.SigningKey{second, first}, 10*time.Minute)
+
+	tests := []struct {
+		name           string
+		at             time.Time
+		wantSigning    string
+		wantVerifying  []string
+		wantPublicKeys []string
+	}{
+		{
+			name:           "before rotation",
+			at:             now,
+			wantSigning:    "key-1",
+			wantVerifying:  []string{"key-1"},
+			wantPublicKeys: []string{"key-1", "key-2"},
+		},
+		{
+			name:           "within grace period",
+			at:             now.Add(time.Hour + 5*time.Minute),
+			wantSigning:    "key-2",
+			wantVerifying:  []string{"key-1", "key-2"},
+			wantPublicKeys: []string{"key-1", "key-2"},
+		},
+		{
+			name:           "after grace period",
+			at:             now.Add(time.Hour + 11*time.Minute),
+			wantSigning:    "key-2",
+			wantVerifying:  []string{"key-2"},
+			wantPublicKeys: []string{"key-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantSigning, keySet.SigningKey(tt.at).ID)
+
+			verifying := []string{}
+			for _, id := range []string{"key-1", "key-2", "key-3"} {
+				if keySet.VerificationKey(id, tt.at) != nil {
+					verifying = append(verifying, id)
+				}
+			}
+			assert.Equal(t, tt.wantVerifying, verifying)
+
+			publicKeys := []string{}
+			for _, key := range keySet.PublicKeys(tt.at) {
+				publicKeys = append(publicKeys, key.ID)
+			}
+			assert.Equal(t, tt.wantPublicKeys, publicKeys)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	PrefixOIDCStateKey = "oidc-state"
+	OIDCStateTTL       = 10 * time.Minute
+
+	// oidcJWKSRefreshInterval limits how often an unknown kid makes us fetch the cached provider keys again
+	oidcJWKSRefreshInterval = 1 * time.Minute
+	oidcClockSkew           = 1 * time.Minute
+	oidcMaxResponseSize     = 1 << 20
+)
+
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// OIDCClaims are the claims of a verified id token
+type OIDCClaims struct {
+	Nonce             string `json:"nonce,omitempty"`
+	AuthorizedParty   string `json:"azp,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// OIDCProvider runs the authorization code flow with PKCE against an OpenID Connect provider
+//
+//go:generate mockery --name=OIDCProvider --structname OIDCProvider --outpkg=mocks --output=./../mocks
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OIDCClaims, error)
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcProvider struct {
+	Config     OIDCProviderConfig
+	HTTPClient *http.Client
+
+	mu              sync.Mutex
+	discovery       *oidcDiscovery
+	jwks            *JSONWebKeySet
+	jwksRefreshedAt time.Time
+}
+
+// NewOIDCProvider discovers the provider endpoints lazily, so the api still starts while a provider is unreachable
+func NewOIDCProvider(cfg OIDCProviderConfig, httpClient *http.Client) OIDCProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &oidcProvider{
+		Config:     cfg,
+		HTTPClient: httpClient,
+	}
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.Config.ClientID)
+	q.Set("redirect_uri", p.Config.RedirectURL)
+	q.Set("scope", strings.Join(p.Config.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OIDCClaims, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.Config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.Config.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.Config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	err = p.doJSON(req, &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no id token")
+	}
+
+	return p.verifyIDToken(ctx, discovery, token.IDToken, nonce)
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, idToken string, nonce string) (*OIDCClaims, error) {
+	token, err := jwt.ParseWithClaims(idToken, &OIDCClaims{}, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := p.verificationKey(ctx, discovery, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		// a key that names its algorithm is only used with that algorithm
+		if key.Alg != "" && key.Alg != t.Method.Alg() {
+			return nil, fmt.Errorf("unexpected id token signing method %s", t.Method.Alg())
+		}
+
+		return key.PublicKey()
+	},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*OIDCClaims)
+	if !ok {
+		return nil, errors.New("invalid id token claims")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	if (len(claims.Audience) > 1 || claims.AuthorizedParty != "") && claims.AuthorizedParty != p.Config.ClientID {
+		return nil, errors.New("id token is authorized for another party")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	return claims, nil
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	wellKnown := strings.TrimSuffix(p.Config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := new(oidcDiscovery)
+	err = p.doJSON(req, discovery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover provider %s: %w", p.Config.Name, err)
+	}
+
+	if discovery.Issuer != p.Config.Issuer {
+		return nil, fmt.Errorf("provider %s reports issuer %s", p.Config.Name, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("provider %s discovery is incomplete", p.Config.Name)
+	}
+
+	p.discovery = discovery
+	return discovery, nil
+}
+
+// verificationKey refetches the provider keys when the kid is unknown, that's how a provider rotation shows up
+func (p *oidcProvider) verificationKey(ctx context.Context, discovery *oidcDiscovery, kid string) (*JSONWebKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := p.jwks.Key(kid)
+	if key == nil && (p.jwks == nil || time.Since(p.jwksRefreshedAt) >= oidcJWKSRefreshInterval) {
+		if p.jwks != nil {
+			p.jwksRefreshedAt = time.Now()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		jwks := new(JSONWebKeySet)
+		err = p.doJSON(req, jwks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch provider keys: %w", err)
+		}
+
+		p.jwks = jwks
+		key = p.jwks.Key(kid)
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("unknown id token key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (p *oidcProvider) doJSON(req *http.Request, v any) error {
+	res, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, req.URL.Path)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, oidcMaxResponseSize)).Decode(v)
+}
+
+// GeneratePKCEVerifier creates the code verifier of RFC 7636, it never leaves the server until the code exchange
+func GeneratePKCEVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,212 @@
+package auth_test
+
+import (
+	"context"
+	"go-api-example/internal/auth"
+	"go-api-example/test"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const oidcRedirectURL = "http://localhost:3000/api/oidc/mock/callback"
+
+func newOIDCProvider(server *test.OIDCServer, clientSecret string) auth.OIDCProvider {
+	return auth.NewOIDCProvider(auth.OIDCProviderConfig{
+		Name:         "mock",
+		Issuer:       server.URL,
+		ClientID:     server.ClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  oidcRedirectURL,
+		Scopes:       []string{"openid", "email"},
+	}, server.Client())
+}
+
+// authorizeOIDC runs the browser part of the flow and returns the code and the verifier that belongs to it
+func authorizeOIDC(t *testing.T, server *test.OIDCServer, provider auth.OIDCProvider, nonce string) (string, string) {
+	verifier, err := auth.GeneratePKCEVerifier()
+	assert.Nil(t, err)
+
+	authCodeURL, err := provider.AuthCodeURL(context.Background(), "dummy-state", nonce, auth.PKCEChallenge(verifier))
+	assert.Nil(t, err)
+
+	callback, err := server.Authorize(authCodeURL)
+	assert.Nil(t, err)
+	assert.Equal(t, "dummy-state", callback.Query().Get("state"))
+
+	return callback.Query().Get("code"), verifier
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	server := test.NewOIDCServer("dummy-client", "")
+	defer server.Close()
+
+	authCodeURL, err := newOIDCProvider(server, "").AuthCodeURL(context.Background(), "dummy-state", "dummy-nonce", "dummy-challenge")
+	assert.Nil(t, err)
+
+	u, err := url.Parse(authCodeURL)
+	assert.Nil(t, err)
+	assert.Equal(t, server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"dummy-client"},
+		"redirect_uri":          {oidcRedirectURL},
+		"scope":                 {"openid email"},
+		"state":                 {"dummy-state"},
+		"nonce":                 {"dummy-nonce"},
+		"code_challenge":        {"dummy-challenge"},
+		"code_challenge_method": {"S256"},
+	}, u.Query())
+}
+
+func TestOIDCProvider_Discovery(t *testing.T) {
+	server := test.NewOIDCServer("dummy-client", "")
+	defer server.Close()
+
+	provider := auth.NewOIDCProvider(auth.OIDCProviderConfig{
+		Name:     "mock",
+		Issuer:   server.URL + "/",
+		ClientID: server.ClientID,
+	}, server.Client())
+
+	_, err := provider.AuthCodeURL(context.Background(), "dummy-state", "dummy-nonce", "dummy-challenge")
+	assert.EqualError(t, err, "provider mock reports issuer "+server.URL)
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientSecret  string
+		nonce         string
+		verifier      string
+		idTokenClaims func(claims jwt.MapClaims)
+		wantErrMsg    string
+	}{
+		{
+			name:         "success",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+		},
+		{
+			name:         "wrong client secret",
+			clientSecret: "wrong-secret",
+			nonce:        "dummy-nonce",
+			wantErrMsg:   "failed to exchange code: unexpected status 401 from /token",
+		},
+		{
+			name:         "wrong code verifier",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+			verifier:     "wrong-verifier",
+			wantErrMsg:   "failed to exchange code: unexpected status 400 from /token",
+		},
+		{
+			name:         "nonce mismatch",
+			clientSecret: "dummy-secret",
+			nonce:        "other-nonce",
+			wantErrMsg:   "id token nonce mismatch",
+		},
+		{
+			name:         "wrong audience",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+			idTokenClaims: func(claims jwt.MapClaims) {
+				claims["aud"] = "other-client"
+			},
+			wantErrMsg: "invalid id token: token has invalid claims: token has invalid audience",
+		},
+		{
+			name:         "wrong issuer",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+			idTokenClaims: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://attacker.example.com"
+			},
+			wantErrMsg: "invalid id token: token has invalid claims: token has invalid issuer",
+		},
+		{
+			name:         "expired",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+			idTokenClaims: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			},
+			wantErrMsg: "invalid id token: token has invalid claims: token is expired",
+		},
+		{
+			name:         "missing subject",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+			idTokenClaims: func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			},
+			wantErrMsg: "id token has no subject",
+		},
+		{
+			name:         "authorized for another party",
+			clientSecret: "dummy-secret",
+			nonce:        "dummy-nonce",
+			idTokenClaims: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{"dummy-client", "other-client"}
+				claims["azp"] = "other-client"
+			},
+			wantErrMsg: "id token is authorized for another party",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := test.NewOIDCServer("dummy-client", "dummy-secret")
+			defer server.Close()
+			server.IDTokenClaims = tt.idTokenClaims
+
+			provider := newOIDCProvider(server, tt.clientSecret)
+			code, verifier := authorizeOIDC(t, server, provider, "dummy-nonce")
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+
+			claims, err := provider.Exchange(context.Background(), code, verifier, tt.nonce)
+
+			if tt.wantErrMsg != "" {
+				assert.Nil(t, claims)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "oidc-subject", claims.Subject)
+			assert.Equal(t, "user@example.com", claims.Email)
+			assert.True(t, claims.EmailVerified)
+			assert.Equal(t, "OIDC User", claims.Name)
+		})
+	}
+}
+
+func TestOIDCProvider_KeyRotation(t *testing.T) {
+	server := test.NewOIDCServer("dummy-client", "")
+	defer server.Close()
+	provider := newOIDCProvider(server, "")
+
+	code, verifier := authorizeOIDC(t, server, provider, "dummy-nonce")
+	_, err := provider.Exchange(context.Background(), code, verifier, "dummy-nonce")
+	assert.Nil(t, err)
+
+	server.RotateKey()
+
+	code, verifier = authorizeOIDC(t, server, provider, "dummy-nonce")
+	claims, err := provider.Exchange(context.Background(), code, verifier, "dummy-nonce")
+	assert.Nil(t, err)
+	assert.Equal(t, "oidc-subject", claims.Subject)
+
+	// a second unknown key within the refresh interval doesn't hit the provider again
+	server.RotateKey()
+
+	code, verifier = authorizeOIDC(t, server, provider, "dummy-nonce")
+	claims, err = provider.Exchange(context.Background(), code, verifier, "dummy-nonce")
+	assert.Nil(t, claims)
+	assert.EqualError(t, err, `invalid id token: token is unverifiable: error while executing keyfunc: unknown id token key "key-3"`)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrPasswordTooShort  = errors.New("password is too short")
+	ErrPasswordTooWeak   = errors.New("password misses a required character class")
+	ErrPasswordTooCommon = errors.New("password is too common")
+)
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+type PasswordPolicyConfig struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// BlocklistFile adds breached passwords, one per line, to the built-in list of common passwords
+	BlocklistFile string
+}
+
+type PasswordPolicy struct {
+	Config    PasswordPolicyConfig
+	blocklist map[string]struct{}
+}
+
+func NewPasswordPolicy(cfg PasswordPolicyConfig) (*PasswordPolicy, error) {
+	if cfg.MinLength < 1 {
+		return nil, errors.New("password min length must be positive")
+	}
+
+	p := &PasswordPolicy{
+		Config:    cfg,
+		blocklist: map[string]struct{}{},
+	}
+
+	err := p.loadBlocklist(strings.NewReader(commonPasswords))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.BlocklistFile != "" {
+		f, err := os.Open(cfg.BlocklistFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		err = p.loadBlocklist(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *PasswordPolicy) loadBlocklist(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.blocklist[strings.ToLower(line)] = struct{}{}
+	}
+
+	return scanner.Err()
+}
+
+func (p *PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.Config.MinLength {
+		return ErrPasswordTooShort
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	if (p.Config.RequireUpper && !upper) || (p.Config.RequireLower && !lower) ||
+		(p.Config.RequireDigit && !digit) || (p.Config.RequireSymbol && !symbol) {
+		return ErrPasswordTooWeak
+	}
+
+	if _, ok := p.blocklist[strings.ToLower(password)]; ok {
+		return ErrPasswordTooCommon
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package auth_test
+
+import (
+	"go-api-example/internal/auth"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var argon2idConfig = auth.PasswordHashConfig{
+	Algorithm:         auth.PasswordHashArgon2id,
+	Argon2Memory:      1024,
+	Argon2Iterations:  1,
+	Argon2Parallelism: 1,
+}
+
+var bcryptConfig = auth.PasswordHashConfig{
+	Algorithm:  auth.PasswordHashBcrypt,
+	BcryptCost: bcrypt.MinCost,
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        auth.PasswordHashConfig
+		wantErrMsg string
+	}{
+		{
+			name:       "unsupported algorithm",
+			cfg:        auth.PasswordHashConfig{Algorithm: "md5"},
+			wantErrMsg: `unsupported password hash algorithm "md5"`,
+		},
+		{
+			name:       "invalid bcrypt cost",
+			cfg:        auth.PasswordHashConfig{Algorithm: auth.PasswordHashBcrypt, BcryptCost: 2},
+			wantErrMsg: "bcrypt cost must be between 4 and 31",
+		},
+		{
+			name:       "invalid argon2id parameters",
+			cfg:        auth.PasswordHashConfig{Algorithm: auth.PasswordHashArgon2id, Argon2Memory: 1024, Argon2Parallelism: 1},
+			wantErrMsg: "argon2id needs at least one iteration, one thread and 8 KiB of memory per thread",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := auth.NewPasswordHasher(tt.cfg)
+
+			assert.Nil(t, hasher)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestPasswordHasher_Compare(t *testing.T) {
+	for _, cfg := range []auth.PasswordHashConfig{bcryptConfig, argon2idConfig} {
+		hasher, err := auth.NewPasswordHasher(cfg)
+		assert.Nil(t, err)
+
+		hash, err := hasher.Hash("correct horse")
+		assert.Nil(t, err)
+
+		assert.Nil(t, hasher.Compare(hash, "correct horse"), cfg.Algorithm)
+		assert.ErrorIs(t, hasher.Compare(hash, "battery staple"), auth.ErrPasswordMismatch, cfg.Algorithm)
+		assert.ErrorIs(t, hasher.Compare("", "correct horse"), auth.ErrPasswordMismatch, cfg.Algorithm)
+	}
+
+	hasher, _ := auth.NewPasswordHasher(argon2idConfig)
+
+	// hashes of the other algorithm keep working after the configuration changed
+	bcryptHash, _ := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	assert.Nil(t, hasher.Compare(string(bcryptHash), "correct horse"))
+
+	assert.ErrorIs(t, hasher.Compare("$argon2id$v=19$m=1024,t=1,p=1$c2FsdA", "correct horse"), auth.ErrInvalidPasswordHash)
+	assert.ErrorIs(t, hasher.Compare("$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5", "correct horse"), auth.ErrInvalidPasswordHash)
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	argon2idHasher, _ := auth.NewPasswordHasher(argon2idConfig)
+	argon2idHash, _ := argon2idHasher.Hash("correct horse")
+
+	bcryptHasher, _ := auth.NewPasswordHasher(bcryptConfig)
+	bcryptHash, _ := bcryptHasher.Hash("correct horse")
+
+	strongerConfig := argon2idConfig
+	strongerConfig.Argon2Iterations = 2
+	strongerHasher, _ := auth.NewPasswordHasher(strongerConfig)
+
+	assert.False(t, argon2idHasher.NeedsRehash(argon2idHash))
+	assert.True(t, argon2idHasher.NeedsRehash(bcryptHash))
+	assert.True(t, strongerHasher.NeedsRehash(argon2idHash))
+	assert.False(t, bcryptHasher.NeedsRehash(bcryptHash))
+	assert.True(t, bcryptHasher.NeedsRehash(argon2idHash))
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	blocklist := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(blocklist, []byte("# breached\nTr0ub4dor&3\n"), 0o600)
+	assert.Nil(t, err)
+
+	policy, err := auth.NewPasswordPolicy(auth.PasswordPolicyConfig{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		BlocklistFile: blocklist,
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "too short",
+			password: "Ab1!",
+			wantErr:  auth.ErrPasswordTooShort,
+		},
+		{
+			name:     "length counts characters",
+			password: strings.Repeat("é", 7) + "A1!",
+			wantErr:  nil,
+		},
+		{
+			name:     "missing upper",
+			password: "abcdefg1!",
+			wantErr:  auth.ErrPasswordTooWeak,
+		},
+		{
+			name:     "missing symbol",
+			password: "Abcdefg12",
+			wantErr:  auth.ErrPasswordTooWeak,
+		},
+		{
+			name:     "breached password",
+			password: "tR0UB4DOR&3",
+			wantErr:  auth.ErrPasswordTooCommon,
+		},
+		{
+			name:     "success",
+			password: "Correct-Horse-7",
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+
+	lenient, err := auth.NewPasswordPolicy(auth.PasswordPolicyConfig{MinLength: 8})
+	assert.Nil(t, err)
+	assert.Equal(t, auth.ErrPasswordTooCommon, lenient.Validate("Password123"))
+	assert.Equal(t, auth.ErrPasswordTooCommon, lenient.Validate("qwertyuiop"))
+	assert.Nil(t, lenient.Validate("correct horse"))
+
+	_, err = auth.NewPasswordPolicy(auth.PasswordPolicyConfig{MinLength: 8, BlocklistFile: filepath.Join(t.TempDir(), "missing.txt")})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+
+	// PermissionAll is granted to the admin role and matches every permission
+	PermissionAll               = "*"
+	PermissionUserRead          = "users:read"
+	PermissionUserManage        = "users:manage"
+	PermissionUserImpersonate   = "users:impersonate"
+	PermissionTodoRead          = "todos:read"
+	PermissionTodoWrite         = "todos:write"
+	PermissionNotificationRead  = "notifications:read"
+	PermissionNotificationWrite = "notifications:write"
+)
+
+// PersonalAccessTokenScopes are the only permissions a personal access token may carry, the wildcard and user management stay with login sessions
+var PersonalAccessTokenScopes = []string{
+	PermissionUserRead,
+	PermissionTodoRead,
+	PermissionTodoWrite,
+	PermissionNotificationRead,
+	PermissionNotificationWrite,
+}
+
+type Subject struct {
+	UserID       string
+	Role         string
+	Permissions  []string
+	SessionID    string
+	TokenVersion uint64
+	// ActorID is the admin impersonating the user, it is signed into the act claim
+	ActorID string
+	// ExpireDuration shortens the lifetime of the token when it is below the default one
+	ExpireDuration time.Duration
+}
+
+func (c *JWTClaims) HasPermission(permission string) bool {
+	if c == nil {
+		return false
+	}
+
+	return GrantsPermission(c.Permissions, permission)
+}
+
+// IsPersonalAccessToken reports whether the claims were resolved from a personal access token instead of a login session
+func (c *JWTClaims) IsPersonalAccessToken() bool {
+	return c != nil && c.PersonalAccessTokenID != 0
+}
+
+// IsImpersonated reports whether an admin acts as the user with these claims
+func (c *JWTClaims) IsImpersonated() bool {
+	return c != nil && c.Actor != nil
+}
+
+func GrantsPermission(permissions []string, permission string) bool {
+	return slices.Contains(permissions, PermissionAll) || slices.Contains(permissions, permission)
+}
@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"go-api-example/internal/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTClaims_HasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     *auth.JWTClaims
+		permission string
+		wantRes    bool
+	}{
+		{
+			name:       "nil claims",
+			claims:     nil,
+			permission: auth.PermissionUserRead,
+			wantRes:    false,
+		},
+		{
+			name: "missing permission",
+			claims: &auth.JWTClaims{
+				Permissions: []string{auth.PermissionTodoRead},
+			},
+			permission: auth.PermissionUserRead,
+			wantRes:    false,
+		},
+		{
+			name: "granted permission",
+			claims: &auth.JWTClaims{
+				Permissions: []string{auth.PermissionTodoRead, auth.PermissionUserRead},
+			},
+			permission: auth.PermissionUserRead,
+			wantRes:    true,
+		},
+		{
+			name: "wildcard permission",
+			claims: &auth.JWTClaims{
+				Permissions: []string{auth.PermissionAll},
+			},
+			permission: auth.PermissionUserRead,
+			wantRes:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.claims.HasPermission(tt.permission)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestJWTClaims_IsPersonalAccessToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  *auth.JWTClaims
+		wantRes bool
+	}{
+		{
+			name:    "nil claims",
+			claims:  nil,
+			wantRes: false,
+		},
+		{
+			name:    "session claims",
+			claims:  &auth.JWTClaims{UserID: "1", SessionID: "dummy-sid"},
+			wantRes: false,
+		},
+		{
+			name:    "personal access token claims",
+			claims:  &auth.JWTClaims{UserID: "1", PersonalAccessTokenID: 1},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.claims.IsPersonalAccessToken()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
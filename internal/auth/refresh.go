@@ -7,8 +7,12 @@ import (
 )
 
 const (
-	PrefixRefreshKey = "refresh-token"
-	RefreshTTL       = 7 * 24 * time.Hour
+	PrefixRefreshKey        = "refresh-token"
+	PrefixUserRefreshKey    = "user-refresh-token"
+	PrefixRotatedRefreshKey = "rotated-refresh-token"
+	RefreshTTL              = 7 * 24 * time.Hour
+	PrefixSessionKey        = "session"
+	PrefixUserSessionKey    = "user-session"
 )
 
 //go:generate mockery --name=RefreshToken --structname RefreshToken --outpkg=mocks --output=./../mocks
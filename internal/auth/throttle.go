@@ -0,0 +1,41 @@
+package auth
+
+import "time"
+
+const (
+	PrefixLoginFailureKey = "login-failure"
+	PrefixLoginLockKey    = "login-lock"
+	LoginFailureWindow    = 15 * time.Minute
+
+	// an ip is shared by everyone behind the same nat, it gets more room than a single username
+	LoginMaxUserFailures = 5
+	LoginMaxIPFailures   = 20
+
+	LoginLockBase = 30 * time.Second
+	LoginLockMax  = 30 * time.Minute
+
+	// every accepted magic link request sends a mail, so they are limited even when the email is unknown
+	PrefixMagicLinkRequestKey = "magic-link-request"
+	MagicLinkRequestWindow    = 15 * time.Minute
+	MagicLinkMaxEmailRequests = 3
+	MagicLinkMaxIPRequests    = 10
+
+	// user searches without users:manage are limited so the directory can't be crawled through autocomplete
+	PrefixUserSearchKey   = "user-search"
+	UserSearchWindow      = 1 * time.Minute
+	UserSearchMaxRequests = 60
+)
+
+// LoginLockDuration doubles the lockout for every failure past the limit, capped at LoginLockMax
+func LoginLockDuration(failures int64, maxFailures int64) time.Duration {
+	if failures < maxFailures {
+		return 0
+	}
+
+	lock := LoginLockBase
+	for i := maxFailures; i < failures && lock < LoginLockMax; i++ {
+		lock *= 2
+	}
+
+	return min(lock, LoginLockMax)
+}
@@ -0,0 +1,46 @@
+package auth_test
+
+import (
+	"go-api-example/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginLockDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int64
+		wantRes  time.Duration
+	}{
+		{
+			name:     "below limit",
+			failures: 4,
+			wantRes:  0,
+		},
+		{
+			name:     "at limit",
+			failures: 5,
+			wantRes:  30 * time.Second,
+		},
+		{
+			name:     "past limit",
+			failures: 7,
+			wantRes:  2 * time.Minute,
+		},
+		{
+			name:     "capped",
+			failures: 100,
+			wantRes:  30 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := auth.LoginLockDuration(tt.failures, auth.LoginMaxUserFailures)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	PrefixEmailVerifyKey = "email-verify-token"
+	EmailVerifyTTL       = 24 * time.Hour
+
+	PrefixPasswordResetKey = "password-reset-token"
+	// PrefixUserPasswordResetKey tracks the open reset tokens of a user, a successful reset revokes all of them
+	PrefixUserPasswordResetKey = "user-password-reset-token"
+	PasswordResetTTL           = 30 * time.Minute
+
+	PrefixMagicLinkKey = "magic-link-token"
+	MagicLinkTTL       = 15 * time.Minute
+
+	// PersonalAccessTokenPrefix tells personal access tokens apart from jwts in the authorization header
+	PersonalAccessTokenPrefix = "pat_"
+	// PersonalAccessTokenLastUsedInterval limits how often last_used_at is written for a busy token
+	PersonalAccessTokenLastUsedInterval = 1 * time.Minute
+)
+
+// OpaqueToken creates random single use tokens, only their hash is meant to be stored
+//
+//go:generate mockery --name=OpaqueToken --structname OpaqueToken --outpkg=mocks --output=./../mocks
+type OpaqueToken interface {
+	Create() (string, error)
+}
+
+type opaqueToken struct{}
+
+func NewOpaqueToken() OpaqueToken {
+	return &opaqueToken{}
+}
+
+func (o *opaqueToken) Create() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
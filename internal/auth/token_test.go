@@ -0,0 +1,27 @@
+package auth_test
+
+import (
+	"go-api-example/internal/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpaqueToken_Create(t *testing.T) {
+	ot := auth.NewOpaqueToken()
+
+	res1, err := ot.Create()
+	assert.Nil(t, err)
+	assert.Len(t, res1, 43)
+
+	res2, err := ot.Create()
+	assert.Nil(t, err)
+	assert.NotEqual(t, res1, res2)
+}
+
+func TestHashToken(t *testing.T) {
+	res := auth.HashToken("dummy")
+
+	assert.Equal(t, "b5a2c96250612366ea272ffac6d9744aaf4b45aacd96aa7cfcb931ee3b558259", res)
+	assert.NotEqual(t, res, auth.HashToken("dummy2"))
+}
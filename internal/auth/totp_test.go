@@ -0,0 +1,122 @@
+package auth_test
+
+import (
+	"go-api-example/internal/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// base32 of the RFC 6238 sha1 test secret "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		unixTime int64
+		wantCode string
+	}{
+		{
+			name:     "59",
+			unixTime: 59,
+			wantCode: "287082",
+		},
+		{
+			name:     "1111111109",
+			unixTime: 1111111109,
+			wantCode: "081804",
+		},
+		{
+			name:     "1234567890",
+			unixTime: 1234567890,
+			wantCode: "005924",
+		},
+		{
+			name:     "20000000000",
+			unixTime: 20000000000,
+			wantCode: "353130",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := auth.TOTPCode(rfcSecret, auth.TOTPStep(time.Unix(tt.unixTime, 0)))
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantCode, code)
+		})
+	}
+
+	_, err := auth.TOTPCode("not base32!", 1)
+	assert.ErrorContains(t, err, "invalid totp secret")
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := auth.TOTPStep(now)
+	previous, _ := auth.TOTPCode(rfcSecret, step-1)
+	tooOld, _ := auth.TOTPCode(rfcSecret, step-2)
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOk   bool
+	}{
+		{
+			name:     "current step",
+			code:     "081804",
+			wantStep: step,
+			wantOk:   true,
+		},
+		{
+			name:     "previous step",
+			code:     previous,
+			wantStep: step - 1,
+			wantOk:   true,
+		},
+		{
+			name:   "outside skew",
+			code:   tooOld,
+			wantOk: false,
+		},
+		{
+			name:   "wrong length",
+			code:   "81804",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, ok := auth.ValidateTOTP(rfcSecret, tt.code, now)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantStep, matched)
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := auth.GenerateTOTPSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = auth.TOTPCode(secret, 1)
+	assert.Nil(t, err)
+}
+
+func TestTOTPURI(t *testing.T) {
+	res := auth.TOTPURI("api example", "johndoe", rfcSecret)
+
+	assert.Equal(t, "otpauth://totp/api%20example:johndoe?algorithm=SHA1&digits=6&issuer=api+example&period=30&secret="+rfcSecret, res)
+}
+
+func TestRecoveryCode(t *testing.T) {
+	code, err := auth.GenerateRecoveryCode()
+	assert.Nil(t, err)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+
+	assert.Equal(t, "abcde12345", auth.NormalizeRecoveryCode(" ABCDE-12345 "))
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrUsernameInvalid  = errors.New("username contains characters that are not allowed")
+	ErrUsernameReserved = errors.New("username is reserved")
+)
+
+const (
+	UsernameMinLength = 4
+	UsernameMaxLength = 64
+)
+
+// reservedUsernames could be mistaken for the service or its staff, they can't be registered or taken over
+var reservedUsernames = map[string]struct{}{
+	"abuse":         {},
+	"admin":         {},
+	"administrator": {},
+	"anonymous":     {},
+	"billing":       {},
+	"help":          {},
+	"hostmaster":    {},
+	"info":          {},
+	"moderator":     {},
+	"no-reply":      {},
+	"noreply":       {},
+	"null":          {},
+	"official":      {},
+	"postmaster":    {},
+	"root":          {},
+	"security":      {},
+	"staff":         {},
+	"support":       {},
+	"system":        {},
+	"undefined":     {},
+	"webmaster":     {},
+}
+
+// NormalizeUsername returns the one spelling a username is stored and looked up with. NFKC turns full-width and
+// other compatibility characters into their plain form and case folding makes JohnDoe and johndoe the same name
+func NormalizeUsername(username string) string {
+	return cases.Fold().String(norm.NFKC.String(strings.TrimSpace(username)))
+}
+
+// ValidateUsername checks a normalized username. Only ascii letters, digits, dots, dashes and underscores are allowed
+// so names can't be spoofed with look-alike characters, and the name has to start and end with a letter or digit
+func ValidateUsername(username string) error {
+	if len(username) < UsernameMinLength || len(username) > UsernameMaxLength {
+		return ErrUsernameInvalid
+	}
+
+	last := len(username) - 1
+	for i, r := range username {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case (r == '.' || r == '-' || r == '_') && i > 0 && i < last:
+		default:
+			return ErrUsernameInvalid
+		}
+	}
+
+	if _, ok := reservedUsernames[username]; ok {
+		return ErrUsernameReserved
+	}
+
+	return nil
+}
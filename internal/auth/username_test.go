@@ -0,0 +1,54 @@
+package auth_test
+
+import (
+	"go-api-example/internal/auth"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+	}{
+		{name: "lowercase", username: "johndoe", want: "johndoe"},
+		{name: "case folding", username: "JohnDoe", want: "johndoe"},
+		{name: "surrounding spaces", username: "  johndoe ", want: "johndoe"},
+		{name: "full-width characters", username: "ｊｏｈｎ＿ｄｏｅ", want: "john_doe"},
+		{name: "ligature", username: "ﬁnn", want: "finn"},
+		{name: "sharp s", username: "Straße", want: "strasse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, auth.NormalizeUsername(tt.username))
+		})
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		wantErr  error
+	}{
+		{name: "valid", username: "john.doe-99_x", wantErr: nil},
+		{name: "too short", username: "joe", wantErr: auth.ErrUsernameInvalid},
+		{name: "too long", username: strings.Repeat("a", 65), wantErr: auth.ErrUsernameInvalid},
+		{name: "uppercase", username: "JohnDoe", wantErr: auth.ErrUsernameInvalid},
+		{name: "space", username: "john doe", wantErr: auth.ErrUsernameInvalid},
+		{name: "non ascii letter", username: "jоhndoe", wantErr: auth.ErrUsernameInvalid},
+		{name: "leading separator", username: "_johndoe", wantErr: auth.ErrUsernameInvalid},
+		{name: "trailing separator", username: "johndoe.", wantErr: auth.ErrUsernameInvalid},
+		{name: "reserved", username: "admin", wantErr: auth.ErrUsernameReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, auth.ValidateUsername(tt.username))
+		})
+	}
+}
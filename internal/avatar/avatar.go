@@ -0,0 +1,116 @@
+package avatar
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+)
+
+const (
+	// URLPath is where the stored avatars are served
+	URLPath = "/avatars"
+
+	MaxUploadSize = 5 << 20
+	MaxDimension  = 4096
+)
+
+// Sizes are the square sizes in pixels every avatar is stored in
+var Sizes = []int{32, 64, 128, 256}
+
+var (
+	ErrInvalidImage  = errors.New("invalid avatar image")
+	ErrImageTooLarge = errors.New("avatar image is too large")
+)
+
+// Decode accepts png, jpeg and gif images, the dimensions are checked before the pixels are decoded
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	return img, nil
+}
+
+// Resize crops the centered square of the image and scales it to size x size, every target pixel is the average
+// of the source pixels it covers
+func Resize(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.NewRGBA(image.Rect(0, 0, side, side))
+	offset := image.Pt(bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2)
+	draw.Draw(crop, crop.Bounds(), img, offset, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		y0, y1 := span(y, side, size)
+		for x := range size {
+			x0, x1 := span(x, side, size)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := crop.Pix[sy*crop.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := range sum {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			i := y*dst.Stride + x*4
+			for c := range sum {
+				dst.Pix[i+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+
+	return dst
+}
+
+// span returns the source pixels covered by target pixel i, at least one so upscaling repeats pixels
+func span(i int, side int, size int) (int, int) {
+	start := i * side / size
+	end := max((i+1)*side/size, start+1)
+	return start, end
+}
+
+func Encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewKey returns a random key, the stored images of one upload share it
+func NewKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// FileName names the stored image of one size, every upload gets a new key so cached images are never stale
+func FileName(key string, size int) string {
+	return fmt.Sprintf("%s_%d.png", key, size)
+}
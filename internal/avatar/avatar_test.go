@@ -0,0 +1,123 @@
+package avatar_test
+
+import (
+	"bytes"
+	"go-api-example/internal/avatar"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	assert.Nil(t, err)
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	var jpegBuf bytes.Buffer
+	err := jpeg.Encode(&jpegBuf, image.NewRGBA(image.Rect(0, 0, 10, 20)), nil)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name:    "not an image",
+			data:    []byte("dummy"),
+			wantErr: avatar.ErrInvalidImage,
+		},
+		{
+			name:    "truncated image",
+			data:    encodePNG(t, image.NewRGBA(image.Rect(0, 0, 10, 10)))[:60],
+			wantErr: avatar.ErrInvalidImage,
+		},
+		{
+			name:    "too large",
+			data:    encodePNG(t, image.NewGray(image.Rect(0, 0, avatar.MaxDimension+1, 1))),
+			wantErr: avatar.ErrImageTooLarge,
+		},
+		{
+			name:    "png",
+			data:    encodePNG(t, image.NewRGBA(image.Rect(0, 0, 10, 10))),
+			wantErr: nil,
+		},
+		{
+			name:    "jpeg",
+			data:    jpegBuf.Bytes(),
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := avatar.Decode(tt.data)
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotNil(t, img)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	// a wide image with blue borders on the sides that the centered square crops away
+	src := image.NewRGBA(image.Rect(0, 0, 300, 100))
+	for y := range 100 {
+		for x := range 300 {
+			c := red
+			if x < 100 || x >= 200 {
+				c = blue
+			}
+			src.Set(x, y, c)
+		}
+	}
+
+	for _, size := range avatar.Sizes {
+		img := avatar.Resize(src, size)
+
+		assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+		assert.Equal(t, red, img.RGBAAt(0, 0))
+		assert.Equal(t, red, img.RGBAAt(size-1, size-1))
+	}
+
+	// half black and half white columns average to gray
+	stripes := image.NewGray(image.Rect(0, 0, 64, 64))
+	for y := range 64 {
+		for x := range 64 {
+			stripes.SetGray(x, y, color.Gray{Y: uint8(255 * (x % 2))})
+		}
+	}
+	assert.Equal(t, color.RGBA{R: 128, G: 128, B: 128, A: 255}, avatar.Resize(stripes, 32).RGBAAt(5, 5))
+
+	data, err := avatar.Encode(avatar.Resize(stripes, 32))
+	assert.Nil(t, err)
+	decoded, err := avatar.Decode(data)
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 32, 32), decoded.Bounds())
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "1-qwe_64.png", avatar.FileName("1-qwe", 64))
+}
+
+func TestNewKey(t *testing.T) {
+	key, err := avatar.NewKey()
+	assert.Nil(t, err)
+	assert.Len(t, key, 32)
+
+	other, err := avatar.NewKey()
+	assert.Nil(t, err)
+	assert.NotEqual(t, key, other)
+}
@@ -8,8 +8,10 @@ import (
 	"go-api-example/internal/delivery/http"
 	"go-api-example/internal/delivery/http/middleware"
 	"go-api-example/internal/delivery/http/route"
+	"go-api-example/internal/mail"
 	"go-api-example/internal/messaging"
 	"go-api-example/internal/repository"
+	"go-api-example/internal/storage"
 	"go-api-example/internal/usecase"
 	"time"
 
@@ -21,13 +23,20 @@ import (
 )
 
 type ApiConfig struct {
-	DB       *sql.DB
-	TX       db.Transactioner
-	App      *gin.Engine
-	Log      *zap.Logger
-	Validate *validator.Validate
-	Config   *Env
-	Producer *kafka.Producer
+	DB             *sql.DB
+	TX             db.Transactioner
+	App            *gin.Engine
+	Log            *zap.Logger
+	Validate       *validator.Validate
+	Config         *Env
+	Producer       *kafka.Producer
+	Mailer         mail.Mailer
+	JWTToken       auth.JWTToken
+	PasswordHasher auth.PasswordHasher
+	PasswordPolicy *auth.PasswordPolicy
+	OIDCProviders  map[string]auth.OIDCProvider
+	OAuthClients   map[string]string
+	Cookie         http.CookieConfig
 }
 
 func NewApi(cfg *ApiConfig) {
@@ -36,30 +45,81 @@ func NewApi(cfg *ApiConfig) {
 		DB:   cfg.Config.RedistDB,
 	})
 
-	jwtToken := auth.NewJWTToken(cfg.Config.JWTSecretKey, 15*time.Minute)
 	refreshToken := auth.NewRefreshToken()
-
-	authMiddleware := middleware.NewAuthMiddleware(cfg.Log, redisClient, jwtToken)
+	opaqueToken := auth.NewOpaqueToken()
+	avatarStorage := storage.NewLocalFileStorage(cfg.Config.AvatarDir)
 
 	userProducer := messaging.NewUserProducer(cfg.Log, cfg.Producer, cfg.Config.KafkaTopicUserRegistered)
+	userDeletedProducer := messaging.NewUserDeletedProducer(cfg.Log, cfg.Producer, cfg.Config.KafkaTopicUserDeleted)
+	userUpdatedProducer := messaging.NewUserUpdatedProducer(cfg.Log, cfg.Producer, cfg.Config.KafkaTopicUserUpdated)
+	todoProducer := messaging.NewTodoProducer(cfg.Log, cfg.Producer, cfg.Config.KafkaTopicTodoAssigned)
+	securityEventProducer := messaging.NewSecurityEventProducer(cfg.Log, cfg.Producer, cfg.Config.KafkaTopicSecurityEvent)
 
 	userRepository := repository.NewUserRepository(cfg.DB)
 	todoRepository := repository.NewTodoRepository(cfg.DB)
+	notificationRepository := repository.NewNotificationRepository(cfg.DB)
+	roleRepository := repository.NewRoleRepository(cfg.DB)
+	totpRepository := repository.NewTOTPRepository(cfg.DB)
+	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(cfg.DB)
+	userIdentityRepository := repository.NewUserIdentityRepository(cfg.DB)
+	impersonationLogRepository := repository.NewImpersonationLogRepository(cfg.DB)
+	adminAuditLogRepository := repository.NewAdminAuditLogRepository(cfg.DB)
+	securityEventRepository := repository.NewSecurityEventRepository(cfg.DB)
+
+	authUsecase := usecase.NewAuthUsecase(cfg.Log, redisClient, cfg.JWTToken, refreshToken, cfg.PasswordHasher,
+		securityEventProducer, userRepository, roleRepository, totpRepository, userIdentityRepository, cfg.OIDCProviders,
+		cfg.Mailer, opaqueToken, cfg.Config.AppBaseURL)
+	userUsecase := usecase.NewUserUsecase(cfg.Log, cfg.TX, redisClient, cfg.PasswordHasher, cfg.PasswordPolicy, userProducer,
+		userDeletedProducer, userUpdatedProducer, securityEventProducer, avatarStorage, userRepository, todoRepository,
+		notificationRepository, totpRepository, personalAccessTokenRepository, userIdentityRepository, roleRepository,
+		adminAuditLogRepository, securityEventRepository)
+	twoFactorUsecase := usecase.NewTwoFactorUsecase(cfg.Log, cfg.TX, redisClient, cfg.PasswordHasher, userRepository,
+		totpRepository, cfg.Config.AppName)
+	emailUsecase := usecase.NewEmailUsecase(cfg.Log, redisClient, cfg.Mailer, opaqueToken, userRepository, cfg.Config.AppBaseURL)
+	passwordUsecase := usecase.NewPasswordUsecase(cfg.Log, cfg.TX, redisClient, cfg.Mailer, opaqueToken, cfg.PasswordHasher,
+		cfg.PasswordPolicy, securityEventProducer, userRepository, cfg.Config.AppBaseURL)
+	todoUsecase := usecase.NewTodoUsecase(cfg.Log, todoProducer, todoRepository, userRepository)
+	notificationUsecase := usecase.NewNotificationUsecase(cfg.Log, notificationRepository)
+	securityEventUsecase := usecase.NewSecurityEventUsecase(cfg.Log, securityEventRepository)
+	personalAccessTokenUsecase := usecase.NewPersonalAccessTokenUsecase(cfg.Log, opaqueToken, userRepository, roleRepository,
+		personalAccessTokenRepository)
+	oauthUsecase := usecase.NewOAuthUsecase(cfg.Log, redisClient, cfg.JWTToken, userRepository, roleRepository,
+		personalAccessTokenRepository)
+	impersonationUsecase := usecase.NewImpersonationUsecase(cfg.Log, cfg.JWTToken,
+		time.Duration(cfg.Config.ImpersonationTokenTTL)*time.Second, userRepository, roleRepository, impersonationLogRepository)
 
-	authUsecase := usecase.NewAuthUsecase(cfg.Log, redisClient, jwtToken, refreshToken, userRepository)
-	userUsecase := usecase.NewUserUsecase(cfg.Log, cfg.TX, userProducer, userRepository)
-	todoUsecase := usecase.NewTodoUsecase(cfg.Log, todoRepository)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.Log, redisClient, cfg.JWTToken, authUsecase,
+		personalAccessTokenUsecase, impersonationUsecase, cfg.Cookie.Enabled)
+	clientAuthMiddleware := middleware.NewClientAuthMiddleware(cfg.Log, cfg.OAuthClients)
 
-	authController := http.NewAuthController(cfg.Log, cfg.Validate, authUsecase)
+	authController := http.NewAuthController(cfg.Log, cfg.Validate, authUsecase, cfg.Cookie)
 	userController := http.NewUserController(cfg.Log, cfg.Validate, userUsecase)
+	emailController := http.NewEmailController(cfg.Log, cfg.Validate, emailUsecase)
+	passwordController := http.NewPasswordController(cfg.Log, cfg.Validate, passwordUsecase)
 	todoController := http.NewTodoController(cfg.Log, cfg.Validate, todoUsecase)
+	notificationController := http.NewNotificationController(cfg.Log, cfg.Validate, notificationUsecase)
+	twoFactorController := http.NewTwoFactorController(cfg.Log, cfg.Validate, twoFactorUsecase)
+	personalAccessTokenController := http.NewPersonalAccessTokenController(cfg.Log, cfg.Validate, personalAccessTokenUsecase)
+	impersonationController := http.NewImpersonationController(cfg.Log, cfg.Validate, impersonationUsecase)
+	oauthController := http.NewOAuthController(cfg.Log, cfg.Validate, oauthUsecase)
+	securityEventController := http.NewSecurityEventController(cfg.Log, cfg.Validate, securityEventUsecase)
 
 	routeCfg := route.RouteConfig{
-		App:            cfg.App,
-		AuthMiddlware:  authMiddleware,
-		AuthController: authController,
-		UserController: userController,
-		TodoController: todoController,
+		App:                           cfg.App,
+		AuthMiddlware:                 authMiddleware,
+		ClientAuthMiddleware:          clientAuthMiddleware,
+		AuthController:                authController,
+		UserController:                userController,
+		EmailController:               emailController,
+		PasswordController:            passwordController,
+		TodoController:                todoController,
+		NotificationController:        notificationController,
+		TwoFactorController:           twoFactorController,
+		PersonalAccessTokenController: personalAccessTokenController,
+		ImpersonationController:       impersonationController,
+		OAuthController:               oauthController,
+		SecurityEventController:       securityEventController,
+		AvatarDir:                     cfg.Config.AvatarDir,
 	}
 	routeCfg.Setup()
 }
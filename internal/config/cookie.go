@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"go-api-example/internal/auth"
+	internalHttp "go-api-example/internal/delivery/http"
+	"net/http"
+	"strings"
+)
+
+func NewCookieConfig(env *Env) (internalHttp.CookieConfig, error) {
+	cookie := internalHttp.CookieConfig{
+		Enabled:         env.AuthCookieEnabled,
+		Domain:          env.AuthCookieDomain,
+		Secure:          env.AuthCookieSecure,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: auth.RefreshTTL,
+	}
+
+	switch strings.ToLower(env.AuthCookieSameSite) {
+	case "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "lax":
+		cookie.SameSite = http.SameSiteLaxMode
+	case "none":
+		// browsers drop SameSite=None cookies that are not secure
+		if !cookie.Secure {
+			return cookie, fmt.Errorf("AUTH_COOKIE_SAME_SITE=none requires AUTH_COOKIE_SECURE")
+		}
+		cookie.SameSite = http.SameSiteNoneMode
+	default:
+		return cookie, fmt.Errorf("unknown AUTH_COOKIE_SAME_SITE %q", env.AuthCookieSameSite)
+	}
+
+	return cookie, nil
+}
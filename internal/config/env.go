@@ -2,18 +2,22 @@ package config
 
 import (
 	"fmt"
+	"go-api-example/internal/auth"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Env struct {
-	AppName         string
-	AppPort         int
-	AppReadTimeout  int
-	AppWriteTimeout int
-	AppIdleTimeout  int
+	AppName           string
+	AppPort           int
+	AppReadTimeout    int
+	AppWriteTimeout   int
+	AppIdleTimeout    int
+	AppBaseURL        string
+	AppTrustedProxies []string
 
 	DBHost            string
 	DBPort            string
@@ -28,12 +32,49 @@ type Env struct {
 	RedistPort string
 	RedistDB   int
 
-	JWTSecretKey string
-
-	KafkaBrokerHost          string
-	KafkaConsumerGroup       string
-	KafkaAutoOffsetReset     string
-	KafkaTopicUserRegistered string
+	JWTSecretKey      string
+	JWTSigningKeys    []string
+	JWTKeyGracePeriod int
+
+	ImpersonationTokenTTL int
+
+	AuthCookieEnabled  bool
+	AuthCookieDomain   string
+	AuthCookieSecure   bool
+	AuthCookieSameSite string
+
+	OIDCProviders []auth.OIDCProviderConfig
+
+	OAuthClients []string
+
+	PasswordHashAlgorithm     string
+	PasswordBcryptCost        int
+	PasswordArgon2Memory      int
+	PasswordArgon2Iterations  int
+	PasswordArgon2Parallelism int
+	PasswordMinLength         int
+	PasswordRequireUpper      bool
+	PasswordRequireLower      bool
+	PasswordRequireDigit      bool
+	PasswordRequireSymbol     bool
+	PasswordBlocklistFile     string
+
+	MailDriver  string
+	MailFrom    string
+	MailFileDir string
+
+	AvatarDir string
+
+	KafkaBrokerHost                 string
+	KafkaConsumerGroup              string
+	KafkaNotificationConsumerGroup  string
+	KafkaSecurityEventConsumerGroup string
+	KafkaAutoOffsetReset            string
+	KafkaTopicUserRegistered        string
+	KafkaTopicUserDeleted           string
+	KafkaTopicUserUpdated           string
+	KafkaTopicTodoAssigned          string
+	KafkaTopicSecurityEvent         string
 }
 
 func NewEnv() (*Env, error) {
@@ -43,11 +84,13 @@ func NewEnv() (*Env, error) {
 	}
 
 	cfg := &Env{
-		AppName:         getEnvString("APP_NAME", "api-example"),
-		AppPort:         getEnvInt("APP_PORT", 8500),
-		AppReadTimeout:  getEnvInt("APP_READ_TIMEOUT", 60),
-		AppWriteTimeout: getEnvInt("APP_WRITE_TIMEOUT", 60),
-		AppIdleTimeout:  getEnvInt("APP_IDLE_TIMEOUT", 120),
+		AppName:           getEnvString("APP_NAME", "api-example"),
+		AppPort:           getEnvInt("APP_PORT", 8500),
+		AppReadTimeout:    getEnvInt("APP_READ_TIMEOUT", 60),
+		AppWriteTimeout:   getEnvInt("APP_WRITE_TIMEOUT", 60),
+		AppIdleTimeout:    getEnvInt("APP_IDLE_TIMEOUT", 120),
+		AppBaseURL:        getEnvString("APP_BASE_URL", "http://localhost:8500"),
+		AppTrustedProxies: getEnvStrings("APP_TRUSTED_PROXIES", nil),
 
 		DBHost:            getEnvString("DATABASE_HOST", "127.0.0.1"),
 		DBPort:            getEnvString("DATABASE_PORT", "3306"),
@@ -62,17 +105,76 @@ func NewEnv() (*Env, error) {
 		RedistPort: getEnvString("REDIS_PORT", "6379"),
 		RedistDB:   getEnvInt("REDIS_DB", 0),
 
-		JWTSecretKey: getEnvString("JWT_SECRET_KEY", ""),
-
-		KafkaBrokerHost:          getEnvString("KAFKA_BROKER_HOST", "127.0.0.1:9092"),
-		KafkaConsumerGroup:       getEnvString("KAFKA_CONSUMER_GROUP", "api-example"),
-		KafkaAutoOffsetReset:     getEnvString("KAFKA_AUTO_OFFSET_RESET", "latest"),
-		KafkaTopicUserRegistered: getEnvString("KAFKA_TOPIC_USER_REGISTERED", "user-registered"),
+		JWTSecretKey:      getEnvString("JWT_SECRET_KEY", ""),
+		JWTSigningKeys:    getEnvStrings("JWT_SIGNING_KEYS", nil),
+		JWTKeyGracePeriod: getEnvInt("JWT_KEY_GRACE_PERIOD", 900),
+
+		ImpersonationTokenTTL: getEnvInt("IMPERSONATION_TOKEN_TTL", 600),
+
+		OAuthClients: getEnvStrings("OAUTH_CLIENTS", nil),
+
+		AuthCookieEnabled:  getEnvBool("AUTH_COOKIE_ENABLED", false),
+		AuthCookieDomain:   getEnvString("AUTH_COOKIE_DOMAIN", ""),
+		AuthCookieSecure:   getEnvBool("AUTH_COOKIE_SECURE", true),
+		AuthCookieSameSite: getEnvString("AUTH_COOKIE_SAME_SITE", "lax"),
+
+		PasswordHashAlgorithm:     getEnvString("PASSWORD_HASH_ALGORITHM", auth.PasswordHashArgon2id),
+		PasswordBcryptCost:        getEnvInt("PASSWORD_BCRYPT_COST", 10),
+		PasswordArgon2Memory:      getEnvInt("PASSWORD_ARGON2_MEMORY", 64*1024),
+		PasswordArgon2Iterations:  getEnvInt("PASSWORD_ARGON2_ITERATIONS", 3),
+		PasswordArgon2Parallelism: getEnvInt("PASSWORD_ARGON2_PARALLELISM", 2),
+		PasswordMinLength:         getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordRequireUpper:      getEnvBool("PASSWORD_REQUIRE_UPPER", false),
+		PasswordRequireLower:      getEnvBool("PASSWORD_REQUIRE_LOWER", false),
+		PasswordRequireDigit:      getEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+		PasswordRequireSymbol:     getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		PasswordBlocklistFile:     getEnvString("PASSWORD_BLOCKLIST_FILE", ""),
+
+		MailDriver:  getEnvString("MAIL_DRIVER", "log"),
+		MailFrom:    getEnvString("MAIL_FROM", "noreply@api-example.local"),
+		MailFileDir: getEnvString("MAIL_FILE_DIR", "tmp/mails"),
+
+		AvatarDir: getEnvString("AVATAR_DIR", "tmp/avatars"),
+
+		KafkaBrokerHost:                 getEnvString("KAFKA_BROKER_HOST", "127.0.0.1:9092"),
+		KafkaConsumerGroup:              getEnvString("KAFKA_CONSUMER_GROUP", "api-example"),
+		KafkaNotificationConsumerGroup:  getEnvString("KAFKA_NOTIFICATION_CONSUMER_GROUP", "api-example-notification"),
+		KafkaSecurityEventConsumerGroup: getEnvString("KAFKA_SECURITY_EVENT_CONSUMER_GROUP", "api-example-security-event"),
+		KafkaAutoOffsetReset:            getEnvString("KAFKA_AUTO_OFFSET_RESET", "latest"),
+		KafkaTopicUserRegistered:        getEnvString("KAFKA_TOPIC_USER_REGISTERED", "user-registered"),
+		KafkaTopicUserDeleted:           getEnvString("KAFKA_TOPIC_USER_DELETED", "user-deleted"),
+		KafkaTopicUserUpdated:           getEnvString("KAFKA_TOPIC_USER_UPDATED", "user-updated"),
+		KafkaTopicTodoAssigned:          getEnvString("KAFKA_TOPIC_TODO_ASSIGNED", "todo-assigned"),
+		KafkaTopicSecurityEvent:         getEnvString("KAFKA_TOPIC_SECURITY_EVENT", "security-event"),
 	}
+	cfg.OIDCProviders = getOIDCProviders(cfg.AppBaseURL)
 
 	return cfg, nil
 }
 
+// getOIDCProviders reads the providers named in OIDC_PROVIDERS, every provider is configured with its own
+// OIDC_<NAME>_* variables
+func getOIDCProviders(appBaseURL string) []auth.OIDCProviderConfig {
+	names := getEnvStrings("OIDC_PROVIDERS", nil)
+
+	providers := make([]auth.OIDCProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		providers = append(providers, auth.OIDCProviderConfig{
+			Name:         name,
+			Issuer:       getEnvString(prefix+"ISSUER", ""),
+			ClientID:     getEnvString(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnvString(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnvString(prefix+"REDIRECT_URL", fmt.Sprintf("%s/api/oidc/%s/callback", appBaseURL, name)),
+			Scopes:       getEnvStrings(prefix+"SCOPES", []string{"openid", "email", "profile"}),
+		})
+	}
+
+	return providers
+}
+
 func getEnvString(key string, defaultVal string) string {
 	if val, ok := os.LookupEnv(key); ok {
 		return val
@@ -81,6 +183,18 @@ func getEnvString(key string, defaultVal string) string {
 	return defaultVal
 }
 
+func getEnvStrings(key string, defaultVal []string) []string {
+	if val, ok := os.LookupEnv(key); ok && val != "" {
+		vals := strings.Split(val, ",")
+		for i := range vals {
+			vals[i] = strings.TrimSpace(vals[i])
+		}
+		return vals
+	}
+
+	return defaultVal
+}
+
 func getEnvInt(key string, defaultVal int) int {
 	if val, ok := os.LookupEnv(key); ok {
 		pVal, err := strconv.Atoi(val)
@@ -92,3 +206,15 @@ func getEnvInt(key string, defaultVal int) int {
 
 	return defaultVal
 }
+
+func getEnvBool(key string, defaultVal bool) bool {
+	if val, ok := os.LookupEnv(key); ok {
+		pVal, err := strconv.ParseBool(val)
+		if err != nil {
+			return defaultVal
+		}
+		return pVal
+	}
+
+	return defaultVal
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"strings"
+	"time"
+)
+
+const accessTokenTTL = 15 * time.Minute
+
+// NewJWTToken signs with the keys of JWT_SIGNING_KEYS when set, otherwise with the HS256 JWT_SECRET_KEY.
+// Every signing key is written as <kid>=<pem file>, optionally followed by @<RFC 3339 time> to schedule
+// when the key starts signing
+func NewJWTToken(env *Env) (auth.JWTToken, error) {
+	if len(env.JWTSigningKeys) == 0 {
+		return auth.NewJWTToken(env.JWTSecretKey, accessTokenTTL)
+	}
+
+	keys := make([]*auth.SigningKey, 0, len(env.JWTSigningKeys))
+	for _, entry := range env.JWTSigningKeys {
+		id, path, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || path == "" {
+			return nil, fmt.Errorf("invalid jwt signing key %q", entry)
+		}
+
+		var activeFrom time.Time
+		path, schedule, scheduled := strings.Cut(path, "@")
+		if scheduled {
+			var err error
+			activeFrom, err = time.Parse(time.RFC3339, schedule)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schedule of jwt signing key %s: %w", id, err)
+			}
+		}
+
+		key, err := auth.LoadSigningKey(id, path, activeFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	// tokens signed right before a rotation have to stay verifiable until they expire
+	gracePeriod := max(time.Duration(env.JWTKeyGracePeriod)*time.Second, accessTokenTTL)
+	keySet, err := auth.NewKeySet(keys, gracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	if keySet.SigningKey(time.Now()) == nil {
+		return nil, errors.New("no jwt signing key is active yet")
+	}
+
+	return auth.NewKeySetJWTToken(keySet, accessTokenTTL), nil
+}
@@ -20,10 +20,10 @@ func NewKafkaProducer(env *Env, logger *zap.Logger) (*kafka.Producer, error) {
 	return producer, nil
 }
 
-func NewKafkaConsumer(env *Env, logger *zap.Logger) (*kafka.Consumer, error) {
+func NewKafkaConsumer(env *Env, groupID string, logger *zap.Logger) (*kafka.Consumer, error) {
 	cfg := &kafka.ConfigMap{
 		"bootstrap.servers": env.KafkaBrokerHost,
-		"group.id":          env.KafkaConsumerGroup,
+		"group.id":          groupID,
 		"auto.offset.reset": env.KafkaAutoOffsetReset,
 	}
 
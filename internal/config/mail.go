@@ -0,0 +1,11 @@
+package config
+
+import (
+	"go-api-example/internal/mail"
+
+	"go.uber.org/zap"
+)
+
+func NewMailer(env *Env, logger *zap.Logger) (mail.Mailer, error) {
+	return mail.NewMailer(env.MailDriver, logger, env.MailFrom, env.MailFileDir)
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewOAuthClients reads the internal services allowed to introspect and revoke tokens, OAUTH_CLIENTS is a comma
+// separated list of <client id>:<client secret> entries
+func NewOAuthClients(env *Env) (map[string]string, error) {
+	clients := make(map[string]string, len(env.OAuthClients))
+	for _, entry := range env.OAuthClients {
+		clientID, secret, ok := strings.Cut(entry, ":")
+		if !ok || clientID == "" || secret == "" {
+			return nil, fmt.Errorf("invalid oauth client %q", clientID)
+		}
+
+		if _, exists := clients[clientID]; exists {
+			return nil, fmt.Errorf("duplicate oauth client %q", clientID)
+		}
+		clients[clientID] = secret
+	}
+
+	return clients, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"go-api-example/internal/auth"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+)
+
+var oidcProviderNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+func NewOIDCProviders(env *Env) (map[string]auth.OIDCProvider, error) {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	providers := make(map[string]auth.OIDCProvider, len(env.OIDCProviders))
+	for _, cfg := range env.OIDCProviders {
+		if !oidcProviderNameRegex.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("invalid oidc provider name %q", cfg.Name)
+		}
+		if _, ok := providers[cfg.Name]; ok {
+			return nil, fmt.Errorf("duplicate oidc provider %s", cfg.Name)
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			return nil, fmt.Errorf("oidc provider %s needs an issuer and a client id", cfg.Name)
+		}
+		if !slices.Contains(cfg.Scopes, "openid") {
+			return nil, fmt.Errorf("scopes of oidc provider %s miss openid", cfg.Name)
+		}
+
+		providers[cfg.Name] = auth.NewOIDCProvider(cfg, httpClient)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"go-api-example/internal/auth"
+	"math"
+)
+
+func NewPasswordHasher(env *Env) (auth.PasswordHasher, error) {
+	if env.PasswordArgon2Memory < 0 || env.PasswordArgon2Iterations < 0 ||
+		env.PasswordArgon2Parallelism < 0 || env.PasswordArgon2Parallelism > math.MaxUint8 {
+		return nil, fmt.Errorf("invalid argon2 parameters")
+	}
+
+	return auth.NewPasswordHasher(auth.PasswordHashConfig{
+		Algorithm:         env.PasswordHashAlgorithm,
+		BcryptCost:        env.PasswordBcryptCost,
+		Argon2Memory:      uint32(env.PasswordArgon2Memory),
+		Argon2Iterations:  uint32(env.PasswordArgon2Iterations),
+		Argon2Parallelism: uint8(env.PasswordArgon2Parallelism),
+	})
+}
+
+func NewPasswordPolicy(env *Env) (*auth.PasswordPolicy, error) {
+	return auth.NewPasswordPolicy(auth.PasswordPolicyConfig{
+		MinLength:     env.PasswordMinLength,
+		RequireUpper:  env.PasswordRequireUpper,
+		RequireLower:  env.PasswordRequireLower,
+		RequireDigit:  env.PasswordRequireDigit,
+		RequireSymbol: env.PasswordRequireSymbol,
+		BlocklistFile: env.PasswordBlocklistFile,
+	})
+}
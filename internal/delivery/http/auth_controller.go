@@ -1,11 +1,14 @@
 package http
 
 import (
+	"errors"
 	"go-api-example/internal/delivery/http/middleware"
 	"go-api-example/internal/model"
 	"go-api-example/internal/usecase"
 	"net/http"
+	"strconv"
 
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
@@ -15,14 +18,16 @@ type AuthController struct {
 	Log         *zap.Logger
 	Validate    *validator.Validate
 	AuthUsecase usecase.AuthUsecase
+	Cookie      CookieConfig
 }
 
 func NewAuthController(log *zap.Logger, validate *validator.Validate,
-	authUsecase usecase.AuthUsecase) *AuthController {
+	authUsecase usecase.AuthUsecase, cookie CookieConfig) *AuthController {
 	return &AuthController{
 		Log:         log,
 		Validate:    validate,
 		AuthUsecase: authUsecase,
+		Cookie:      cookie,
 	}
 }
 
@@ -42,6 +47,9 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
 	res, err := c.AuthUsecase.Login(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.Log, "failed to login", err)
@@ -49,6 +57,184 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
+	err = c.setSessionCookies(ctx, res, request.UseCookies)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to set session cookies", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AuthController) VerifyMFA(ctx *gin.Context) {
+	request := new(model.VerifyMFARequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
+	res, err := c.AuthUsecase.VerifyMFA(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to verify mfa", err)
+		ctx.Error(err)
+		return
+	}
+
+	err = c.setSessionCookies(ctx, res, request.UseCookies)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to set session cookies", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AuthController) RequestMagicLink(ctx *gin.Context) {
+	request := new(model.MagicLinkRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.IP = ctx.ClientIP()
+	err = c.AuthUsecase.RequestMagicLink(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to request magic link", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusAccepted,
+		model.NewSuccessMessageResponse("If the email is registered, a login link has been sent", http.StatusAccepted),
+	)
+}
+
+func (c *AuthController) LoginMagicLink(ctx *gin.Context) {
+	request := new(model.MagicLinkLoginRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
+	res, err := c.AuthUsecase.LoginMagicLink(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to login with magic link", err)
+		ctx.Error(err)
+		return
+	}
+
+	err = c.setSessionCookies(ctx, res, request.UseCookies)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to set session cookies", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AuthController) AuthorizeOIDC(ctx *gin.Context) {
+	request := &model.AuthorizeOIDCRequest{
+		Provider:   ctx.Param("provider"),
+		DeviceName: ctx.Query("device_name"),
+	}
+
+	err := c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.AuthUsecase.AuthorizeOIDC(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to authorize oidc", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AuthController) OIDCCallback(ctx *gin.Context) {
+	// the provider redirects with an error instead of a code when the user declined or the login failed there
+	if providerErr := ctx.Query("error"); providerErr != "" {
+		LogWarn(ctx, c.Log, "oidc provider returned an error", errors.New(providerErr))
+		ctx.Error(model.ErrOIDCLoginFailed)
+		return
+	}
+
+	request := &model.OIDCCallbackRequest{
+		Provider: ctx.Param("provider"),
+		Code:     ctx.Query("code"),
+		State:    ctx.Query("state"),
+	}
+
+	err := c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
+	res, err := c.AuthUsecase.LoginOIDC(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to login with oidc", err)
+		ctx.Error(err)
+		return
+	}
+
 	ctx.JSON(
 		http.StatusOK,
 		model.NewSuccessResponse(res, http.StatusOK),
@@ -64,11 +250,14 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	}
 
 	request := new(model.LogoutRequest)
-	err = ctx.ShouldBindJSON(request)
-	if err != nil {
-		LogWarn(ctx, c.Log, "failed to parse request body", err)
-		ctx.Error(model.ErrBadRequest)
-		return
+	request.RefreshToken = c.Cookie.refreshToken(ctx)
+	if request.RefreshToken == "" {
+		err = ctx.ShouldBindJSON(request)
+		if err != nil {
+			LogWarn(ctx, c.Log, "failed to parse request body", err)
+			ctx.Error(model.ErrBadRequest)
+			return
+		}
 	}
 
 	err = c.Validate.Struct(request)
@@ -79,6 +268,9 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 	}
 
 	request.Claims = claims
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
 	err = c.AuthUsecase.Logout(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.Log, "failed to logout", err)
@@ -86,6 +278,10 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 		return
 	}
 
+	if c.Cookie.Enabled {
+		c.Cookie.clearTokens(ctx)
+	}
+
 	ctx.JSON(
 		http.StatusOK,
 		model.NewSuccessMessageResponse("Logged out", http.StatusOK),
@@ -93,12 +289,18 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 }
 
 func (c *AuthController) RefreshToken(ctx *gin.Context) {
+	var err error
 	request := new(model.RefreshRequest)
-	err := ctx.ShouldBindJSON(request)
-	if err != nil {
-		LogWarn(ctx, c.Log, "failed to parse request body", err)
-		ctx.Error(model.ErrBadRequest)
-		return
+	cookieToken := c.Cookie.refreshToken(ctx)
+	if cookieToken != "" {
+		request.RefreshToken = cookieToken
+	} else {
+		err = ctx.ShouldBindJSON(request)
+		if err != nil {
+			LogWarn(ctx, c.Log, "failed to parse request body", err)
+			ctx.Error(model.ErrBadRequest)
+			return
+		}
 	}
 
 	err = c.Validate.Struct(request)
@@ -108,6 +310,9 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
 	res, err := c.AuthUsecase.Refresh(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.Log, "failed to refresh token", err)
@@ -115,8 +320,208 @@ func (c *AuthController) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
+	if cookieToken != "" {
+		err = c.Cookie.setTokens(ctx, res.AccessToken, res.RefreshToken)
+		if err != nil {
+			LogWarn(ctx, c.Log, "failed to set session cookies", err)
+			ctx.Error(err)
+			return
+		}
+		res = &model.RefreshResponse{}
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AuthController) Sessions(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.AuthUsecase.ListSessions(ctx.Request.Context(), &model.ListSessionRequest{
+		Claims: claims,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to list sessions", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AuthController) RevokeSession(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	err = c.AuthUsecase.RevokeSession(ctx.Request.Context(), &model.RevokeSessionRequest{
+		ID:        ctx.Param("id"),
+		Claims:    claims,
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to revoke session", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Session revoked", http.StatusOK),
+	)
+}
+
+func (c *AuthController) RevokeAllSessions(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	err = c.AuthUsecase.RevokeAllSessions(ctx.Request.Context(), &model.RevokeAllSessionRequest{
+		Claims:    claims,
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to revoke all sessions", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("All sessions revoked", http.StatusOK),
+	)
+}
+
+func (c *AuthController) UnlockLogin(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.AuthUsecase.UnlockLogin(ctx.Request.Context(), &model.UnlockLoginRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to unlock login", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Login unlocked", http.StatusOK),
+	)
+}
+
+func (c *AuthController) Identities(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.AuthUsecase.ListIdentities(ctx.Request.Context(), &model.ListIdentityRequest{UserID: userID})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to list identities", err)
+		ctx.Error(err)
+		return
+	}
+
 	ctx.JSON(
 		http.StatusOK,
 		model.NewSuccessResponse(res, http.StatusOK),
 	)
 }
+
+func (c *AuthController) UnlinkIdentity(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := &model.UnlinkIdentityRequest{
+		UserID:   userID,
+		Provider: ctx.Param("provider"),
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.AuthUsecase.UnlinkIdentity(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to unlink identity", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Identity unlinked", http.StatusOK),
+	)
+}
+
+func (c *AuthController) JWKS(ctx *gin.Context) {
+	// verifiers may cache the keys, a rotation is published before the new key starts signing
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.AuthUsecase.JWKS(ctx.Request.Context()))
+}
+
+// setSessionCookies moves the tokens of a finished login into cookies when the client asked for a browser session,
+// an mfa challenge carries no tokens and is returned as is
+func (c *AuthController) setSessionCookies(ctx *gin.Context, res *model.LoginResponse, useCookies bool) error {
+	if !useCookies || !c.Cookie.Enabled || res.AccessToken == "" {
+		return nil
+	}
+
+	err := c.Cookie.setTokens(ctx, res.AccessToken, res.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	res.AccessToken = ""
+	res.RefreshToken = ""
+	return nil
+}
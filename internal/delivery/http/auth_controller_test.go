@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"go-api-example/internal/auth"
 	"go-api-example/internal/config"
 	internalHttp "go-api-example/internal/delivery/http"
 	"go-api-example/internal/mocks"
@@ -13,6 +14,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
@@ -74,10 +76,10 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
 				a.On("Login", mock.Anything, mock.Anything).
-					Return(nil, model.ErrUserNotFound)
+					Return(nil, model.ErrInvalidCredentials)
 			},
-			wantStatus: http.StatusNotFound,
-			wantRes:    `{"errors":[{"code":1002,"message":"username not found"}],"meta":{"http_status":404}}`,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1013,"message":"invalid username or password"}],"meta":{"http_status":401}}`,
 		},
 		{
 			name: "unexpected error on login",
@@ -95,11 +97,14 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 		{
 			name: "success",
 			body: map[string]interface{}{
-				"username": "johndoe",
-				"password": "password",
+				"username":    "johndoe",
+				"password":    "password",
+				"device_name": "laptop",
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
-				a.On("Login", mock.Anything, mock.Anything).Return(&model.LoginResponse{
+				a.On("Login", mock.Anything, mock.MatchedBy(func(r *model.LoginRequest) bool {
+					return r.DeviceName == "laptop" && r.UserAgent == "curl/8.0" && r.IP == "192.0.2.1"
+				})).Return(&model.LoginResponse{
 					AccessToken:  "qwerty-12345",
 					RefreshToken: "zxc-123",
 				}, nil)
@@ -107,6 +112,21 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			wantStatus: http.StatusOK,
 			wantRes:    `{"data":{"access_token":"qwerty-12345","refresh_token":"zxc-123"},"meta":{"http_status":200}}`,
 		},
+		{
+			name: "success with mfa required",
+			body: map[string]interface{}{
+				"username": "johndoe",
+				"password": "password",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("Login", mock.Anything, mock.Anything).Return(&model.LoginResponse{
+					MFARequired: true,
+					MFAToken:    "mfa-123",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"mfa_required":true,"mfa_token":"mfa-123"},"meta":{"http_status":200}}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,7 +134,7 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			au := mocks.NewAuthUsecase(s.T())
 			tt.mockFunc(au)
 
-			ac := internalHttp.NewAuthController(s.log, s.validate, au)
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
 
 			app := config.NewGin(s.log)
 			app.POST("/api/login", ac.Login)
@@ -122,6 +142,7 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			reqBody, _ := json.Marshal(tt.body)
 			req := httptest.NewRequest("POST", "/api/login", bytes.NewReader(reqBody))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "curl/8.0")
 
 			rec := httptest.NewRecorder()
 			app.ServeHTTP(rec, req)
@@ -132,7 +153,7 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 	}
 }
 
-func (s *AuthControllerSuite) TestAuthController_Logout() {
+func (s *AuthControllerSuite) TestAuthController_VerifyMFA() {
 	tests := []struct {
 		name       string
 		body       any
@@ -141,43 +162,189 @@ func (s *AuthControllerSuite) TestAuthController_Logout() {
 		wantRes    string
 	}{
 		{
-			name:       "empty body",
-			body:       nil,
+			name: "error on validate body without code",
+			body: map[string]interface{}{
+				"mfa_token": "mfa-123",
+			},
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on validate body with invalid code",
+			body: map[string]interface{}{
+				"mfa_token": "mfa-123",
+				"code":      "1234",
+			},
 			mockFunc:   func(a *mocks.AuthUsecase) {},
 			wantStatus: http.StatusBadRequest,
 			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
 		},
+		{
+			name: "error invalid code",
+			body: map[string]interface{}{
+				"mfa_token": "mfa-123",
+				"code":      "123456",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("VerifyMFA", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidMFACode)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1019,"message":"invalid two-factor code"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "success with recovery code",
+			body: map[string]interface{}{
+				"mfa_token":     "mfa-123",
+				"recovery_code": "abcde-fghij",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("VerifyMFA", mock.Anything, mock.MatchedBy(func(r *model.VerifyMFARequest) bool {
+					return r.MFAToken == "mfa-123" && r.RecoveryCode == "abcde-fghij" && r.UserAgent == "curl/8.0" && r.IP == "192.0.2.1"
+				})).Return(&model.LoginResponse{
+					AccessToken:  "qwerty-12345",
+					RefreshToken: "zxc-123",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"access_token":"qwerty-12345","refresh_token":"zxc-123"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.POST("/api/login/mfa", ac.VerifyMFA)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/login/mfa", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "curl/8.0")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_RequestMagicLink() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
 		{
 			name: "error on validate body",
 			body: map[string]interface{}{
-				"refresh_token": "",
+				"email": "johndoe",
 			},
 			mockFunc:   func(a *mocks.AuthUsecase) {},
 			wantStatus: http.StatusBadRequest,
 			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
 		},
 		{
-			name: "error on logout",
+			name: "custom error on request magic link",
 			body: map[string]interface{}{
-				"refresh_token": "zxc-123",
+				"email": "johndoe@example.com",
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
-				a.On("Logout", mock.Anything, mock.Anything).
-					Return(errors.New("something error"))
+				a.On("RequestMagicLink", mock.Anything, mock.Anything).Return(model.ErrTooManyMagicLinkRequests)
 			},
-			wantStatus: http.StatusInternalServerError,
-			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+			wantStatus: http.StatusTooManyRequests,
+			wantRes:    `{"errors":[{"code":1036,"message":"too many magic link requests, try again later"}],"meta":{"http_status":429}}`,
 		},
 		{
 			name: "success",
 			body: map[string]interface{}{
-				"refresh_token": "zxc-123",
+				"email":       "johndoe@example.com",
+				"device_name": "laptop",
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
-				a.On("Logout", mock.Anything, mock.Anything).Return(nil)
+				a.On("RequestMagicLink", mock.Anything, &model.MagicLinkRequest{
+					Email:      "johndoe@example.com",
+					DeviceName: "laptop",
+					IP:         "192.0.2.1",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusAccepted,
+			wantRes:    `{"message":"If the email is registered, a login link has been sent","meta":{"http_status":202}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.POST("/api/login/magic-link", ac.RequestMagicLink)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/login/magic-link", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_LoginMagicLink() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{},
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error on login",
+			body: map[string]interface{}{
+				"token": "dummy",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("LoginMagicLink", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidMagicLinkToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1037,"message":"invalid or expired magic link"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"token": "dummy",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("LoginMagicLink", mock.Anything, mock.MatchedBy(func(r *model.MagicLinkLoginRequest) bool {
+					return r.Token == "dummy" && r.UserAgent == "curl/8.0" && r.IP == "192.0.2.1"
+				})).Return(&model.LoginResponse{
+					AccessToken:  "qwerty-12345",
+					RefreshToken: "zxc-123",
+				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantRes:    `{"message":"Logged out","meta":{"http_status":200}}`,
+			wantRes:    `{"data":{"access_token":"qwerty-12345","refresh_token":"zxc-123"},"meta":{"http_status":200}}`,
 		},
 	}
 
@@ -186,15 +353,15 @@ func (s *AuthControllerSuite) TestAuthController_Logout() {
 			au := mocks.NewAuthUsecase(s.T())
 			tt.mockFunc(au)
 
-			ac := internalHttp.NewAuthController(s.log, s.validate, au)
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
 
 			app := config.NewGin(s.log)
-			app.Use(test.NewAuthMiddleware(1))
-			app.POST("/api/logout", ac.Logout)
+			app.POST("/api/login/magic-link/verify", ac.LoginMagicLink)
 
 			reqBody, _ := json.Marshal(tt.body)
-			req := httptest.NewRequest("POST", "/api/logout", bytes.NewReader(reqBody))
+			req := httptest.NewRequest("POST", "/api/login/magic-link/verify", bytes.NewReader(reqBody))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "curl/8.0")
 
 			rec := httptest.NewRecorder()
 			app.ServeHTTP(rec, req)
@@ -205,7 +372,146 @@ func (s *AuthControllerSuite) TestAuthController_Logout() {
 	}
 }
 
-func (s *AuthControllerSuite) TestAuthController_RefreshToken() {
+func (s *AuthControllerSuite) TestAuthController_AuthorizeOIDC() {
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "device name too long",
+			url:        "/api/oidc/google/authorize?device_name=" + strings.Repeat("a", 101),
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "provider not found",
+			url:  "/api/oidc/other/authorize",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("AuthorizeOIDC", mock.Anything, &model.AuthorizeOIDCRequest{Provider: "other"}).
+					Return(nil, model.ErrOIDCProviderNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1022,"message":"login provider not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			url:  "/api/oidc/google/authorize?device_name=laptop",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("AuthorizeOIDC", mock.Anything, &model.AuthorizeOIDCRequest{Provider: "google", DeviceName: "laptop"}).
+					Return(&model.AuthorizeOIDCResponse{AuthorizationURL: "https://idp.example.com/authorize?state=state-123"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"authorization_url":"https://idp.example.com/authorize?state=state-123"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.GET("/api/oidc/:provider/authorize", ac.AuthorizeOIDC)
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_OIDCCallback() {
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "provider returned an error",
+			url:        "/api/oidc/google/callback?error=access_denied&state=state-123",
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1024,"message":"login with provider failed"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "missing code",
+			url:        "/api/oidc/google/callback?state=state-123",
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "invalid state",
+			url:  "/api/oidc/google/callback?code=code-123&state=state-123",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("LoginOIDC", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidOIDCState)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1023,"message":"invalid or expired login state"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "account not linked",
+			url:  "/api/oidc/google/callback?code=code-123&state=state-123",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("LoginOIDC", mock.Anything, mock.Anything).Return(nil, model.ErrOIDCAccountNotLinked)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1025,"message":"no account linked to this provider login"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "success",
+			url:  "/api/oidc/google/callback?code=code-123&state=state-123",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("LoginOIDC", mock.Anything, &model.OIDCCallbackRequest{
+					Provider:  "google",
+					Code:      "code-123",
+					State:     "state-123",
+					UserAgent: "curl/8.0",
+					IP:        "192.0.2.1",
+					RequestID: "req-123",
+				}).Return(&model.LoginResponse{AccessToken: "qwerty-12345", RefreshToken: "zxc-123"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"access_token":"qwerty-12345","refresh_token":"zxc-123"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.GET("/api/oidc/:provider/callback", ac.OIDCCallback)
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			req.Header.Set("User-Agent", "curl/8.0")
+			req.Header.Set("X-Request-ID", "req-123")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_Logout() {
 	tests := []struct {
 		name       string
 		body       any
@@ -230,13 +536,13 @@ func (s *AuthControllerSuite) TestAuthController_RefreshToken() {
 			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
 		},
 		{
-			name: "error on refresh",
+			name: "error on logout",
 			body: map[string]interface{}{
 				"refresh_token": "zxc-123",
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
-				a.On("Refresh", mock.Anything, mock.Anything).
-					Return(nil, errors.New("something error"))
+				a.On("Logout", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
@@ -247,13 +553,10 @@ func (s *AuthControllerSuite) TestAuthController_RefreshToken() {
 				"refresh_token": "zxc-123",
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
-				a.On("Refresh", mock.Anything, mock.Anything).Return(&model.RefreshResponse{
-					AccessToken:  "qwerty-12345",
-					RefreshToken: "zxc-123",
-				}, nil)
+				a.On("Logout", mock.Anything, mock.Anything).Return(nil)
 			},
 			wantStatus: http.StatusOK,
-			wantRes:    `{"data":{"access_token":"qwerty-12345","refresh_token":"zxc-123"},"meta":{"http_status":200}}`,
+			wantRes:    `{"message":"Logged out","meta":{"http_status":200}}`,
 		},
 	}
 
@@ -262,13 +565,14 @@ func (s *AuthControllerSuite) TestAuthController_RefreshToken() {
 			au := mocks.NewAuthUsecase(s.T())
 			tt.mockFunc(au)
 
-			ac := internalHttp.NewAuthController(s.log, s.validate, au)
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
 
 			app := config.NewGin(s.log)
-			app.POST("/api/refresh-token", ac.RefreshToken)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/logout", ac.Logout)
 
 			reqBody, _ := json.Marshal(tt.body)
-			req := httptest.NewRequest("POST", "/api/refresh-token", bytes.NewReader(reqBody))
+			req := httptest.NewRequest("POST", "/api/logout", bytes.NewReader(reqBody))
 			req.Header.Set("Content-Type", "application/json")
 
 			rec := httptest.NewRecorder()
@@ -280,6 +584,529 @@ func (s *AuthControllerSuite) TestAuthController_RefreshToken() {
 	}
 }
 
+func (s *AuthControllerSuite) TestAuthController_RefreshToken() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "empty body",
+			body:       nil,
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on validate body",
+			body: map[string]interface{}{
+				"refresh_token": "",
+			},
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on refresh",
+			body: map[string]interface{}{
+				"refresh_token": "zxc-123",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("Refresh", mock.Anything, mock.Anything).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"refresh_token": "zxc-123",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("Refresh", mock.Anything, mock.Anything).Return(&model.RefreshResponse{
+					AccessToken:  "qwerty-12345",
+					RefreshToken: "zxc-123",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"access_token":"qwerty-12345","refresh_token":"zxc-123"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.POST("/api/refresh-token", ac.RefreshToken)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/refresh-token", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_CookieSession() {
+	cookieConfig := internalHttp.CookieConfig{
+		Enabled:         true,
+		Secure:          true,
+		SameSite:        http.SameSiteStrictMode,
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}
+
+	s.Run("login", func() {
+		au := mocks.NewAuthUsecase(s.T())
+		au.On("Login", mock.Anything, mock.Anything).Return(&model.LoginResponse{
+			AccessToken:  "qwerty-12345",
+			RefreshToken: "zxc-123",
+		}, nil)
+
+		ac := internalHttp.NewAuthController(s.log, s.validate, au, cookieConfig)
+
+		app := config.NewGin(s.log)
+		app.POST("/api/login", ac.Login)
+
+		reqBody, _ := json.Marshal(map[string]interface{}{
+			"username":    "johndoe",
+			"password":    "password",
+			"use_cookies": true,
+		})
+		req := httptest.NewRequest("POST", "/api/login", bytes.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusOK, rec.Code)
+		s.Equal(`{"data":{},"meta":{"http_status":200}}`, strings.TrimSpace(rec.Body.String()))
+
+		cookies := map[string]*http.Cookie{}
+		for _, cookie := range rec.Result().Cookies() {
+			cookies[cookie.Name] = cookie
+		}
+		s.Equal("qwerty-12345", cookies["access_token"].Value)
+		s.True(cookies["access_token"].HttpOnly)
+		s.True(cookies["access_token"].Secure)
+		s.Equal(http.SameSiteStrictMode, cookies["access_token"].SameSite)
+		s.Equal(900, cookies["access_token"].MaxAge)
+		s.Equal("zxc-123", cookies["refresh_token"].Value)
+		s.Equal("/api", cookies["refresh_token"].Path)
+		s.True(cookies["refresh_token"].HttpOnly)
+		s.NotEmpty(cookies["csrf_token"].Value)
+		s.False(cookies["csrf_token"].HttpOnly)
+	})
+
+	s.Run("refresh", func() {
+		au := mocks.NewAuthUsecase(s.T())
+		au.On("Refresh", mock.Anything, mock.MatchedBy(func(r *model.RefreshRequest) bool {
+			return r.RefreshToken == "zxc-123"
+		})).Return(&model.RefreshResponse{
+			AccessToken:  "qwerty-67890",
+			RefreshToken: "zxc-456",
+		}, nil)
+
+		ac := internalHttp.NewAuthController(s.log, s.validate, au, cookieConfig)
+
+		app := config.NewGin(s.log)
+		app.POST("/api/refresh-token", ac.RefreshToken)
+
+		req := httptest.NewRequest("POST", "/api/refresh-token", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "zxc-123"})
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusOK, rec.Code)
+		s.Equal(`{"data":{},"meta":{"http_status":200}}`, strings.TrimSpace(rec.Body.String()))
+
+		cookies := map[string]string{}
+		for _, cookie := range rec.Result().Cookies() {
+			cookies[cookie.Name] = cookie.Value
+		}
+		s.Equal("qwerty-67890", cookies["access_token"])
+		s.Equal("zxc-456", cookies["refresh_token"])
+		s.NotEmpty(cookies["csrf_token"])
+	})
+
+	s.Run("logout", func() {
+		au := mocks.NewAuthUsecase(s.T())
+		au.On("Logout", mock.Anything, mock.MatchedBy(func(r *model.LogoutRequest) bool {
+			return r.RefreshToken == "zxc-123"
+		})).Return(nil)
+
+		ac := internalHttp.NewAuthController(s.log, s.validate, au, cookieConfig)
+
+		app := config.NewGin(s.log)
+		app.Use(test.NewAuthMiddleware(1))
+		app.POST("/api/logout", ac.Logout)
+
+		req := httptest.NewRequest("POST", "/api/logout", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "zxc-123"})
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusOK, rec.Code)
+		s.Equal(`{"message":"Logged out","meta":{"http_status":200}}`, strings.TrimSpace(rec.Body.String()))
+
+		cookies := rec.Result().Cookies()
+		s.Len(cookies, 3)
+		for _, cookie := range cookies {
+			s.Empty(cookie.Value)
+			s.Equal(-1, cookie.MaxAge)
+		}
+	})
+}
+
+func (s *AuthControllerSuite) TestAuthController_Sessions() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on list sessions",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("ListSessions", mock.Anything, mock.Anything).
+					Return([]model.SessionResponse{}, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("ListSessions", mock.Anything, mock.MatchedBy(func(r *model.ListSessionRequest) bool {
+					return r.Claims.UserID == "1"
+				})).Return([]model.SessionResponse{
+					{
+						ID:         "qwe-123",
+						DeviceName: "laptop",
+						UserAgent:  "curl/8.0",
+						IP:         "127.0.0.1",
+						Current:    true,
+						CreatedAt:  "2025-08-13T10:00:00Z",
+						LastUsedAt: "2025-08-13T10:00:00Z",
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":"qwe-123","device_name":"laptop","user_agent":"curl/8.0","ip":"127.0.0.1",` +
+				`"current":true,"created_at":"2025-08-13T10:00:00Z","last_used_at":"2025-08-13T10:00:00Z"}],` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/sessions", ac.Sessions)
+
+			req := httptest.NewRequest("GET", "/api/sessions", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_RevokeSession() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "session not found",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("RevokeSession", mock.Anything, mock.Anything).Return(model.ErrSessionNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1012,"message":"session not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("RevokeSession", mock.Anything, mock.MatchedBy(func(r *model.RevokeSessionRequest) bool {
+					return r.ID == "qwe-123" && r.Claims.UserID == "1"
+				})).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Session revoked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/sessions/:id", ac.RevokeSession)
+
+			req := httptest.NewRequest("DELETE", "/api/sessions/qwe-123", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_RevokeAllSessions() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on revoke all sessions",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("RevokeAllSessions", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("RevokeAllSessions", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"All sessions revoked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/sessions", ac.RevokeAllSessions)
+
+			req := httptest.NewRequest("DELETE", "/api/sessions", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_UnlockLogin() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "user not found",
+			id:   "2",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("UnlockLogin", mock.Anything, &model.UnlockLoginRequest{UserID: 2}).Return(model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1002,"message":"username not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("UnlockLogin", mock.Anything, &model.UnlockLoginRequest{UserID: 2}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Login unlocked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/admin/users/:id/unlock", ac.UnlockLogin)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/"+tt.id+"/unlock", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_Identities() {
+	email := "johndoe@example.com"
+
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "unexpected error",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("ListIdentities", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("ListIdentities", mock.Anything, &model.ListIdentityRequest{UserID: 1}).Return([]model.IdentityResponse{
+					{Provider: "google", Email: &email, CreatedAt: "2025-08-13T10:00:00Z"},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":[{"provider":"google","email":"johndoe@example.com","created_at":"2025-08-13T10:00:00Z"}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/users/me/identities", ac.Identities)
+
+			req := httptest.NewRequest("GET", "/api/users/me/identities", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_UnlinkIdentity() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "identity not found",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("UnlinkIdentity", mock.Anything, mock.Anything).Return(model.ErrIdentityNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1026,"message":"linked identity not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("UnlinkIdentity", mock.Anything, &model.UnlinkIdentityRequest{UserID: 1, Provider: "google"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Identity unlinked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/users/me/identities/:provider", ac.UnlinkIdentity)
+
+			req := httptest.NewRequest("DELETE", "/api/users/me/identities/google", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AuthControllerSuite) TestAuthController_JWKS() {
+	au := mocks.NewAuthUsecase(s.T())
+	au.On("JWKS", mock.Anything).Return(&auth.JSONWebKeySet{
+		Keys: []auth.JSONWebKey{{Kty: "OKP", Kid: "key-1", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "abc"}},
+	})
+
+	ac := internalHttp.NewAuthController(s.log, s.validate, au, internalHttp.CookieConfig{})
+
+	app := config.NewGin(s.log)
+	app.GET("/.well-known/jwks.json", ac.JWKS)
+
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("public, max-age=300", rec.Header().Get("Cache-Control"))
+	s.Equal(`{"keys":[{"kty":"OKP","kid":"key-1","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"abc"}]}`,
+		strings.TrimSpace(rec.Body.String()))
+}
+
 func TestAuthControllerSuite(t *testing.T) {
 	suite.Run(t, new(AuthControllerSuite))
 }
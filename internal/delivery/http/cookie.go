@@ -0,0 +1,75 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"go-api-example/internal/delivery/http/middleware"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CookieConfig enables the browser session mode, login and refresh then hand the tokens out as HttpOnly cookies
+// together with a csrf token cookie for the double-submit check
+type CookieConfig struct {
+	Enabled         bool
+	Domain          string
+	Secure          bool
+	SameSite        http.SameSite
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// refreshToken returns the refresh token cookie of a browser session, it is empty when the cookie mode is disabled
+func (c *CookieConfig) refreshToken(ctx *gin.Context) string {
+	if !c.Enabled {
+		return ""
+	}
+
+	token, err := ctx.Cookie(middleware.RefreshTokenCookie)
+	if err != nil {
+		return ""
+	}
+
+	return token
+}
+
+// setTokens stores the tokens in cookies and rotates the csrf token
+func (c *CookieConfig) setTokens(ctx *gin.Context, accessToken string, refreshToken string) error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	csrfToken := base64.RawURLEncoding.EncodeToString(b)
+
+	c.set(ctx, middleware.AccessTokenCookie, accessToken, "/", c.AccessTokenTTL, true)
+	c.set(ctx, middleware.RefreshTokenCookie, refreshToken, "/api", c.RefreshTokenTTL, true)
+	c.set(ctx, middleware.CSRFTokenCookie, csrfToken, "/", c.RefreshTokenTTL, false)
+
+	return nil
+}
+
+func (c *CookieConfig) clearTokens(ctx *gin.Context) {
+	c.set(ctx, middleware.AccessTokenCookie, "", "/", -1, true)
+	c.set(ctx, middleware.RefreshTokenCookie, "", "/api", -1, true)
+	c.set(ctx, middleware.CSRFTokenCookie, "", "/", -1, false)
+}
+
+func (c *CookieConfig) set(ctx *gin.Context, name string, value string, path string, ttl time.Duration, httpOnly bool) {
+	maxAge := -1
+	if ttl > 0 {
+		maxAge = int(ttl.Seconds())
+	}
+
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   c.Domain,
+		MaxAge:   maxAge,
+		Secure:   c.Secure,
+		HttpOnly: httpOnly,
+		SameSite: c.SameSite,
+	})
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type EmailController struct {
+	Log          *zap.Logger
+	Validate     *validator.Validate
+	EmailUsecase usecase.EmailUsecase
+}
+
+func NewEmailController(log *zap.Logger, validate *validator.Validate, emailUsecase usecase.EmailUsecase) *EmailController {
+	return &EmailController{
+		Log:          log,
+		Validate:     validate,
+		EmailUsecase: emailUsecase,
+	}
+}
+
+func (c *EmailController) Verify(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.VerifyEmailRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.UserID = userID
+	err = c.EmailUsecase.RequestVerification(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to request email verification", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusAccepted,
+		model.NewSuccessMessageResponse("Verification email sent", http.StatusAccepted),
+	)
+}
+
+func (c *EmailController) Confirm(ctx *gin.Context) {
+	request := new(model.ConfirmEmailRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.EmailUsecase.ConfirmVerification(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to confirm email verification", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Email verified", http.StatusOK),
+	)
+}
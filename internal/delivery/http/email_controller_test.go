@@ -0,0 +1,186 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type EmailControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *EmailControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *EmailControllerSuite) TestEmailController_Verify() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.EmailUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on validate body",
+			body: map[string]interface{}{
+				"email": "not-an-email",
+			},
+			mockFunc:   func(a *mocks.EmailUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error email already exist",
+			body: map[string]interface{}{
+				"email": "johndoe@example.com",
+			},
+			mockFunc: func(a *mocks.EmailUsecase) {
+				a.On("RequestVerification", mock.Anything, mock.Anything).Return(model.ErrEmailAlreadyExist)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1008,"message":"email already exist"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"email": "johndoe@example.com",
+			},
+			mockFunc: func(a *mocks.EmailUsecase) {
+				a.On("RequestVerification", mock.Anything, &model.VerifyEmailRequest{
+					UserID: 1,
+					Email:  "johndoe@example.com",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusAccepted,
+			wantRes:    `{"message":"Verification email sent","meta":{"http_status":202}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewEmailUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewEmailController(s.log, s.validate, eu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/users/me/email/verify", ec.Verify)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/users/me/email/verify", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *EmailControllerSuite) TestEmailController_VerifyImpersonated() {
+	eu := mocks.NewEmailUsecase(s.T())
+	ec := internalHttp.NewEmailController(s.log, s.validate, eu)
+
+	app := config.NewGin(s.log)
+	app.Use(test.NewImpersonationAuthMiddleware(1, 2))
+	app.POST("/api/users/me/email/verify", middleware.ForbidImpersonation(), ec.Verify)
+
+	reqBody, _ := json.Marshal(map[string]interface{}{"email": "johndoe@example.com"})
+	req := httptest.NewRequest("POST", "/api/users/me/email/verify", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusForbidden, rec.Code)
+	s.Equal(`{"errors":[{"code":1033,"message":"action not allowed while impersonating"}],"meta":{"http_status":403}}`,
+		strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *EmailControllerSuite) TestEmailController_Confirm() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.EmailUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{},
+			mockFunc:   func(a *mocks.EmailUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error invalid token",
+			body: map[string]interface{}{
+				"token": "dummy",
+			},
+			mockFunc: func(a *mocks.EmailUsecase) {
+				a.On("ConfirmVerification", mock.Anything, mock.Anything).Return(model.ErrInvalidEmailToken)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1010,"message":"invalid or expired email verification token"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"token": "dummy",
+			},
+			mockFunc: func(a *mocks.EmailUsecase) {
+				a.On("ConfirmVerification", mock.Anything, &model.ConfirmEmailRequest{Token: "dummy"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Email verified","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewEmailUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewEmailController(s.log, s.validate, eu)
+
+			app := config.NewGin(s.log)
+			app.POST("/api/users/email/confirm", ec.Confirm)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/users/email/confirm", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestEmailControllerSuite(t *testing.T) {
+	suite.Run(t, new(EmailControllerSuite))
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type ImpersonationController struct {
+	Log                  *zap.Logger
+	Validate             *validator.Validate
+	ImpersonationUsecase usecase.ImpersonationUsecase
+}
+
+func NewImpersonationController(log *zap.Logger, validate *validator.Validate,
+	impersonationUsecase usecase.ImpersonationUsecase) *ImpersonationController {
+	return &ImpersonationController{
+		Log:                  log,
+		Validate:             validate,
+		ImpersonationUsecase: impersonationUsecase,
+	}
+}
+
+func (c *ImpersonationController) Impersonate(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	actorID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.ImpersonationUsecase.Impersonate(ctx.Request.Context(), &model.ImpersonateRequest{
+		ActorID:   actorID,
+		UserID:    userID,
+		Method:    ctx.Request.Method,
+		Path:      ctx.Request.URL.Path,
+		IP:        ctx.ClientIP(),
+		RequestID: requestid.Get(ctx),
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to impersonate user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
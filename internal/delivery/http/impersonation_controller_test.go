@@ -0,0 +1,131 @@
+package http_test
+
+import (
+	"errors"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ImpersonationControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *ImpersonationControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *ImpersonationControllerSuite) TestImpersonationController_Impersonate() {
+	request := &model.ImpersonateRequest{
+		ActorID:   1,
+		UserID:    2,
+		Method:    "POST",
+		Path:      "/api/admin/users/2/impersonate",
+		IP:        "192.0.2.1",
+		RequestID: "req-123",
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(i *mocks.ImpersonationUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid id",
+			id:         "abc",
+			mockFunc:   func(i *mocks.ImpersonationUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "user not found",
+			id:   "2",
+			mockFunc: func(i *mocks.ImpersonationUsecase) {
+				i.On("Impersonate", mock.Anything, request).
+					Return(nil, model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1002,"message":"username not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "user can not be impersonated",
+			id:   "2",
+			mockFunc: func(i *mocks.ImpersonationUsecase) {
+				i.On("Impersonate", mock.Anything, request).
+					Return(nil, model.ErrImpersonationNotAllowed)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1034,"message":"user can not be impersonated"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "user suspended",
+			id:   "2",
+			mockFunc: func(i *mocks.ImpersonationUsecase) {
+				i.On("Impersonate", mock.Anything, request).Return(nil, model.ErrUserSuspended)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1042,"message":"account suspended"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "unexpected error",
+			id:   "2",
+			mockFunc: func(i *mocks.ImpersonationUsecase) {
+				i.On("Impersonate", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(i *mocks.ImpersonationUsecase) {
+				i.On("Impersonate", mock.Anything, request).
+					Return(&model.ImpersonateResponse{AccessToken: "dummy-token", ExpiresAt: "2025-08-13T10:10:00Z"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes:    `{"data":{"access_token":"dummy-token","expires_at":"2025-08-13T10:10:00Z"},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			iu := mocks.NewImpersonationUsecase(s.T())
+			tt.mockFunc(iu)
+
+			ic := internalHttp.NewImpersonationController(s.log, s.validate, iu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/admin/users/:id/impersonate", ic.Impersonate)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/"+tt.id+"/impersonate", nil)
+			req.Header.Set("X-Request-ID", "req-123")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestImpersonationControllerSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationControllerSuite))
+}
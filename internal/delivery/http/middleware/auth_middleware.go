@@ -6,6 +6,7 @@ import (
 	"go-api-example/internal/auth"
 	"go-api-example/internal/model"
 	"go-api-example/internal/storage"
+	"go-api-example/internal/usecase"
 	"strings"
 
 	"github.com/gin-contrib/requestid"
@@ -13,9 +14,23 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken) gin.HandlerFunc {
+// NewAuthMiddleware accepts a jwt from a login session or a personal access token, the latter is recognized by its prefix.
+// Without an authorization header the jwt is read from the access token cookie of a browser session, as long as the
+// cookie mode is enabled.
+// A jwt is rejected once it is revoked or its user changed the password or was suspended after it was issued, every
+// request made with an impersonation token is recorded before it is handled
+func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken,
+	authUsecase usecase.AuthUsecase, personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase,
+	impersonationUsecase usecase.ImpersonationUsecase, cookieEnabled bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
+		if authHeader == "" && cookieEnabled {
+			// browser sessions send the access token in a cookie instead
+			if cookie, err := ctx.Cookie(AccessTokenCookie); err == nil && cookie != "" {
+				authHeader = "Bearer " + cookie
+			}
+		}
+
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			logger.Warn("missing or invalid auth header",
 				zap.Any("request_id", requestid.Get(ctx)),
@@ -28,6 +43,28 @@ func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtT
 		}
 
 		token := strings.TrimPrefix(authHeader, "Bearer ")
+		if strings.HasPrefix(token, auth.PersonalAccessTokenPrefix) {
+			claims, err := personalAccessTokenUsecase.Authenticate(ctx.Request.Context(), token)
+			if err != nil {
+				logger.Warn(err.Error(),
+					zap.Any("request_id", requestid.Get(ctx)),
+					zap.Any("path", ctx.Request.RequestURI),
+					zap.Any("method", ctx.Request.Method),
+				)
+				if errors.Is(err, model.ErrUserSuspended) {
+					ctx.Error(model.ErrUserSuspended)
+				} else {
+					ctx.Error(model.ErrInvalidAuthToken)
+				}
+				ctx.Abort()
+				return
+			}
+
+			ctx.Set("claims", claims)
+			ctx.Next()
+			return
+		}
+
 		claims, err := jwtToken.Parse(token)
 		if err != nil {
 			logger.Warn(err.Error(),
@@ -59,6 +96,42 @@ func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtT
 			return
 		}
 
+		err = authUsecase.VerifyTokenVersion(ctx.Request.Context(), claims)
+		if err != nil {
+			logger.Warn(err.Error(),
+				zap.Any("request_id", requestid.Get(ctx)),
+				zap.Any("path", ctx.Request.RequestURI),
+				zap.Any("method", ctx.Request.Method),
+			)
+			if errors.Is(err, model.ErrTokenRevoked) {
+				ctx.Error(model.ErrTokenRevoked)
+			} else {
+				ctx.Error(model.ErrInvalidAuthToken)
+			}
+			ctx.Abort()
+			return
+		}
+
+		if claims.IsImpersonated() {
+			err = impersonationUsecase.Record(ctx.Request.Context(), &model.RecordImpersonationRequest{
+				Claims:    claims,
+				Method:    ctx.Request.Method,
+				Path:      ctx.Request.URL.Path,
+				IP:        ctx.ClientIP(),
+				RequestID: requestid.Get(ctx),
+			})
+			if err != nil {
+				logger.Warn(err.Error(),
+					zap.Any("request_id", requestid.Get(ctx)),
+					zap.Any("path", ctx.Request.RequestURI),
+					zap.Any("method", ctx.Request.Method),
+				)
+				ctx.Error(model.ErrInternalServerError)
+				ctx.Abort()
+				return
+			}
+		}
+
 		ctx.Set("claims", claims)
 		ctx.Next()
 	}
@@ -32,23 +32,26 @@ func (s *AuthMiddlewareSuite) SetupTest() {
 
 func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 	tests := []struct {
-		name       string
-		authToken  string
-		mockFunc   func(rc *mocks.RedisClient, j *mocks.JWTToken)
-		wantStatus int
-		wantRes    string
+		name           string
+		authToken      string
+		cookie         string
+		cookieDisabled bool
+		mockFunc       func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase)
+		wantStatus     int
+		wantRes        string
 	}{
 		{
-			name:       "empty auth token",
-			authToken:  "",
-			mockFunc:   func(rc *mocks.RedisClient, j *mocks.JWTToken) {},
+			name:      "empty auth token",
+			authToken: "",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+			},
 			wantStatus: http.StatusUnauthorized,
 			wantRes:    `{"errors":[{"code":104,"message":"missing or invalid auth header"}],"meta":{"http_status":401}}`,
 		},
 		{
 			name:      "invalid auth token",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
 				j.On("Parse", "dummy-token").
 					Return(nil, errors.New("something error"))
 			},
@@ -58,7 +61,7 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 		{
 			name:      "error on get revoked token cache",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
 				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
 					UserID: "1",
 					RegisteredClaims: jwt.RegisteredClaims{
@@ -75,7 +78,7 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 		{
 			name:      "revoked auth token",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
 				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
 					UserID: "1",
 					RegisteredClaims: jwt.RegisteredClaims{
@@ -89,12 +92,87 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 			wantStatus: http.StatusUnauthorized,
 			wantRes:    `{"errors":[{"code":106,"message":"token revoked"}],"meta":{"http_status":401}}`,
 		},
+		{
+			name:      "error on verify token version",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				au.On("VerifyTokenVersion", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":105,"message":"invalid auth token"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "outdated token version",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				au.On("VerifyTokenVersion", mock.Anything, mock.Anything).Return(model.ErrTokenRevoked)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":106,"message":"token revoked"}],"meta":{"http_status":401}}`,
+		},
 		{
 			name:      "success",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				au.On("VerifyTokenVersion", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"user_id":"1"},"meta":{"http_status":200}}`,
+		},
+		{
+			name:      "error on record impersonation",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					Actor:  &auth.Actor{UserID: "2"},
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				au.On("VerifyTokenVersion", mock.Anything, mock.Anything).Return(nil)
+				iu.On("Record", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:      "success with impersonation token",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
 				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
 					UserID: "1",
+					Actor:  &auth.Actor{UserID: "2"},
 					RegisteredClaims: jwt.RegisteredClaims{
 						ID: "zxc-123",
 					},
@@ -102,6 +180,80 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 				existsCmd := redis.NewIntCmd(context.Background())
 				existsCmd.SetVal(0)
 				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				au.On("VerifyTokenVersion", mock.Anything, mock.Anything).Return(nil)
+				iu.On("Record", mock.Anything, mock.MatchedBy(func(req *model.RecordImpersonationRequest) bool {
+					return req.Claims.Actor.UserID == "2" && req.Method == "GET" && req.Path == "/"
+				})).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"user_id":"1"},"meta":{"http_status":200}}`,
+		},
+		{
+			name:           "error on access token cookie while cookies are disabled",
+			cookie:         "dummy-token",
+			cookieDisabled: true,
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":104,"message":"missing or invalid auth header"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:   "success with access token cookie",
+			cookie: "dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				au.On("VerifyTokenVersion", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"user_id":"1"},"meta":{"http_status":200}}`,
+		},
+		{
+			name:      "invalid personal access token",
+			authToken: "Bearer pat_dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				pu.On("Authenticate", mock.Anything, "pat_dummy-token").
+					Return(nil, model.ErrInvalidAuthToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":105,"message":"invalid auth token"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "error on authenticate personal access token",
+			authToken: "Bearer pat_dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				pu.On("Authenticate", mock.Anything, "pat_dummy-token").
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":105,"message":"invalid auth token"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "personal access token of suspended user",
+			authToken: "Bearer pat_dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				pu.On("Authenticate", mock.Anything, "pat_dummy-token").
+					Return(nil, model.ErrUserSuspended)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1042,"message":"account suspended"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:      "success with personal access token",
+			authToken: "Bearer pat_dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, au *mocks.AuthUsecase, pu *mocks.PersonalAccessTokenUsecase, iu *mocks.ImpersonationUsecase) {
+				pu.On("Authenticate", mock.Anything, "pat_dummy-token").Return(&auth.JWTClaims{
+					UserID:                "1",
+					Permissions:           []string{auth.PermissionTodoRead},
+					PersonalAccessTokenID: 1,
+				}, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantRes:    `{"data":{"user_id":"1"},"meta":{"http_status":200}}`,
@@ -112,9 +264,12 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 		s.Run(tt.name, func() {
 			rc := mocks.NewRedisClient(s.T())
 			jwt := mocks.NewJWTToken(s.T())
-			tt.mockFunc(rc, jwt)
+			au := mocks.NewAuthUsecase(s.T())
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			iu := mocks.NewImpersonationUsecase(s.T())
+			tt.mockFunc(rc, jwt, au, pu, iu)
 
-			authMw := middleware.NewAuthMiddleware(s.log, rc, jwt)
+			authMw := middleware.NewAuthMiddleware(s.log, rc, jwt, au, pu, iu, !tt.cookieDisabled)
 
 			app := config.NewGin(s.log)
 			app.Use(authMw)
@@ -140,6 +295,9 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 			req := httptest.NewRequest("GET", "/", nil)
 			req.Header.Set("Content-Type", "application/json")
 			req.Header.Set("Authorization", tt.authToken)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "access_token", Value: tt.cookie})
+			}
 
 			rec := httptest.NewRecorder()
 			app.ServeHTTP(rec, req)
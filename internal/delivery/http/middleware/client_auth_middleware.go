@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"go-api-example/internal/model"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// NewClientAuthMiddleware authenticates internal services with HTTP basic auth against the configured client id and
+// secret pairs, the client id is kept in the context for the handlers
+func NewClientAuthMiddleware(logger *zap.Logger, clients map[string]string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		clientID, secret, ok := ctx.Request.BasicAuth()
+		expected, known := clients[clientID]
+		if !ok || !known || !equalSecret(secret, expected) {
+			logger.Warn("invalid client credentials",
+				zap.Any("request_id", requestid.Get(ctx)),
+				zap.Any("path", ctx.Request.RequestURI),
+				zap.Any("method", ctx.Request.Method),
+				zap.String("client_id", clientID),
+			)
+			ctx.Header("WWW-Authenticate", `Basic realm="api"`)
+			ctx.Error(model.ErrInvalidClient)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set("client_id", clientID)
+		ctx.Next()
+	}
+}
+
+func GetClientID(ctx *gin.Context) string {
+	return ctx.GetString("client_id")
+}
+
+// equalSecret compares digests so the time taken does not depend on where the secrets differ or on their length
+func equalSecret(secret string, expected string) bool {
+	a := sha256.Sum256([]byte(secret))
+	b := sha256.Sum256([]byte(expected))
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
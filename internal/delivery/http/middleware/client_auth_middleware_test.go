@@ -0,0 +1,95 @@
+package middleware_test
+
+import (
+	"go-api-example/internal/config"
+	"go-api-example/internal/delivery/http/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ClientAuthMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *ClientAuthMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *ClientAuthMiddlewareSuite) TestClientAuthMiddleware_Handler() {
+	clients := map[string]string{"billing": "billing-secret"}
+
+	tests := []struct {
+		name       string
+		clientID   string
+		secret     string
+		basicAuth  bool
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing credentials",
+			basicAuth:  false,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":107,"message":"invalid client credentials"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "unknown client",
+			clientID:   "reporting",
+			secret:     "billing-secret",
+			basicAuth:  true,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":107,"message":"invalid client credentials"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "wrong secret",
+			clientID:   "billing",
+			secret:     "wrong-secret",
+			basicAuth:  true,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":107,"message":"invalid client credentials"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "valid credentials",
+			clientID:   "billing",
+			secret:     "billing-secret",
+			basicAuth:  true,
+			wantStatus: http.StatusOK,
+			wantRes:    "billing",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := config.NewGin(s.log)
+			app.Use(middleware.NewClientAuthMiddleware(s.log, clients))
+			app.POST("/", func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, middleware.GetClientID(ctx))
+			})
+
+			req := httptest.NewRequest("POST", "/", nil)
+			if tt.basicAuth {
+				req.SetBasicAuth(tt.clientID, tt.secret)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			if tt.wantStatus == http.StatusUnauthorized {
+				s.Equal(`Basic realm="api"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestClientAuthMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(ClientAuthMiddlewareSuite))
+}
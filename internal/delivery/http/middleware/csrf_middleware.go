@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"go-api-example/internal/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	// CSRFTokenCookie is readable by the browser app, which repeats it in the CSRFTokenHeader
+	CSRFTokenCookie = "csrf_token"
+	CSRFTokenHeader = "X-CSRF-Token"
+)
+
+// RequireCSRF protects requests authenticated by the session cookies with the double-submit pattern, the csrf header
+// has to repeat the csrf cookie on every state-changing request. Requests with an authorization header carry no
+// ambient credentials and are let through
+func RequireCSRF() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+
+		if ctx.GetHeader("Authorization") != "" || !hasSessionCookie(ctx) {
+			ctx.Next()
+			return
+		}
+
+		csrfToken, err := ctx.Cookie(CSRFTokenCookie)
+		if err != nil || csrfToken == "" ||
+			subtle.ConstantTimeCompare([]byte(csrfToken), []byte(ctx.GetHeader(CSRFTokenHeader))) != 1 {
+			ctx.Error(model.ErrInvalidCSRFToken)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func hasSessionCookie(ctx *gin.Context) bool {
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie} {
+		if value, err := ctx.Cookie(name); err == nil && value != "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package middleware_test
+
+import (
+	"go-api-example/internal/config"
+	"go-api-example/internal/delivery/http/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type CSRFMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *CSRFMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *CSRFMiddlewareSuite) TestRequireCSRF_Handler() {
+	tests := []struct {
+		name       string
+		method     string
+		cookies    map[string]string
+		headers    map[string]string
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "safe method",
+			method:     http.MethodGet,
+			cookies:    map[string]string{"access_token": "dummy-token"},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+		{
+			name:       "without session cookie",
+			method:     http.MethodPost,
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+		{
+			name:       "with authorization header",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"access_token": "dummy-token"},
+			headers:    map[string]string{"Authorization": "Bearer dummy-token"},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+		{
+			name:       "missing csrf token",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"access_token": "dummy-token", "csrf_token": "csrf-123"},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1035,"message":"missing or invalid csrf token"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:       "missing csrf cookie",
+			method:     http.MethodDelete,
+			cookies:    map[string]string{"refresh_token": "dummy-token"},
+			headers:    map[string]string{"X-CSRF-Token": "csrf-123"},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1035,"message":"missing or invalid csrf token"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:       "mismatched csrf token",
+			method:     http.MethodPatch,
+			cookies:    map[string]string{"access_token": "dummy-token", "csrf_token": "csrf-123"},
+			headers:    map[string]string{"X-CSRF-Token": "csrf-456"},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1035,"message":"missing or invalid csrf token"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:       "valid csrf token",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"access_token": "dummy-token", "csrf_token": "csrf-123"},
+			headers:    map[string]string{"X-CSRF-Token": "csrf-123"},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := config.NewGin(s.log)
+			app.Use(middleware.RequireCSRF())
+			app.Handle(tt.method, "/", func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for name, value := range tt.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestCSRFMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(CSRFMiddlewareSuite))
+}
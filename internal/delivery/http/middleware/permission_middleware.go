@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"go-api-example/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission must run after the auth middleware, every given permission has to be granted to the token
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !claims.HasPermission(permission) {
+				ctx.Error(model.ErrForbidden)
+				ctx.Abort()
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireSession must run after the auth middleware, it keeps personal access tokens away from routes that manage the account itself
+func RequireSession() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		if claims.IsPersonalAccessToken() {
+			ctx.Error(model.ErrForbidden)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireScope must run after the auth middleware, personal access tokens need every given scope while login
+// sessions act with the full rights of the user
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		if claims.IsPersonalAccessToken() {
+			for _, scope := range scopes {
+				if !claims.HasPermission(scope) {
+					ctx.Error(model.ErrForbidden)
+					ctx.Abort()
+					return
+				}
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// ForbidImpersonation must run after the auth middleware, it keeps impersonation tokens away from sensitive account actions
+func ForbidImpersonation() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		if claims.IsImpersonated() {
+			ctx.Error(model.ErrImpersonationForbidden)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
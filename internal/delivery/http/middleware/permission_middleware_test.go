@@ -0,0 +1,285 @@
+package middleware_test
+
+import (
+	"go-api-example/internal/auth"
+	"go-api-example/internal/config"
+	"go-api-example/internal/delivery/http/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PermissionMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *PermissionMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *PermissionMiddlewareSuite) TestPermissionMiddleware_Handler() {
+	tests := []struct {
+		name        string
+		claims      *auth.JWTClaims
+		permissions []string
+		wantStatus  int
+		wantRes     string
+	}{
+		{
+			name:        "missing claims",
+			claims:      nil,
+			permissions: []string{auth.PermissionUserRead},
+			wantStatus:  http.StatusUnauthorized,
+			wantRes:     `{"errors":[{"code":101,"message":"unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "missing permission",
+			claims: &auth.JWTClaims{
+				UserID:      "1",
+				Role:        auth.RoleUser,
+				Permissions: []string{auth.PermissionTodoRead},
+			},
+			permissions: []string{auth.PermissionTodoRead, auth.PermissionUserRead},
+			wantStatus:  http.StatusForbidden,
+			wantRes:     `{"errors":[{"code":103,"message":"forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "granted permission",
+			claims: &auth.JWTClaims{
+				UserID:      "1",
+				Role:        auth.RoleUser,
+				Permissions: []string{auth.PermissionTodoRead, auth.PermissionTodoWrite},
+			},
+			permissions: []string{auth.PermissionTodoRead, auth.PermissionTodoWrite},
+			wantStatus:  http.StatusOK,
+			wantRes:     "OK",
+		},
+		{
+			name: "admin role",
+			claims: &auth.JWTClaims{
+				UserID:      "1",
+				Role:        auth.RoleAdmin,
+				Permissions: []string{auth.PermissionAll},
+			},
+			permissions: []string{auth.PermissionUserRead},
+			wantStatus:  http.StatusOK,
+			wantRes:     "OK",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := config.NewGin(s.log)
+			app.Use(func(ctx *gin.Context) {
+				if tt.claims != nil {
+					ctx.Set("claims", tt.claims)
+				}
+				ctx.Next()
+			})
+			app.GET("/", middleware.RequirePermission(tt.permissions...), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PermissionMiddlewareSuite) TestRequireSession_Handler() {
+	tests := []struct {
+		name       string
+		claims     *auth.JWTClaims
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing claims",
+			claims:     nil,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":101,"message":"unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "personal access token",
+			claims: &auth.JWTClaims{
+				UserID:                "1",
+				Permissions:           []string{auth.PermissionTodoRead},
+				PersonalAccessTokenID: 1,
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "session",
+			claims: &auth.JWTClaims{
+				UserID:    "1",
+				SessionID: "dummy-sid",
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := config.NewGin(s.log)
+			app.Use(func(ctx *gin.Context) {
+				if tt.claims != nil {
+					ctx.Set("claims", tt.claims)
+				}
+				ctx.Next()
+			})
+			app.GET("/", middleware.RequireSession(), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PermissionMiddlewareSuite) TestRequireScope_Handler() {
+	tests := []struct {
+		name       string
+		claims     *auth.JWTClaims
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing claims",
+			claims:     nil,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":101,"message":"unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "personal access token without scope",
+			claims: &auth.JWTClaims{
+				UserID:                "1",
+				Permissions:           []string{auth.PermissionTodoRead},
+				PersonalAccessTokenID: 1,
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "personal access token with scope",
+			claims: &auth.JWTClaims{
+				UserID:                "1",
+				Permissions:           []string{auth.PermissionUserRead},
+				PersonalAccessTokenID: 1,
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+		{
+			name: "session",
+			claims: &auth.JWTClaims{
+				UserID:    "1",
+				SessionID: "dummy-sid",
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := config.NewGin(s.log)
+			app.Use(func(ctx *gin.Context) {
+				if tt.claims != nil {
+					ctx.Set("claims", tt.claims)
+				}
+				ctx.Next()
+			})
+			app.GET("/", middleware.RequireScope(auth.PermissionUserRead), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PermissionMiddlewareSuite) TestForbidImpersonation_Handler() {
+	tests := []struct {
+		name       string
+		claims     *auth.JWTClaims
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing claims",
+			claims:     nil,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":101,"message":"unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "impersonation token",
+			claims: &auth.JWTClaims{
+				UserID: "1",
+				Actor:  &auth.Actor{UserID: "2"},
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1033,"message":"action not allowed while impersonating"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "session",
+			claims: &auth.JWTClaims{
+				UserID:    "1",
+				SessionID: "dummy-sid",
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := config.NewGin(s.log)
+			app.Use(func(ctx *gin.Context) {
+				if tt.claims != nil {
+					ctx.Set("claims", tt.claims)
+				}
+				ctx.Next()
+			})
+			app.GET("/", middleware.ForbidImpersonation(), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPermissionMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(PermissionMiddlewareSuite))
+}
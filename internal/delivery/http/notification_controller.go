@@ -0,0 +1,154 @@
+package http
+
+import (
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type NotificationController struct {
+	Log                 *zap.Logger
+	Validate            *validator.Validate
+	NotificationUsecase usecase.NotificationUsecase
+}
+
+func NewNotificationController(log *zap.Logger, validate *validator.Validate,
+	notificationUsecase usecase.NotificationUsecase) *NotificationController {
+	return &NotificationController{
+		Log:                 log,
+		Validate:            validate,
+		NotificationUsecase: notificationUsecase,
+	}
+}
+
+func (c *NotificationController) Search(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	unreadOnly, err := strconv.ParseBool(ctx.DefaultQuery("unread_only", "false"))
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert unread only", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	request := &model.SearchNotificationRequest{
+		UserID:     userID,
+		UnreadOnly: unreadOnly,
+		Limit:      limit,
+		Offset:     offset,
+	}
+	res, total, unread, err := c.NotificationUsecase.List(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get notifications", err)
+		ctx.Error(err)
+		return
+	}
+
+	meta := model.MetaWithUnread{
+		Limit:      limit,
+		Offset:     offset,
+		Total:      total,
+		Unread:     unread,
+		HTTPStatus: http.StatusOK,
+	}
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessListWithUnreadResponse(res, meta),
+	)
+}
+
+func (c *NotificationController) Read(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.NotificationUsecase.MarkReadByID(ctx.Request.Context(), &model.ReadNotificationRequest{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to mark notification as read", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Notification marked as read", http.StatusOK),
+	)
+}
+
+func (c *NotificationController) ReadAll(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.NotificationUsecase.MarkAllRead(ctx.Request.Context(), &model.ReadAllNotificationRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to mark all notifications as read", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("All notifications marked as read", http.StatusOK),
+	)
+}
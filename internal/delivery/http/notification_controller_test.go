@@ -0,0 +1,229 @@
+package http_test
+
+import (
+	"errors"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type NotificationControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *NotificationControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *NotificationControllerSuite) TestNotificationController_Search() {
+	readAt := "2025-10-27T13:07:31Z"
+
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func(a *mocks.NotificationUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid unread only",
+			url:        "/api/notifications?unread_only=maybe",
+			mockFunc:   func(a *mocks.NotificationUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on list",
+			url:  "/api/notifications",
+			mockFunc: func(a *mocks.NotificationUsecase) {
+				a.On("List", mock.Anything, mock.Anything).
+					Return(nil, 0, 0, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			url:  "/api/notifications?unread_only=true&limit=5&offset=0",
+			mockFunc: func(a *mocks.NotificationUsecase) {
+				a.On("List", mock.Anything, &model.SearchNotificationRequest{
+					UserID:     1,
+					UnreadOnly: true,
+					Limit:      5,
+					Offset:     0,
+				}).Return([]model.NotificationResponse{
+					{
+						ID:        2,
+						Type:      "todo_assigned",
+						Title:     "New todo assigned to you",
+						Body:      "body",
+						CreatedAt: "2025-10-27T13:07:31Z",
+					},
+					{
+						ID:        1,
+						Type:      "user_registered",
+						Title:     "Welcome to the Todo App",
+						Body:      "body",
+						ReadAt:    &readAt,
+						CreatedAt: "2025-10-27T13:07:31Z",
+					},
+				}, 2, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":2,"type":"todo_assigned","title":"New todo assigned to you","body":"body",` +
+				`"read_at":null,"created_at":"2025-10-27T13:07:31Z"},{"id":1,"type":"user_registered",` +
+				`"title":"Welcome to the Todo App","body":"body","read_at":"2025-10-27T13:07:31Z",` +
+				`"created_at":"2025-10-27T13:07:31Z"}],"meta":{"limit":5,"offset":0,"total":2,"unread":1,"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nu := mocks.NewNotificationUsecase(s.T())
+			tt.mockFunc(nu)
+
+			nc := internalHttp.NewNotificationController(s.log, s.validate, nu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/notifications", nc.Search)
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *NotificationControllerSuite) TestNotificationController_Read() {
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func(a *mocks.NotificationUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid id",
+			url:        "/api/notifications/abc/read",
+			mockFunc:   func(a *mocks.NotificationUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error not found",
+			url:  "/api/notifications/1/read",
+			mockFunc: func(a *mocks.NotificationUsecase) {
+				a.On("MarkReadByID", mock.Anything, mock.Anything).
+					Return(model.ErrNotificationNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":3000,"message":"notification not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			url:  "/api/notifications/1/read",
+			mockFunc: func(a *mocks.NotificationUsecase) {
+				a.On("MarkReadByID", mock.Anything, &model.ReadNotificationRequest{
+					ID:     1,
+					UserID: 1,
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Notification marked as read","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nu := mocks.NewNotificationUsecase(s.T())
+			tt.mockFunc(nu)
+
+			nc := internalHttp.NewNotificationController(s.log, s.validate, nu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.PATCH("/api/notifications/:id/read", nc.Read)
+
+			req := httptest.NewRequest("PATCH", tt.url, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *NotificationControllerSuite) TestNotificationController_ReadAll() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.NotificationUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on mark all read",
+			mockFunc: func(a *mocks.NotificationUsecase) {
+				a.On("MarkAllRead", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.NotificationUsecase) {
+				a.On("MarkAllRead", mock.Anything, &model.ReadAllNotificationRequest{
+					UserID: 1,
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"All notifications marked as read","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nu := mocks.NewNotificationUsecase(s.T())
+			tt.mockFunc(nu)
+
+			nc := internalHttp.NewNotificationController(s.log, s.validate, nu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/notifications/read-all", nc.ReadAll)
+
+			req := httptest.NewRequest("POST", "/api/notifications/read-all", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestNotificationControllerSuite(t *testing.T) {
+	suite.Run(t, new(NotificationControllerSuite))
+}
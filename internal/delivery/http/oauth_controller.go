@@ -0,0 +1,83 @@
+package http
+
+import (
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type OAuthController struct {
+	Log          *zap.Logger
+	Validate     *validator.Validate
+	OAuthUsecase usecase.OAuthUsecase
+}
+
+func NewOAuthController(log *zap.Logger, validate *validator.Validate, oauthUsecase usecase.OAuthUsecase) *OAuthController {
+	return &OAuthController{
+		Log:          log,
+		Validate:     validate,
+		OAuthUsecase: oauthUsecase,
+	}
+}
+
+// Introspect answers with the plain RFC 7662 document instead of the usual response envelope
+func (c *OAuthController) Introspect(ctx *gin.Context) {
+	request := new(model.IntrospectTokenRequest)
+	err := ctx.ShouldBind(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.ClientID = middleware.GetClientID(ctx)
+	res, err := c.OAuthUsecase.Introspect(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to introspect token", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Revoke answers with an empty body, unknown tokens are treated as revoked already
+func (c *OAuthController) Revoke(ctx *gin.Context) {
+	request := new(model.RevokeTokenRequest)
+	err := ctx.ShouldBind(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.ClientID = middleware.GetClientID(ctx)
+	err = c.OAuthUsecase.Revoke(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to revoke token", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,181 @@
+package http_test
+
+import (
+	"errors"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OAuthControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *OAuthControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *OAuthControllerSuite) withClient(ctx *gin.Context) {
+	ctx.Set("client_id", "billing")
+	ctx.Next()
+}
+
+func (s *OAuthControllerSuite) TestOAuthController_Introspect() {
+	tests := []struct {
+		name       string
+		form       url.Values
+		mockFunc   func(o *mocks.OAuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing token",
+			form:       url.Values{},
+			mockFunc:   func(o *mocks.OAuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "unexpected error",
+			form: url.Values{"token": {"qwe.asd.zxc"}},
+			mockFunc: func(o *mocks.OAuthUsecase) {
+				o.On("Introspect", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "inactive token",
+			form: url.Values{"token": {"zxc-123"}},
+			mockFunc: func(o *mocks.OAuthUsecase) {
+				o.On("Introspect", mock.Anything, mock.Anything).Return(&model.IntrospectTokenResponse{Active: false}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"active":false}`,
+		},
+		{
+			name: "active token",
+			form: url.Values{"token": {"qwe.asd.zxc"}, "token_type_hint": {"access_token"}},
+			mockFunc: func(o *mocks.OAuthUsecase) {
+				o.On("Introspect", mock.Anything, &model.IntrospectTokenRequest{
+					Token:         "qwe.asd.zxc",
+					TokenTypeHint: "access_token",
+					ClientID:      "billing",
+				}).Return(&model.IntrospectTokenResponse{
+					Active:    true,
+					TokenType: model.TokenTypeAccessToken,
+					Scope:     "todos:read",
+					Sub:       "1",
+					Exp:       1755079800,
+					Iat:       1755078900,
+					Jti:       "asd-789",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"active":true,"token_type":"access_token","scope":"todos:read","sub":"1","exp":1755079800,"iat":1755078900,"jti":"asd-789"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ou := mocks.NewOAuthUsecase(s.T())
+			tt.mockFunc(ou)
+
+			oc := internalHttp.NewOAuthController(s.log, s.validate, ou)
+
+			app := config.NewGin(s.log)
+			app.Use(s.withClient)
+			app.POST("/api/oauth/introspect", oc.Introspect)
+
+			req := httptest.NewRequest("POST", "/api/oauth/introspect", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *OAuthControllerSuite) TestOAuthController_Revoke() {
+	tests := []struct {
+		name       string
+		form       url.Values
+		mockFunc   func(o *mocks.OAuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing token",
+			form:       url.Values{},
+			mockFunc:   func(o *mocks.OAuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "unexpected error",
+			form: url.Values{"token": {"zxc-123"}},
+			mockFunc: func(o *mocks.OAuthUsecase) {
+				o.On("Revoke", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			form: url.Values{"token": {"zxc-123"}, "token_type_hint": {"refresh_token"}},
+			mockFunc: func(o *mocks.OAuthUsecase) {
+				o.On("Revoke", mock.Anything, &model.RevokeTokenRequest{
+					Token:         "zxc-123",
+					TokenTypeHint: "refresh_token",
+					ClientID:      "billing",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ou := mocks.NewOAuthUsecase(s.T())
+			tt.mockFunc(ou)
+
+			oc := internalHttp.NewOAuthController(s.log, s.validate, ou)
+
+			app := config.NewGin(s.log)
+			app.Use(s.withClient)
+			app.POST("/api/oauth/revoke", oc.Revoke)
+
+			req := httptest.NewRequest("POST", "/api/oauth/revoke", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOAuthControllerSuite(t *testing.T) {
+	suite.Run(t, new(OAuthControllerSuite))
+}
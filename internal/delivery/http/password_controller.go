@@ -0,0 +1,87 @@
+package http
+
+import (
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PasswordController struct {
+	Log             *zap.Logger
+	Validate        *validator.Validate
+	PasswordUsecase usecase.PasswordUsecase
+}
+
+func NewPasswordController(log *zap.Logger, validate *validator.Validate, passwordUsecase usecase.PasswordUsecase) *PasswordController {
+	return &PasswordController{
+		Log:             log,
+		Validate:        validate,
+		PasswordUsecase: passwordUsecase,
+	}
+}
+
+func (c *PasswordController) Forgot(ctx *gin.Context) {
+	request := new(model.ForgotPasswordRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.PasswordUsecase.Forgot(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to request password reset", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusAccepted,
+		model.NewSuccessMessageResponse("If the email is registered, a password reset link has been sent", http.StatusAccepted),
+	)
+}
+
+func (c *PasswordController) Reset(ctx *gin.Context) {
+	request := new(model.ResetPasswordRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
+	err = c.PasswordUsecase.Reset(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to reset password", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Password reset", http.StatusOK),
+	)
+}
@@ -0,0 +1,163 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PasswordControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *PasswordControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *PasswordControllerSuite) TestPasswordController_Forgot() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.PasswordUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on validate body",
+			body: map[string]interface{}{
+				"email": "johndoe",
+			},
+			mockFunc:   func(a *mocks.PasswordUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"email": "johndoe@example.com",
+			},
+			mockFunc: func(a *mocks.PasswordUsecase) {
+				a.On("Forgot", mock.Anything, &model.ForgotPasswordRequest{
+					Email: "johndoe@example.com",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusAccepted,
+			wantRes:    `{"message":"If the email is registered, a password reset link has been sent","meta":{"http_status":202}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPasswordUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPasswordController(s.log, s.validate, pu)
+
+			app := config.NewGin(s.log)
+			app.POST("/api/password/forgot", pc.Forgot)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/password/forgot", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PasswordControllerSuite) TestPasswordController_Reset() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.PasswordUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on validate body",
+			body: map[string]interface{}{
+				"token": "dummy",
+			},
+			mockFunc:   func(a *mocks.PasswordUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error invalid token",
+			body: map[string]interface{}{
+				"token":        "dummy",
+				"new_password": "newpassword",
+			},
+			mockFunc: func(a *mocks.PasswordUsecase) {
+				a.On("Reset", mock.Anything, mock.Anything).Return(model.ErrInvalidPasswordResetToken)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1011,"message":"invalid or expired password reset token"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"token":        "dummy",
+				"new_password": "newpassword",
+			},
+			mockFunc: func(a *mocks.PasswordUsecase) {
+				a.On("Reset", mock.Anything, &model.ResetPasswordRequest{
+					Token:       "dummy",
+					NewPassword: "newpassword",
+					UserAgent:   "curl/8.0",
+					IP:          "192.0.2.1",
+					RequestID:   "req-123",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Password reset","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPasswordUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPasswordController(s.log, s.validate, pu)
+
+			app := config.NewGin(s.log)
+			app.POST("/api/password/reset", pc.Reset)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/password/reset", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "curl/8.0")
+			req.Header.Set("X-Request-ID", "req-123")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPasswordControllerSuite(t *testing.T) {
+	suite.Run(t, new(PasswordControllerSuite))
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenController struct {
+	Log                        *zap.Logger
+	Validate                   *validator.Validate
+	PersonalAccessTokenUsecase usecase.PersonalAccessTokenUsecase
+}
+
+func NewPersonalAccessTokenController(log *zap.Logger, validate *validator.Validate,
+	personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		Log:                        log,
+		Validate:                   validate,
+		PersonalAccessTokenUsecase: personalAccessTokenUsecase,
+	}
+}
+
+func (c *PersonalAccessTokenController) Create(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.CreatePersonalAccessTokenRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.UserID = userID
+	res, err := c.PersonalAccessTokenUsecase.Create(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to create personal access token", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
+
+func (c *PersonalAccessTokenController) List(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.PersonalAccessTokenUsecase.List(ctx.Request.Context(), &model.ListPersonalAccessTokenRequest{UserID: userID})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to list personal access tokens", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *PersonalAccessTokenController) Revoke(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.PersonalAccessTokenUsecase.Revoke(ctx.Request.Context(), &model.RevokePersonalAccessTokenRequest{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to revoke personal access token", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Personal access token revoked", http.StatusOK),
+	)
+}
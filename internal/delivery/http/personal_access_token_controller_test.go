@@ -0,0 +1,245 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *PersonalAccessTokenControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *PersonalAccessTokenControllerSuite) TestPersonalAccessTokenController_Create() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(p *mocks.PersonalAccessTokenUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid request body",
+			body:       "invalid",
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "missing scopes",
+			body:       map[string]any{"name": "ci"},
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "unknown scope",
+			body:       map[string]any{"name": "ci", "scopes": []string{"users:manage"}},
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "invalid expiry",
+			body:       map[string]any{"name": "ci", "scopes": []string{"todos:read"}, "expires_in_days": 0},
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error scope not granted",
+			body: map[string]any{"name": "ci", "scopes": []string{"users:read"}},
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Create", mock.Anything, mock.Anything).Return(nil, model.ErrScopeNotGranted)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1021,"message":"scope not granted to user role"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "unexpected error",
+			body: map[string]any{"name": "ci", "scopes": []string{"todos:read"}},
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			body: map[string]any{"name": "ci", "scopes": []string{"todos:read"}},
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Create", mock.Anything, &model.CreatePersonalAccessTokenRequest{
+					UserID: 1,
+					Name:   "ci",
+					Scopes: []string{"todos:read"},
+				}).Return(&model.CreatePersonalAccessTokenResponse{
+					PersonalAccessTokenResponse: model.PersonalAccessTokenResponse{
+						ID:        1,
+						Name:      "ci",
+						Scopes:    []string{"todos:read"},
+						CreatedAt: "2025-08-13T10:00:00Z",
+					},
+					Token: "pat_dummy-secret",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes:    `{"data":{"id":1,"name":"ci","scopes":["todos:read"],"last_used_at":null,"expires_at":null,"created_at":"2025-08-13T10:00:00Z","token":"pat_dummy-secret"},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPersonalAccessTokenController(s.log, s.validate, pu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/tokens", pc.Create)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/tokens", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PersonalAccessTokenControllerSuite) TestPersonalAccessTokenController_List() {
+	lastUsedAt := "2025-08-14T10:00:00Z"
+
+	tests := []struct {
+		name       string
+		mockFunc   func(p *mocks.PersonalAccessTokenUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "unexpected error",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("List", mock.Anything, &model.ListPersonalAccessTokenRequest{UserID: 1}).Return([]model.PersonalAccessTokenResponse{
+					{ID: 1, Name: "ci", Scopes: []string{"todos:read"}, LastUsedAt: &lastUsedAt, CreatedAt: "2025-08-13T10:00:00Z"},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":[{"id":1,"name":"ci","scopes":["todos:read"],"last_used_at":"2025-08-14T10:00:00Z","expires_at":null,"created_at":"2025-08-13T10:00:00Z"}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPersonalAccessTokenController(s.log, s.validate, pu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/tokens", pc.List)
+
+			req := httptest.NewRequest("GET", "/api/tokens", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PersonalAccessTokenControllerSuite) TestPersonalAccessTokenController_Revoke() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(p *mocks.PersonalAccessTokenUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid id",
+			id:         "abc",
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error token not found",
+			id:   "1",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Revoke", mock.Anything, mock.Anything).Return(model.ErrAccessTokenNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1020,"message":"personal access token not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "1",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Revoke", mock.Anything, &model.RevokePersonalAccessTokenRequest{ID: 1, UserID: 1}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Personal access token revoked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPersonalAccessTokenController(s.log, s.validate, pu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/tokens/:id", pc.Revoke)
+
+			req := httptest.NewRequest("DELETE", "/api/tokens/"+tt.id, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPersonalAccessTokenControllerSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenControllerSuite))
+}
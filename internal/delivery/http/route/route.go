@@ -1,26 +1,44 @@
 package route
 
 import (
+	"go-api-example/internal/auth"
+	"go-api-example/internal/avatar"
 	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/delivery/http/middleware"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 type RouteConfig struct {
-	App            *gin.Engine
-	AuthMiddlware  gin.HandlerFunc
-	AuthController *internalHttp.AuthController
-	UserController *internalHttp.UserController
-	TodoController *internalHttp.TodoController
+	App                           *gin.Engine
+	AuthMiddlware                 gin.HandlerFunc
+	ClientAuthMiddleware          gin.HandlerFunc
+	AuthController                *internalHttp.AuthController
+	UserController                *internalHttp.UserController
+	EmailController               *internalHttp.EmailController
+	PasswordController            *internalHttp.PasswordController
+	TodoController                *internalHttp.TodoController
+	NotificationController        *internalHttp.NotificationController
+	TwoFactorController           *internalHttp.TwoFactorController
+	PersonalAccessTokenController *internalHttp.PersonalAccessTokenController
+	ImpersonationController       *internalHttp.ImpersonationController
+	OAuthController               *internalHttp.OAuthController
+	SecurityEventController       *internalHttp.SecurityEventController
+	AvatarDir                     string
 }
 
 func (c *RouteConfig) Setup() {
+	c.App.Use(middleware.RequireCSRF())
+
 	c.App.GET("/healthz", func(ctx *gin.Context) {
 		ctx.String(http.StatusOK, "OK")
 	})
+	c.App.GET("/.well-known/jwks.json", c.AuthController.JWKS)
+	c.App.Static(avatar.URLPath, c.AvatarDir)
 
 	c.SetupPublicRoute()
+	c.SetupClientRoute()
 	c.SetupAuthRoute()
 }
 
@@ -30,20 +48,66 @@ func (c *RouteConfig) SetupPublicRoute() {
 	})
 
 	c.App.POST("/api/login", c.AuthController.Login)
+	c.App.POST("/api/login/mfa", c.AuthController.VerifyMFA)
+	c.App.POST("/api/login/magic-link", c.AuthController.RequestMagicLink)
+	c.App.POST("/api/login/magic-link/verify", c.AuthController.LoginMagicLink)
+	c.App.GET("/api/oidc/:provider/authorize", c.AuthController.AuthorizeOIDC)
+	c.App.GET("/api/oidc/:provider/callback", c.AuthController.OIDCCallback)
 	c.App.POST("/api/refresh-token", c.AuthController.RefreshToken)
+	c.App.POST("/api/password/forgot", c.PasswordController.Forgot)
+	c.App.POST("/api/password/reset", c.PasswordController.Reset)
 
 	c.App.POST("/api/users", c.UserController.Register)
+	c.App.POST("/api/users/email/confirm", c.EmailController.Confirm)
+}
+
+func (c *RouteConfig) SetupClientRoute() {
+	c.App.POST("/api/oauth/introspect", c.ClientAuthMiddleware, c.OAuthController.Introspect)
+	c.App.POST("/api/oauth/revoke", c.ClientAuthMiddleware, c.OAuthController.Revoke)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
-	c.App.POST("/api/logout", c.AuthMiddlware, c.AuthController.Logout)
+	c.App.POST("/api/logout", c.AuthMiddlware, middleware.RequireSession(), c.AuthController.Logout)
+	c.App.GET("/api/sessions", c.AuthMiddlware, middleware.RequireSession(), c.AuthController.Sessions)
+	c.App.DELETE("/api/sessions", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.AuthController.RevokeAllSessions)
+	c.App.DELETE("/api/sessions/:id", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.AuthController.RevokeSession)
+
+	c.App.POST("/api/tokens", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.PersonalAccessTokenController.Create)
+	c.App.GET("/api/tokens", c.AuthMiddlware, middleware.RequireSession(), c.PersonalAccessTokenController.List)
+	c.App.DELETE("/api/tokens/:id", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.PersonalAccessTokenController.Revoke)
+
+	c.App.POST("/api/admin/users/:id/impersonate", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), middleware.RequirePermission(auth.PermissionUserImpersonate), c.ImpersonationController.Impersonate)
+	c.App.POST("/api/admin/users/:id/unlock", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), middleware.RequirePermission(auth.PermissionUserManage), c.AuthController.UnlockLogin)
+	c.App.GET("/api/admin/users", c.AuthMiddlware, middleware.RequireSession(), middleware.RequirePermission(auth.PermissionUserManage), c.UserController.AdminSearch)
+	c.App.GET("/api/admin/users/:id", c.AuthMiddlware, middleware.RequireSession(), middleware.RequirePermission(auth.PermissionUserManage), c.UserController.AdminGet)
+	c.App.DELETE("/api/admin/users/:id", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), middleware.RequirePermission(auth.PermissionUserManage), c.UserController.AdminDelete)
+	c.App.POST("/api/admin/users/:id/suspend", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), middleware.RequirePermission(auth.PermissionUserManage), c.UserController.Suspend)
+	c.App.POST("/api/admin/users/:id/unsuspend", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), middleware.RequirePermission(auth.PermissionUserManage), c.UserController.Unsuspend)
+	c.App.POST("/api/admin/users/:id/password-reset", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), middleware.RequirePermission(auth.PermissionUserManage), c.UserController.ForcePasswordReset)
+
+	c.App.GET("/api/users", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionUserRead), c.UserController.Search)
+	c.App.GET("/api/users/me", c.AuthMiddlware, middleware.RequireScope(auth.PermissionUserRead), c.UserController.Me)
+	c.App.PATCH("/api/users/me", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.UserController.Update)
+	c.App.DELETE("/api/users/me", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.UserController.Delete)
+	c.App.PUT("/api/users/me/avatar", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.UserController.UpdateAvatar)
+	c.App.DELETE("/api/users/me/avatar", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.UserController.DeleteAvatar)
+	c.App.POST("/api/users/me/email/verify", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.EmailController.Verify)
+	c.App.POST("/api/users/me/totp", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.TwoFactorController.Enroll)
+	c.App.POST("/api/users/me/totp/confirm", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.TwoFactorController.Confirm)
+	c.App.DELETE("/api/users/me/totp", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.TwoFactorController.Disable)
+	c.App.GET("/api/users/me/identities", c.AuthMiddlware, middleware.RequireSession(), c.AuthController.Identities)
+	c.App.DELETE("/api/users/me/identities/:provider", c.AuthMiddlware, middleware.RequireSession(), middleware.ForbidImpersonation(), c.AuthController.UnlinkIdentity)
+	c.App.GET("/api/users/me/security-events", c.AuthMiddlware, middleware.RequireSession(), c.SecurityEventController.Search)
 
-	c.App.GET("/api/users", c.AuthMiddlware, c.UserController.Search)
-	c.App.GET("/api/users/me", c.AuthMiddlware, c.UserController.Me)
-	c.App.PATCH("/api/users/me", c.AuthMiddlware, c.UserController.Update)
+	c.App.POST("/api/todos", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoWrite), c.TodoController.Create)
+	c.App.GET("/api/todos", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoRead), c.TodoController.Search)
+	c.App.GET("/api/todos/board", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoRead), c.TodoController.Board)
+	c.App.GET("/api/todos/:id", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoRead), c.TodoController.Get)
+	c.App.PATCH("/api/todos/:id", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoWrite), c.TodoController.Update)
+	c.App.DELETE("/api/todos/:id", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoWrite), c.TodoController.Delete)
+	c.App.PUT("/api/todos/:id/assignee", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionTodoWrite), c.TodoController.Assign)
 
-	c.App.POST("/api/todos", c.AuthMiddlware, c.TodoController.Create)
-	c.App.GET("/api/todos", c.AuthMiddlware, c.TodoController.Search)
-	c.App.GET("/api/todos/:id", c.AuthMiddlware, c.TodoController.Get)
-	c.App.PATCH("/api/todos/:id", c.AuthMiddlware, c.TodoController.Update)
+	c.App.GET("/api/notifications", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionNotificationRead), c.NotificationController.Search)
+	c.App.PATCH("/api/notifications/:id/read", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionNotificationWrite), c.NotificationController.Read)
+	c.App.POST("/api/notifications/read-all", c.AuthMiddlware, middleware.RequirePermission(auth.PermissionNotificationWrite), c.NotificationController.ReadAll)
 }
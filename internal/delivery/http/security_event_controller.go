@@ -0,0 +1,85 @@
+package http
+
+import (
+	"go-api-example/internal/delivery/http/middleware"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type SecurityEventController struct {
+	Log                  *zap.Logger
+	Validate             *validator.Validate
+	SecurityEventUsecase usecase.SecurityEventUsecase
+}
+
+func NewSecurityEventController(log *zap.Logger, validate *validator.Validate,
+	securityEventUsecase usecase.SecurityEventUsecase) *SecurityEventController {
+	return &SecurityEventController{
+		Log:                  log,
+		Validate:             validate,
+		SecurityEventUsecase: securityEventUsecase,
+	}
+}
+
+func (c *SecurityEventController) Search(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	request := &model.SearchSecurityEventRequest{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request query", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, total, err := c.SecurityEventUsecase.List(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get security events", err)
+		ctx.Error(err)
+		return
+	}
+
+	meta := model.MetaWithPage{
+		Limit:      limit,
+		Offset:     offset,
+		Total:      total,
+		HTTPStatus: http.StatusOK,
+	}
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessListResponse(res, meta),
+	)
+}
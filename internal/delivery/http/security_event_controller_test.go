@@ -0,0 +1,116 @@
+package http_test
+
+import (
+	"errors"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SecurityEventControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *SecurityEventControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *SecurityEventControllerSuite) TestSecurityEventController_Search() {
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func(a *mocks.SecurityEventUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid limit",
+			url:        "/api/users/me/security-events?limit=100",
+			mockFunc:   func(a *mocks.SecurityEventUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on list",
+			url:  "/api/users/me/security-events",
+			mockFunc: func(a *mocks.SecurityEventUsecase) {
+				a.On("List", mock.Anything, mock.Anything).
+					Return(nil, 0, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			url:  "/api/users/me/security-events?limit=5&offset=0",
+			mockFunc: func(a *mocks.SecurityEventUsecase) {
+				a.On("List", mock.Anything, &model.SearchSecurityEventRequest{
+					UserID: 1,
+					Limit:  5,
+					Offset: 0,
+				}).Return([]model.SecurityEventResponse{
+					{
+						ID:         2,
+						Type:       "login",
+						SessionID:  "qwe-123",
+						IP:         "127.0.0.1",
+						UserAgent:  "curl/8.0",
+						RequestID:  "req-123",
+						OccurredAt: "2026-10-18T09:20:00Z",
+					},
+					{
+						ID:         1,
+						Type:       "login_failed",
+						IP:         "127.0.0.1",
+						UserAgent:  "curl/8.0",
+						OccurredAt: "2026-10-18T09:19:00Z",
+					},
+				}, 2, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":2,"type":"login","session_id":"qwe-123","ip":"127.0.0.1","user_agent":"curl/8.0",` +
+				`"request_id":"req-123","occurred_at":"2026-10-18T09:20:00Z"},{"id":1,"type":"login_failed",` +
+				`"ip":"127.0.0.1","user_agent":"curl/8.0","occurred_at":"2026-10-18T09:19:00Z"}],` +
+				`"meta":{"limit":5,"offset":0,"total":2,"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSecurityEventUsecase(s.T())
+			tt.mockFunc(su)
+
+			sc := internalHttp.NewSecurityEventController(s.log, s.validate, su)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/users/me/security-events", sc.Search)
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestSecurityEventControllerSuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventControllerSuite))
+}
@@ -99,6 +99,13 @@ func (c *TodoController) Search(ctx *gin.Context) {
 		status = &ts
 	}
 
+	assignedToMe, err := strconv.ParseBool(ctx.DefaultQuery("assigned_to_me", "false"))
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert assigned to me", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
 	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	if err != nil || limit <= 0 {
 		limit = 10
@@ -110,10 +117,11 @@ func (c *TodoController) Search(ctx *gin.Context) {
 	}
 
 	request := &model.SearchTodoRequest{
-		UserID: userID,
-		Status: status,
-		Limit:  limit,
-		Offset: offset,
+		UserID:       userID,
+		AssignedToMe: assignedToMe,
+		Status:       status,
+		Limit:        limit,
+		Offset:       offset,
 	}
 	res, total, err := c.TodoUsecase.List(ctx.Request.Context(), request)
 	if err != nil {
@@ -134,6 +142,79 @@ func (c *TodoController) Search(ctx *gin.Context) {
 	)
 }
 
+func (c *TodoController) Board(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	assignedToMe, err := strconv.ParseBool(ctx.DefaultQuery("assigned_to_me", "false"))
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert assigned to me", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	defaultLimit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || defaultLimit <= 0 {
+		defaultLimit = 10
+	}
+
+	statuses := entity.TodoStatuses()
+	request := &model.BoardTodoRequest{
+		UserID:       userID,
+		AssignedToMe: assignedToMe,
+		Columns:      make([]model.BoardTodoColumnRequest, len(statuses)),
+	}
+
+	// every column is paginated on its own, e.g. ?pending_limit=5&completed_offset=10
+	for i, status := range statuses {
+		limit, err := strconv.Atoi(ctx.DefaultQuery(status.String()+"_limit", strconv.Itoa(defaultLimit)))
+		if err != nil || limit <= 0 {
+			limit = defaultLimit
+		}
+
+		offset, err := strconv.Atoi(ctx.DefaultQuery(status.String()+"_offset", "0"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		request.Columns[i] = model.BoardTodoColumnRequest{
+			Status: status,
+			Limit:  limit,
+			Offset: offset,
+		}
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.TodoUsecase.Board(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get todo board", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
 func (c *TodoController) Get(ctx *gin.Context) {
 	claims, err := middleware.GetJWTClaims(ctx)
 	if err != nil {
@@ -230,3 +311,86 @@ func (c *TodoController) Update(ctx *gin.Context) {
 		model.NewSuccessMessageResponse("Todo updated", http.StatusOK),
 	)
 }
+
+func (c *TodoController) Assign(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.AssignTodoRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.ID = id
+	request.UserID = userID
+	res, err := c.TodoUsecase.AssignByID(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to assign todo", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *TodoController) Delete(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.TodoUsecase.DeleteByID(ctx.Request.Context(), &model.DeleteTodoRequest{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to delete todo", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Todo deleted", http.StatusOK),
+	)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"go-api-example/internal/config"
 	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/entity"
 	"go-api-example/internal/mocks"
 	"go-api-example/internal/model"
 	"go-api-example/test"
@@ -89,7 +90,7 @@ func (s *TodoControllerSuite) TestTodoController_Create() {
 				}, nil)
 			},
 			wantStatus: http.StatusCreated,
-			wantRes: `{"data":{"id":1,"user_id":1,"title":"dummy title","description":"dummy description",` +
+			wantRes: `{"data":{"id":1,"user_id":1,"assignee_id":null,"title":"dummy title","description":"dummy description",` +
 				`"status":"pending","created_at":"2025-10-27T13:07:31Z","updated_at":"2025-10-27T13:07:31Z"},` +
 				`"meta":{"http_status":201}}`,
 		},
@@ -122,12 +123,21 @@ func (s *TodoControllerSuite) TestTodoController_Create() {
 func (s *TodoControllerSuite) TestTodoController_Search() {
 	tests := []struct {
 		name       string
+		url        string
 		mockFunc   func(a *mocks.TodoUsecase)
 		wantStatus int
 		wantRes    string
 	}{
+		{
+			name:       "invalid assigned to me",
+			url:        "/api/todos?assigned_to_me=maybe",
+			mockFunc:   func(a *mocks.TodoUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
 		{
 			name: "error on list",
+			url:  "/api/todos",
 			mockFunc: func(a *mocks.TodoUsecase) {
 				a.On("List", mock.Anything, mock.Anything).
 					Return([]model.TodoResponse{}, 0, errors.New("something error"))
@@ -137,6 +147,7 @@ func (s *TodoControllerSuite) TestTodoController_Search() {
 		},
 		{
 			name: "success",
+			url:  "/api/todos",
 			mockFunc: func(a *mocks.TodoUsecase) {
 				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
 				a.On("List", mock.Anything, mock.Anything).
@@ -153,10 +164,23 @@ func (s *TodoControllerSuite) TestTodoController_Search() {
 					}, 1, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantRes: `{"data":[{"id":1,"user_id":1,"title":"dummy title","description":"dummy description",` +
+			wantRes: `{"data":[{"id":1,"user_id":1,"assignee_id":null,"title":"dummy title","description":"dummy description",` +
 				`"status":"pending","created_at":"2025-10-27T13:07:31Z","updated_at":"2025-10-27T13:07:31Z"}],` +
 				`"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
 		},
+		{
+			name: "success assigned to me",
+			url:  "/api/todos?assigned_to_me=true",
+			mockFunc: func(a *mocks.TodoUsecase) {
+				matcher := mock.MatchedBy(func(r *model.SearchTodoRequest) bool {
+					return r.UserID == uint64(1) && r.AssignedToMe
+				})
+				a.On("List", mock.Anything, matcher).
+					Return([]model.TodoResponse{}, 0, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":[],"meta":{"limit":10,"offset":0,"total":0,"http_status":200}}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -170,7 +194,7 @@ func (s *TodoControllerSuite) TestTodoController_Search() {
 			app.Use(test.NewAuthMiddleware(1))
 			app.GET("/api/todos", tc.Search)
 
-			req := httptest.NewRequest("GET", "/api/todos", nil)
+			req := httptest.NewRequest("GET", tt.url, nil)
 			req.Header.Set("Content-Type", "application/json")
 
 			rec := httptest.NewRecorder()
@@ -182,6 +206,103 @@ func (s *TodoControllerSuite) TestTodoController_Search() {
 	}
 }
 
+func (s *TodoControllerSuite) TestTodoController_Board() {
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func(a *mocks.TodoUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid assigned to me",
+			url:        "/api/todos/board?assigned_to_me=maybe",
+			mockFunc:   func(a *mocks.TodoUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "column limit exceeds maximum",
+			url:        "/api/todos/board?pending_limit=21",
+			mockFunc:   func(a *mocks.TodoUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on board",
+			url:  "/api/todos/board",
+			mockFunc: func(a *mocks.TodoUsecase) {
+				a.On("Board", mock.Anything, mock.Anything).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			url:  "/api/todos/board?limit=5&in_progress_limit=2&in_progress_offset=2&completed_offset=x",
+			mockFunc: func(a *mocks.TodoUsecase) {
+				a.On("Board", mock.Anything, &model.BoardTodoRequest{
+					UserID: 1,
+					Columns: []model.BoardTodoColumnRequest{
+						{Status: entity.TodoStatusPending, Limit: 5, Offset: 0},
+						{Status: entity.TodoStatusInProgress, Limit: 2, Offset: 2},
+						{Status: entity.TodoStatusCompleted, Limit: 5, Offset: 0},
+					},
+				}).Return(&model.BoardTodoResponse{
+					Columns: []model.BoardTodoColumnResponse{
+						{
+							Status: "pending",
+							Todos: []model.TodoResponse{
+								{
+									ID:        1,
+									UserID:    1,
+									Title:     "dummy title",
+									Status:    "pending",
+									CreatedAt: "2025-10-27T13:07:31Z",
+									UpdatedAt: "2025-10-27T13:07:31Z",
+								},
+							},
+							Limit:  5,
+							Offset: 0,
+							Total:  1,
+						},
+						{Status: "in_progress", Todos: []model.TodoResponse{}, Limit: 2, Offset: 2, Total: 2},
+						{Status: "completed", Todos: []model.TodoResponse{}, Limit: 5, Offset: 0, Total: 0},
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"columns":[{"status":"pending","todos":[{"id":1,"user_id":1,"assignee_id":null,` +
+				`"title":"dummy title","description":"","status":"pending","created_at":"2025-10-27T13:07:31Z",` +
+				`"updated_at":"2025-10-27T13:07:31Z"}],"limit":5,"offset":0,"total":1},` +
+				`{"status":"in_progress","todos":[],"limit":2,"offset":2,"total":2},` +
+				`{"status":"completed","todos":[],"limit":5,"offset":0,"total":0}]},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTodoUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTodoController(s.log, s.validate, tu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/todos/board", tc.Board)
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func (s *TodoControllerSuite) TestTodoController_Get() {
 	tests := []struct {
 		name       string
@@ -213,7 +334,7 @@ func (s *TodoControllerSuite) TestTodoController_Get() {
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantRes: `{"data":{"id":1,"user_id":1,"title":"dummy title","description":"dummy description",` +
+			wantRes: `{"data":{"id":1,"user_id":1,"assignee_id":null,"title":"dummy title","description":"dummy description",` +
 				`"status":"pending","created_at":"2025-10-27T13:07:31Z","updated_at":"2025-10-27T13:07:31Z"},` +
 				`"meta":{"http_status":200}}`,
 		},
@@ -321,6 +442,138 @@ func (s *TodoControllerSuite) TestTodoController_Update() {
 	}
 }
 
+func (s *TodoControllerSuite) TestTodoController_Assign() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.TodoUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid body",
+			body:       "dummy",
+			mockFunc:   func(a *mocks.TodoUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on assign",
+			body: map[string]interface{}{
+				"assignee_id": 2,
+			},
+			mockFunc: func(a *mocks.TodoUsecase) {
+				a.On("AssignByID", mock.Anything, mock.Anything).
+					Return(nil, model.ErrAssigneeNotFound)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":2001,"message":"assignee not found"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"assignee_id": 2,
+			},
+			mockFunc: func(a *mocks.TodoUsecase) {
+				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
+				assigneeID := uint64(2)
+				matcher := mock.MatchedBy(func(r *model.AssignTodoRequest) bool {
+					return r.ID == uint64(1) && r.UserID == uint64(1) && *r.AssigneeID == uint64(2)
+				})
+				a.On("AssignByID", mock.Anything, matcher).Return(&model.TodoResponse{
+					ID:          1,
+					UserID:      1,
+					AssigneeID:  &assigneeID,
+					Title:       "dummy title",
+					Description: "dummy description",
+					Status:      "pending",
+					CreatedAt:   now.Format(time.RFC3339),
+					UpdatedAt:   now.Format(time.RFC3339),
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":1,"user_id":1,"assignee_id":2,"title":"dummy title","description":"dummy description",` +
+				`"status":"pending","created_at":"2025-10-27T13:07:31Z","updated_at":"2025-10-27T13:07:31Z"},` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTodoUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTodoController(s.log, s.validate, tu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.PUT("/api/todos/:id/assignee", tc.Assign)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/api/todos/1/assignee", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *TodoControllerSuite) TestTodoController_Delete() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.TodoUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error forbidden",
+			mockFunc: func(a *mocks.TodoUsecase) {
+				a.On("DeleteByID", mock.Anything, mock.Anything).
+					Return(model.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.TodoUsecase) {
+				a.On("DeleteByID", mock.Anything, &model.DeleteTodoRequest{
+					ID:     1,
+					UserID: 1,
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Todo deleted","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTodoUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTodoController(s.log, s.validate, tu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/todos/:id", tc.Delete)
+
+			req := httptest.NewRequest("DELETE", "/api/todos/1", nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func TestTodoControllerSuite(t *testing.T) {
 	suite.Run(t, new(TodoControllerSuite))
 }
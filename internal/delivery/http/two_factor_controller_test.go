@@ -0,0 +1,223 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go-api-example/internal/config"
+	internalHttp "go-api-example/internal/delivery/http"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type TwoFactorControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *TwoFactorControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = validator.New()
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Enroll() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error already enabled",
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("EnrollTOTP", mock.Anything, mock.Anything).Return(nil, model.ErrTOTPAlreadyEnabled)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1015,"message":"two-factor authentication already enabled"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("EnrollTOTP", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("EnrollTOTP", mock.Anything, &model.EnrollTOTPRequest{UserID: 1}).Return(&model.EnrollTOTPResponse{
+					Secret:     "ABC",
+					OtpauthURI: "otpauth://totp/api-example:johndoe?secret=ABC",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"secret":"ABC","otpauth_uri":"otpauth://totp/api-example:johndoe?secret=ABC"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/users/me/totp", tc.Enroll)
+
+			req := httptest.NewRequest("POST", "/api/users/me/totp", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Confirm() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on validate body",
+			body: map[string]interface{}{
+				"code": "12ab56",
+			},
+			mockFunc:   func(a *mocks.TwoFactorUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error invalid code",
+			body: map[string]interface{}{
+				"code": "123456",
+			},
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("ConfirmTOTP", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidTOTPCode)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1017,"message":"invalid two-factor code"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"code": "123456",
+			},
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("ConfirmTOTP", mock.Anything, &model.ConfirmTOTPRequest{UserID: 1, Code: "123456"}).
+					Return(&model.RecoveryCodesResponse{RecoveryCodes: []string{"abcde-fghij"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"recovery_codes":["abcde-fghij"]},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/users/me/totp/confirm", tc.Confirm)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/users/me/totp/confirm", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Disable() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{},
+			mockFunc:   func(a *mocks.TwoFactorUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error invalid password",
+			body: map[string]interface{}{
+				"password": "wrong-password",
+			},
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("DisableTOTP", mock.Anything, mock.Anything).Return(model.ErrInvalidPassword)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1003,"message":"invalid password"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"password": "password",
+			},
+			mockFunc: func(a *mocks.TwoFactorUsecase) {
+				a.On("DisableTOTP", mock.Anything, &model.DisableTOTPRequest{UserID: 1, Password: "password"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Two-factor authentication disabled","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/users/me/totp", tc.Disable)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("DELETE", "/api/users/me/totp", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestTwoFactorControllerSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorControllerSuite))
+}
@@ -1,12 +1,17 @@
 package http
 
 import (
+	"errors"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/avatar"
 	"go-api-example/internal/delivery/http/middleware"
 	"go-api-example/internal/model"
 	"go-api-example/internal/usecase"
+	"io"
 	"net/http"
 	"strconv"
 
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
@@ -55,9 +60,25 @@ func (c *UserController) Register(ctx *gin.Context) {
 	)
 }
 
+// Search lists the users the caller may discover, holders of users:manage see every user
 func (c *UserController) Search(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	viewerID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
 	var id *uint64
 	var username *string
+	var query *string
 
 	idQuery, err := strconv.ParseUint(ctx.Query("id"), 10, 64)
 	if err != nil {
@@ -72,6 +93,11 @@ func (c *UserController) Search(ctx *gin.Context) {
 		username = &usernameQuery
 	}
 
+	prefixQuery, ok := ctx.GetQuery("q")
+	if ok {
+		query = &prefixQuery
+	}
+
 	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	if err != nil || limit <= 0 {
 		limit = 10
@@ -83,10 +109,13 @@ func (c *UserController) Search(ctx *gin.Context) {
 	}
 
 	request := &model.SearchUserRequest{
-		ID:       id,
-		Username: username,
-		Limit:    limit,
-		Offset:   offset,
+		ID:           id,
+		Username:     username,
+		Query:        query,
+		Limit:        limit,
+		Offset:       offset,
+		ViewerID:     viewerID,
+		Unrestricted: claims.HasPermission(auth.PermissionUserManage),
 	}
 	res, total, err := c.UserUsecase.List(ctx.Request.Context(), request)
 	if err != nil {
@@ -168,6 +197,9 @@ func (c *UserController) Update(ctx *gin.Context) {
 	}
 
 	request.ID = userID
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IP = ctx.ClientIP()
+	request.RequestID = requestid.Get(ctx)
 	err = c.UserUsecase.UpdateByID(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.Log, "failed to update user", err)
@@ -180,3 +212,344 @@ func (c *UserController) Update(ctx *gin.Context) {
 		model.NewSuccessMessageResponse("User updated", http.StatusOK),
 	)
 }
+
+func (c *UserController) UpdateAvatar(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	// the limit leaves room for the multipart headers around the image
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, avatar.MaxUploadSize+64<<10)
+	fileHeader, err := ctx.FormFile("avatar")
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse avatar", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.Error(model.ErrAvatarTooLarge)
+			return
+		}
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	if fileHeader.Size > avatar.MaxUploadSize {
+		ctx.Error(model.ErrAvatarTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to open avatar", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(io.LimitReader(file, avatar.MaxUploadSize+1))
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to read avatar", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.UserUsecase.UpdateAvatar(ctx.Request.Context(), &model.UpdateAvatarRequest{
+		UserID: userID,
+		Image:  image,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to update avatar", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *UserController) DeleteAvatar(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.UserUsecase.DeleteAvatar(ctx.Request.Context(), &model.DeleteAvatarRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to delete avatar", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Avatar deleted", http.StatusOK),
+	)
+}
+
+func (c *UserController) Delete(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.DeleteUserRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request.ID = userID
+	request.Claims = claims
+	err = c.UserUsecase.DeleteByID(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to delete user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("User deleted", http.StatusOK),
+	)
+}
+
+// AdminSearch lists every user with its account state, filtered by username prefix, email, role and status
+func (c *UserController) AdminSearch(ctx *gin.Context) {
+	request := &model.SearchUserRequest{}
+
+	if query, ok := ctx.GetQuery("q"); ok {
+		request.Query = &query
+	}
+	if email, ok := ctx.GetQuery("email"); ok {
+		request.Email = &email
+	}
+	if role, ok := ctx.GetQuery("role"); ok {
+		request.Role = &role
+	}
+	if status, ok := ctx.GetQuery("status"); ok {
+		request.Status = &status
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	request.Limit = limit
+	request.Offset = offset
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request query", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, total, err := c.UserUsecase.AdminList(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get users", err)
+		ctx.Error(err)
+		return
+	}
+
+	meta := model.MetaWithPage{
+		Limit:      limit,
+		Offset:     offset,
+		Total:      total,
+		HTTPStatus: http.StatusOK,
+	}
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessListResponse(res, meta),
+	)
+}
+
+func (c *UserController) AdminGet(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.UserUsecase.AdminFindByID(ctx.Request.Context(), &model.GetUserRequest{
+		ID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *UserController) Suspend(ctx *gin.Context) {
+	request, ok := c.bindAdminUserAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.UserUsecase.Suspend(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to suspend user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("User suspended", http.StatusOK),
+	)
+}
+
+func (c *UserController) Unsuspend(ctx *gin.Context) {
+	request, ok := c.bindAdminUserAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.UserUsecase.Unsuspend(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to unsuspend user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("User unsuspended", http.StatusOK),
+	)
+}
+
+func (c *UserController) ForcePasswordReset(ctx *gin.Context) {
+	request, ok := c.bindAdminUserAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.UserUsecase.ForcePasswordReset(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to force password reset", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Password reset required", http.StatusOK),
+	)
+}
+
+func (c *UserController) AdminDelete(ctx *gin.Context) {
+	request, ok := c.bindAdminUserAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.UserUsecase.AdminDeleteByID(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to delete user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("User deleted", http.StatusOK),
+	)
+}
+
+// bindAdminUserAction reads the acting admin, the user of the path and the optional reason of the body, the error
+// is already set on the context when it fails
+func (c *UserController) bindAdminUserAction(ctx *gin.Context) (*model.AdminUserActionRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	actorID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	request := new(model.AdminUserActionRequest)
+	if ctx.Request.ContentLength != 0 {
+		err = ctx.ShouldBindJSON(request)
+		if err != nil {
+			LogWarn(ctx, c.Log, "failed to parse request body", err)
+			ctx.Error(model.ErrBadRequest)
+			return nil, false
+		}
+	}
+
+	err = c.Validate.Struct(request)
+	if err != nil {
+		LogWarn(ctx, c.Log, "failed to validate request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	request.ID = userID
+	request.ActorID = actorID
+
+	return request, true
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"go-api-example/internal/avatar"
 	"go-api-example/internal/config"
 	internalHttp "go-api-example/internal/delivery/http"
 	"go-api-example/internal/mocks"
 	"go-api-example/internal/model"
 	"go-api-example/test"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -134,7 +136,9 @@ func (s *UserControllerSuite) TestUserController_Search() {
 			name: "success",
 			mockFunc: func(a *mocks.UserUsecase) {
 				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
-				a.On("List", mock.Anything, mock.Anything).
+				a.On("List", mock.Anything, mock.MatchedBy(func(req *model.SearchUserRequest) bool {
+					return req.Query != nil && *req.Query == "jo" && req.ViewerID == 1 && !req.Unrestricted
+				})).
 					Return([]model.UserResponse{
 						{
 							ID:        1,
@@ -161,7 +165,7 @@ func (s *UserControllerSuite) TestUserController_Search() {
 			app.Use(test.NewAuthMiddleware(1))
 			app.GET("/api/users", uc.Search)
 
-			req := httptest.NewRequest("GET", "/api/users", nil)
+			req := httptest.NewRequest("GET", "/api/users?q=jo", nil)
 			req.Header.Set("Content-Type", "application/json")
 
 			rec := httptest.NewRecorder()
@@ -238,8 +242,8 @@ func (s *UserControllerSuite) TestUserController_Update() {
 		wantRes    string
 	}{
 		{
-			name:       "empty body",
-			body:       nil,
+			name:       "invalid body",
+			body:       "old_password",
 			mockFunc:   func(a *mocks.UserUsecase) {},
 			wantStatus: http.StatusBadRequest,
 			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
@@ -247,13 +251,32 @@ func (s *UserControllerSuite) TestUserController_Update() {
 		{
 			name: "error on validate body",
 			body: map[string]interface{}{
-				"old_password": "",
-				"new_password": "",
+				"old_password": "old_password",
+			},
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on validate timezone",
+			body: map[string]interface{}{
+				"timezone": "Mars/Olympus_Mons",
 			},
 			mockFunc:   func(a *mocks.UserUsecase) {},
 			wantStatus: http.StatusBadRequest,
 			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
 		},
+		{
+			name: "error on duplicate username",
+			body: map[string]interface{}{
+				"username": "janedoe",
+			},
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("UpdateByID", mock.Anything, mock.Anything).Return(model.ErrUsernameAlreadyExist)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1000,"message":"username already exist"}],"meta":{"http_status":400}}`,
+		},
 		{
 			name: "error on update",
 			body: map[string]interface{}{
@@ -279,6 +302,23 @@ func (s *UserControllerSuite) TestUserController_Update() {
 			wantStatus: http.StatusOK,
 			wantRes:    `{"message":"User updated","meta":{"http_status":200}}`,
 		},
+		{
+			name: "success with profile",
+			body: map[string]interface{}{
+				"display_name": "John Doe",
+				"timezone":     "Asia/Jakarta",
+				"locale":       "id-ID",
+			},
+			mockFunc: func(a *mocks.UserUsecase) {
+				matcher := mock.MatchedBy(func(r *model.UpdateUserRequest) bool {
+					return r.ID == uint64(1) && *r.DisplayName == "John Doe" && *r.Timezone == "Asia/Jakarta" &&
+						*r.Locale == "id-ID" && r.Username == nil && r.NewPassword == ""
+				})
+				a.On("UpdateByID", mock.Anything, matcher).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User updated","meta":{"http_status":200}}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -305,6 +345,567 @@ func (s *UserControllerSuite) TestUserController_Update() {
 	}
 }
 
+func (s *UserControllerSuite) TestUserController_UpdateAvatar() {
+	tests := []struct {
+		name       string
+		field      string
+		image      []byte
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing file",
+			field:      "image",
+			image:      []byte("image"),
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "file too large",
+			field:      "avatar",
+			image:      make([]byte, avatar.MaxUploadSize+1),
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantRes:    `{"errors":[{"code":1032,"message":"avatar is too large"}],"meta":{"http_status":413}}`,
+		},
+		{
+			name:  "error invalid image",
+			field: "avatar",
+			image: []byte("not an image"),
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("UpdateAvatar", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidAvatar)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1031,"message":"avatar must be a png, jpeg or gif image"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "success",
+			field: "avatar",
+			image: []byte("image"),
+			mockFunc: func(a *mocks.UserUsecase) {
+				matcher := mock.MatchedBy(func(r *model.UpdateAvatarRequest) bool {
+					return r.UserID == uint64(1) && string(r.Image) == "image"
+				})
+				a.On("UpdateAvatar", mock.Anything, matcher).Return(&model.UserResponse{
+					ID:         1,
+					Username:   "johndoe",
+					AvatarURLs: map[string]string{"32": "/avatars/qwe_32.png"},
+					CreatedAt:  "2025-01-01T00:00:00Z",
+					UpdatedAt:  "2025-01-01T00:00:00Z",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"id":1,"username":"johndoe","avatar_urls":{"32":"/avatars/qwe_32.png"},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.PUT("/api/users/me/avatar", uc.UpdateAvatar)
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, _ := writer.CreateFormFile(tt.field, "avatar.png")
+			_, _ = part.Write(tt.image)
+			_ = writer.Close()
+
+			req := httptest.NewRequest("PUT", "/api/users/me/avatar", &body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_DeleteAvatar() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on delete",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("DeleteAvatar", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("DeleteAvatar", mock.Anything, &model.DeleteAvatarRequest{UserID: 1}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Avatar deleted","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/users/me/avatar", uc.DeleteAvatar)
+
+			req := httptest.NewRequest("DELETE", "/api/users/me/avatar", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_Delete() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{},
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error invalid password",
+			body: map[string]interface{}{
+				"password": "wrong_password",
+			},
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("DeleteByID", mock.Anything, mock.Anything).Return(model.ErrInvalidPassword)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1003,"message":"invalid password"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"password": "password",
+			},
+			mockFunc: func(a *mocks.UserUsecase) {
+				matcher := mock.MatchedBy(func(r *model.DeleteUserRequest) bool {
+					return r.ID == uint64(1) && r.Password == "password" && r.Claims != nil && r.Claims.UserID == "1"
+				})
+				a.On("DeleteByID", mock.Anything, matcher).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User deleted","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/users/me", uc.Delete)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("DELETE", "/api/users/me", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_AdminSearch() {
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate status",
+			query:      "?status=deleted",
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error on list",
+			query: "",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("AdminList", mock.Anything, mock.Anything).
+					Return([]model.UserResponse{}, 0, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "success",
+			query: "?email=johndoe@example.com&role=user&status=suspended",
+			mockFunc: func(a *mocks.UserUsecase) {
+				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC).Format(time.RFC3339)
+				a.On("AdminList", mock.Anything, mock.MatchedBy(func(req *model.SearchUserRequest) bool {
+					return req.Query == nil && *req.Email == "johndoe@example.com" && *req.Role == "user" &&
+						*req.Status == model.UserStatusSuspended
+				})).
+					Return([]model.UserResponse{
+						{
+							ID:          2,
+							Username:    "johndoe",
+							Role:        "user",
+							SuspendedAt: &now,
+							CreatedAt:   now,
+							UpdatedAt:   now,
+						},
+					}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":2,"username":"johndoe","role":"user","suspended_at":"2025-10-27T13:07:31Z",` +
+				`"created_at":"2025-10-27T13:07:31Z","updated_at":"2025-10-27T13:07:31Z"}],` +
+				`"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/admin/users", uc.AdminSearch)
+
+			req := httptest.NewRequest("GET", "/api/admin/users"+tt.query, nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_AdminGet() {
+	tests := []struct {
+		name       string
+		userID     string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid user id",
+			userID:     "abc",
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:   "error user not found",
+			userID: "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("AdminFindByID", mock.Anything, &model.GetUserRequest{ID: 2}).Return(nil, model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1002,"message":"username not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name:   "success",
+			userID: "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC).Format(time.RFC3339)
+				a.On("AdminFindByID", mock.Anything, &model.GetUserRequest{ID: 2}).Return(&model.UserResponse{
+					ID:                      2,
+					Username:                "johndoe",
+					PasswordResetRequiredAt: &now,
+					CreatedAt:               now,
+					UpdatedAt:               now,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":2,"username":"johndoe","password_reset_required_at":"2025-10-27T13:07:31Z",` +
+				`"created_at":"2025-10-27T13:07:31Z","updated_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.GET("/api/admin/users/:id", uc.AdminGet)
+
+			req := httptest.NewRequest("GET", "/api/admin/users/"+tt.userID, nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_Suspend() {
+	tests := []struct {
+		name       string
+		userID     string
+		body       string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid user id",
+			userID:     "abc",
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on validate body",
+			userID:     "2",
+			body:       `{"reason":"` + strings.Repeat("a", 501) + `"}`,
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:   "error already suspended",
+			userID: "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Suspend", mock.Anything, mock.Anything).Return(model.ErrUserAlreadySuspended)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes:    `{"errors":[{"code":1044,"message":"user already suspended"}],"meta":{"http_status":409}}`,
+		},
+		{
+			name:   "success without body",
+			userID: "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Suspend", mock.Anything, &model.AdminUserActionRequest{ID: 2, ActorID: 1}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User suspended","meta":{"http_status":200}}`,
+		},
+		{
+			name:   "success with reason",
+			userID: "2",
+			body:   `{"reason":"spam"}`,
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Suspend", mock.Anything, &model.AdminUserActionRequest{ID: 2, ActorID: 1, Reason: "spam"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User suspended","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/admin/users/:id/suspend", uc.Suspend)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/"+tt.userID+"/suspend", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_Unsuspend() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error not suspended",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Unsuspend", mock.Anything, mock.Anything).Return(model.ErrUserNotSuspended)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes:    `{"errors":[{"code":1045,"message":"user not suspended"}],"meta":{"http_status":409}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Unsuspend", mock.Anything, &model.AdminUserActionRequest{ID: 2, ActorID: 1}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User unsuspended","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/admin/users/:id/unsuspend", uc.Unsuspend)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/2/unsuspend", nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_ForcePasswordReset() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error action not allowed",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("ForcePasswordReset", mock.Anything, mock.Anything).Return(model.ErrAdminActionNotAllowed)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1046,"message":"action not allowed on this user"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("ForcePasswordReset", mock.Anything, &model.AdminUserActionRequest{ID: 2, ActorID: 1, Reason: "leaked"}).
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Password reset required","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.POST("/api/admin/users/:id/password-reset", uc.ForcePasswordReset)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/2/password-reset", strings.NewReader(`{"reason":"leaked"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_AdminDelete() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error user not found",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("AdminDeleteByID", mock.Anything, mock.Anything).Return(model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1002,"message":"username not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("AdminDeleteByID", mock.Anything, &model.AdminUserActionRequest{ID: 2, ActorID: 1}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User deleted","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := config.NewGin(s.log)
+			app.Use(test.NewAuthMiddleware(1))
+			app.DELETE("/api/admin/users/:id", uc.AdminDelete)
+
+			req := httptest.NewRequest("DELETE", "/api/admin/users/2", nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func TestUserControllerSuite(t *testing.T) {
 	suite.Run(t, new(UserControllerSuite))
 }
@@ -0,0 +1,98 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	Log                 *zap.Logger
+	NotificationUsecase usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(log *zap.Logger, notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		Log:                 log,
+		NotificationUsecase: notificationUsecase,
+	}
+}
+
+func (c *NotificationHandler) ConsumeUserRegistered(ctx context.Context, message *kafka.Message) error {
+	c.Log.Info(
+		fmt.Sprintf("processing event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	event := new(model.UserEvent)
+	err := json.Unmarshal(message.Value, &event)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal event for %s with key %s: %w", message.TopicPartition.String(), string(message.Key), err)
+	}
+
+	body := fmt.Sprintf("Hi %s, your account is ready. Start by creating your first todo.", event.Username)
+	err = c.NotificationUsecase.Create(ctx, &model.CreateNotificationRequest{
+		UserID:   event.ID,
+		Type:     entity.NotificationTypeUserRegistered,
+		EventKey: event.GetID(),
+		Title:    "Welcome to the Todo App",
+		Body:     &body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	c.Log.Info(
+		fmt.Sprintf("successfuly proceed event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	return nil
+}
+
+func (c *NotificationHandler) ConsumeTodoAssigned(ctx context.Context, message *kafka.Message) error {
+	c.Log.Info(
+		fmt.Sprintf("processing event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	event := new(model.TodoAssignedEvent)
+	err := json.Unmarshal(message.Value, &event)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal event for %s with key %s: %w", message.TopicPartition.String(), string(message.Key), err)
+	}
+
+	if event.AssigneeID == 0 || event.AssigneeID == event.UserID {
+		c.Log.Info(
+			fmt.Sprintf("skip event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+			zap.Any("event", string(message.Value)),
+		)
+		return nil
+	}
+
+	// the same todo can be assigned to the same user again later, so the assignment time is part of the key
+	body := fmt.Sprintf("You have been assigned to \"%s\".", event.Title)
+	err = c.NotificationUsecase.Create(ctx, &model.CreateNotificationRequest{
+		UserID:   event.AssigneeID,
+		Type:     entity.NotificationTypeTodoAssigned,
+		EventKey: fmt.Sprintf("%s-%s", event.GetID(), event.AssignedAt),
+		Title:    "New todo assigned to you",
+		Body:     &body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	c.Log.Info(
+		fmt.Sprintf("successfuly proceed event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	return nil
+}
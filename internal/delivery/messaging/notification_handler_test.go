@@ -0,0 +1,172 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-api-example/internal/delivery/messaging"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestMessage(topic string, key string, value any) *kafka.Message {
+	data, _ := json.Marshal(value)
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: kafka.PartitionAny,
+		},
+		Value: data,
+		Key:   []byte(key),
+	}
+}
+
+func TestNotificationHandler_ConsumeUserRegistered(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := zap.NewDevelopment()
+	topic := "user-registered"
+
+	event := &model.UserEvent{
+		ID:        1,
+		Username:  "johndoe",
+		CreatedAt: time.Now().Format(time.RFC3339),
+		UpdatedAt: time.Now().Format(time.RFC3339),
+	}
+	matcher := mock.MatchedBy(func(r *model.CreateNotificationRequest) bool {
+		return r.UserID == uint64(1) && r.Type == entity.NotificationTypeUserRegistered &&
+			r.EventKey == "1-johndoe" && r.Title == "Welcome to the Todo App"
+	})
+
+	tests := []struct {
+		name       string
+		message    *kafka.Message
+		mockFunc   func(n *mocks.NotificationUsecase)
+		wantErrMsg string
+	}{
+		{
+			name:       "error on unrmarshal",
+			message:    newTestMessage(topic, "1", "dummy"),
+			mockFunc:   func(n *mocks.NotificationUsecase) {},
+			wantErrMsg: "failed to unmarshal event for user-registered",
+		},
+		{
+			name:    "error on create",
+			message: newTestMessage(topic, event.GetID(), event),
+			mockFunc: func(n *mocks.NotificationUsecase) {
+				n.On("Create", mock.Anything, matcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "something error",
+		},
+		{
+			name:    "success",
+			message: newTestMessage(topic, event.GetID(), event),
+			mockFunc: func(n *mocks.NotificationUsecase) {
+				n.On("Create", mock.Anything, matcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationUsecase := mocks.NewNotificationUsecase(t)
+			handler := messaging.NewNotificationHandler(logger, notificationUsecase)
+			tt.mockFunc(notificationUsecase)
+
+			err := handler.ConsumeUserRegistered(ctx, tt.message)
+
+			if tt.wantErrMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationHandler_ConsumeTodoAssigned(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := zap.NewDevelopment()
+	topic := "todo-assigned"
+
+	event := &model.TodoAssignedEvent{
+		ID:         1,
+		UserID:     1,
+		AssigneeID: 2,
+		Title:      "dummy title",
+		Status:     "pending",
+		AssignedAt: "2025-10-27T13:07:31Z",
+	}
+	selfEvent := &model.TodoAssignedEvent{
+		ID:         1,
+		UserID:     1,
+		AssigneeID: 1,
+		Title:      "dummy title",
+		Status:     "pending",
+		AssignedAt: "2025-10-27T13:07:31Z",
+	}
+	matcher := mock.MatchedBy(func(r *model.CreateNotificationRequest) bool {
+		return r.UserID == uint64(2) && r.Type == entity.NotificationTypeTodoAssigned &&
+			r.EventKey == "1-2-2025-10-27T13:07:31Z" && *r.Body == `You have been assigned to "dummy title".`
+	})
+
+	tests := []struct {
+		name       string
+		message    *kafka.Message
+		mockFunc   func(n *mocks.NotificationUsecase)
+		wantErrMsg string
+	}{
+		{
+			name:       "error on unrmarshal",
+			message:    newTestMessage(topic, "1-2", "dummy"),
+			mockFunc:   func(n *mocks.NotificationUsecase) {},
+			wantErrMsg: "failed to unmarshal event for todo-assigned",
+		},
+		{
+			name:       "skip self assigned",
+			message:    newTestMessage(topic, selfEvent.GetID(), selfEvent),
+			mockFunc:   func(n *mocks.NotificationUsecase) {},
+			wantErrMsg: "",
+		},
+		{
+			name:    "error on create",
+			message: newTestMessage(topic, event.GetID(), event),
+			mockFunc: func(n *mocks.NotificationUsecase) {
+				n.On("Create", mock.Anything, matcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "something error",
+		},
+		{
+			name:    "success",
+			message: newTestMessage(topic, event.GetID(), event),
+			mockFunc: func(n *mocks.NotificationUsecase) {
+				n.On("Create", mock.Anything, matcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationUsecase := mocks.NewNotificationUsecase(t)
+			handler := messaging.NewNotificationHandler(logger, notificationUsecase)
+			tt.mockFunc(notificationUsecase)
+
+			err := handler.ConsumeTodoAssigned(ctx, tt.message)
+
+			if tt.wantErrMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+)
+
+type SecurityEventHandler struct {
+	Log                  *zap.Logger
+	SecurityEventUsecase usecase.SecurityEventUsecase
+}
+
+func NewSecurityEventHandler(log *zap.Logger, securityEventUsecase usecase.SecurityEventUsecase) *SecurityEventHandler {
+	return &SecurityEventHandler{
+		Log:                  log,
+		SecurityEventUsecase: securityEventUsecase,
+	}
+}
+
+func (c *SecurityEventHandler) Consume(ctx context.Context, message *kafka.Message) error {
+	c.Log.Info(
+		fmt.Sprintf("processing event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	event := new(model.SecurityEvent)
+	err := json.Unmarshal(message.Value, &event)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal event for %s with key %s: %w", message.TopicPartition.String(), string(message.Key), err)
+	}
+
+	// an event without an id can't be deduplicated and one without a user has no security log to go to
+	if event.ID == "" || event.UserID == 0 {
+		c.Log.Info(
+			fmt.Sprintf("skip event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+			zap.Any("event", string(message.Value)),
+		)
+		return nil
+	}
+
+	err = c.SecurityEventUsecase.Create(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+
+	c.Log.Info(
+		fmt.Sprintf("successfuly proceed event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	return nil
+}
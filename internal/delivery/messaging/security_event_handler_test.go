@@ -0,0 +1,92 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/delivery/messaging"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestSecurityEventHandler_Consume(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := zap.NewDevelopment()
+	topic := "security-event"
+
+	event := &model.SecurityEvent{
+		ID:         "event-123",
+		Type:       model.SecurityEventLogin,
+		UserID:     1,
+		SessionID:  "qwe-123",
+		IP:         "127.0.0.1",
+		UserAgent:  "curl/8.0",
+		OccurredAt: "2026-10-18T09:20:00Z",
+	}
+	legacyEvent := &model.SecurityEvent{
+		Type:       model.SecurityEventRefreshTokenReused,
+		UserID:     1,
+		OccurredAt: "2026-10-18T09:20:00Z",
+	}
+	matcher := mock.MatchedBy(func(e *model.SecurityEvent) bool {
+		return e.ID == "event-123" && e.UserID == uint64(1) && e.Type == model.SecurityEventLogin &&
+			e.SessionID == "qwe-123"
+	})
+
+	tests := []struct {
+		name       string
+		message    *kafka.Message
+		mockFunc   func(u *mocks.SecurityEventUsecase)
+		wantErrMsg string
+	}{
+		{
+			name:       "error on unrmarshal",
+			message:    newTestMessage(topic, "1", "dummy"),
+			mockFunc:   func(u *mocks.SecurityEventUsecase) {},
+			wantErrMsg: "failed to unmarshal event for security-event",
+		},
+		{
+			name:       "skip event without id",
+			message:    newTestMessage(topic, legacyEvent.GetID(), legacyEvent),
+			mockFunc:   func(u *mocks.SecurityEventUsecase) {},
+			wantErrMsg: "",
+		},
+		{
+			name:    "error on create",
+			message: newTestMessage(topic, event.GetID(), event),
+			mockFunc: func(u *mocks.SecurityEventUsecase) {
+				u.On("Create", mock.Anything, matcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create security event: something error",
+		},
+		{
+			name:    "success",
+			message: newTestMessage(topic, event.GetID(), event),
+			mockFunc: func(u *mocks.SecurityEventUsecase) {
+				u.On("Create", mock.Anything, matcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			securityEventUsecase := mocks.NewSecurityEventUsecase(t)
+			handler := messaging.NewSecurityEventHandler(logger, securityEventUsecase)
+			tt.mockFunc(securityEventUsecase)
+
+			err := handler.Consume(ctx, tt.message)
+
+			if tt.wantErrMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+const (
+	AdminActionSuspend            = "suspend"
+	AdminActionUnsuspend          = "unsuspend"
+	AdminActionForcePasswordReset = "force_password_reset"
+	AdminActionDelete             = "delete"
+)
+
+// AdminAuditLog records one change an admin made to a user account
+type AdminAuditLog struct {
+	ID        uint64    `db:"id"`
+	ActorID   uint64    `db:"actor_id"`
+	UserID    uint64    `db:"user_id"`
+	Action    string    `db:"action"`
+	Reason    *string   `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// ImpersonationLog records one request an admin made with an impersonation token
+type ImpersonationLog struct {
+	ID        uint64    `db:"id"`
+	ActorID   uint64    `db:"actor_id"`
+	UserID    uint64    `db:"user_id"`
+	TokenID   string    `db:"token_id"`
+	Method    string    `db:"method"`
+	Path      string    `db:"path"`
+	IP        string    `db:"ip"`
+	RequestID string    `db:"request_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
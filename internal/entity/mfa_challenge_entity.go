@@ -0,0 +1,8 @@
+package entity
+
+// MFAChallenge is the state kept between a login with a valid password and the second factor that completes it
+type MFAChallenge struct {
+	UserID     uint64 `json:"user_id"`
+	Username   string `json:"username"`
+	DeviceName string `json:"device_name,omitempty"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationTypeUserRegistered NotificationType = "user_registered"
+	NotificationTypeTodoAssigned   NotificationType = "todo_assigned"
+)
+
+type Notification struct {
+	ID        uint64           `db:"id"`
+	UserID    uint64           `db:"user_id"`
+	Type      NotificationType `db:"type"`
+	EventKey  string           `db:"event_key"`
+	Title     string           `db:"title"`
+	Body      *string          `db:"body"`
+	ReadAt    *time.Time       `db:"read_at"`
+	CreatedAt time.Time        `db:"created_at"`
+}
+
+func (n *Notification) GetBody() string {
+	if n != nil && n.Body != nil {
+		return *n.Body
+	}
+
+	return ""
+}
+
+func (n *Notification) IsRead() bool {
+	return n != nil && n.ReadAt != nil
+}
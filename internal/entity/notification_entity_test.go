@@ -0,0 +1,85 @@
+package entity_test
+
+import (
+	"go-api-example/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification_GetBody(t *testing.T) {
+	body := "dummy body"
+
+	tests := []struct {
+		name    string
+		model   *entity.Notification
+		wantRes string
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: "",
+		},
+		{
+			name: "nil body",
+			model: &entity.Notification{
+				Body: nil,
+			},
+			wantRes: "",
+		},
+		{
+			name: "success",
+			model: &entity.Notification{
+				Body: &body,
+			},
+			wantRes: body,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.GetBody()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestNotification_IsRead(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		model   *entity.Notification
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: false,
+		},
+		{
+			name: "unread",
+			model: &entity.Notification{
+				ReadAt: nil,
+			},
+			wantRes: false,
+		},
+		{
+			name: "read",
+			model: &entity.Notification{
+				ReadAt: &now,
+			},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsRead()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package entity
+
+// OIDCState is kept between sending the browser to a provider and the callback, it is bound to the provider it was
+// created for and holds the secrets the code exchange is checked with
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	DeviceName   string `json:"device_name,omitempty"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type PersonalAccessToken struct {
+	ID         uint64     `db:"id"`
+	UserID     uint64     `db:"user_id"`
+	Name       string     `db:"name"`
+	TokenHash  string     `db:"token_hash"`
+	Scopes     []string   `db:"scopes"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// IsExpired reports whether the token had an expiry and it has passed, tokens without one never expire
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t != nil && t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
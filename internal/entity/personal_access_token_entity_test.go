@@ -0,0 +1,50 @@
+package entity_test
+
+import (
+	"go-api-example/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessToken_IsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-1 * time.Hour)
+	future := now.Add(1 * time.Hour)
+
+	tests := []struct {
+		name    string
+		model   *entity.PersonalAccessToken
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: false,
+		},
+		{
+			name:    "without expiry",
+			model:   &entity.PersonalAccessToken{Name: "dummy"},
+			wantRes: false,
+		},
+		{
+			name:    "not expired",
+			model:   &entity.PersonalAccessToken{Name: "dummy", ExpiresAt: &future},
+			wantRes: false,
+		},
+		{
+			name:    "expired",
+			model:   &entity.PersonalAccessToken{Name: "dummy", ExpiresAt: &past},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsExpired(now)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
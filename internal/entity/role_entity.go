@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type Role struct {
+	ID          uint64    `db:"id"`
+	Name        string    `db:"name"`
+	Permissions []string  `db:"permissions"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
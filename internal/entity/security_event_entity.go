@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// SecurityEvent records authentication activity of a user, the event id makes a redelivered event a no-op
+type SecurityEvent struct {
+	ID         uint64    `db:"id"`
+	EventID    string    `db:"event_id"`
+	UserID     uint64    `db:"user_id"`
+	Type       string    `db:"type"`
+	SessionID  string    `db:"session_id"`
+	IP         string    `db:"ip"`
+	UserAgent  string    `db:"user_agent"`
+	RequestID  string    `db:"request_id"`
+	OccurredAt time.Time `db:"occurred_at"`
+	CreatedAt  time.Time `db:"created_at"`
+}
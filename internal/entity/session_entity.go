@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Session is kept as json in redis, it follows one refresh token chain from login until logout or revocation
+type Session struct {
+	ID           string               `json:"id"`
+	UserID       string               `json:"user_id"`
+	RefreshToken string               `json:"refresh_token"`
+	DeviceName   string               `json:"device_name"`
+	UserAgent    string               `json:"user_agent"`
+	IP           string               `json:"ip"`
+	AccessTokens map[string]time.Time `json:"access_tokens,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+	LastUsedAt   time.Time            `json:"last_used_at"`
+}
+
+// AddAccessToken remembers the jti of an access token issued for the session until it expires,
+// expired entries are dropped on the way
+func (s *Session) AddAccessToken(id string, expiresAt time.Time) {
+	now := time.Now()
+	for tokenID, tokenExpiresAt := range s.AccessTokens {
+		if !tokenExpiresAt.After(now) {
+			delete(s.AccessTokens, tokenID)
+		}
+	}
+
+	if s.AccessTokens == nil {
+		s.AccessTokens = make(map[string]time.Time)
+	}
+	s.AccessTokens[id] = expiresAt
+}
@@ -0,0 +1,48 @@
+package entity_test
+
+import (
+	"go-api-example/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_AddAccessToken(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		model   *entity.Session
+		wantRes map[string]time.Time
+	}{
+		{
+			name:  "first access token",
+			model: &entity.Session{},
+			wantRes: map[string]time.Time{
+				"asd-789": now.Add(time.Minute),
+			},
+		},
+		{
+			name: "drop expired access tokens",
+			model: &entity.Session{
+				AccessTokens: map[string]time.Time{
+					"asd-123": now.Add(-time.Minute),
+					"asd-456": now.Add(time.Second),
+				},
+			},
+			wantRes: map[string]time.Time{
+				"asd-456": now.Add(time.Second),
+				"asd-789": now.Add(time.Minute),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.AddAccessToken("asd-789", now.Add(time.Minute))
+
+			assert.Equal(t, tt.wantRes, tt.model.AccessTokens)
+		})
+	}
+}
@@ -16,6 +16,7 @@ const (
 type Todo struct {
 	ID          uint64     `db:"id"`
 	UserID      uint64     `db:"user_id"`
+	AssigneeID  *uint64    `db:"assignee_id"`
 	Title       string     `db:"title"`
 	Description *string    `db:"description"`
 	Status      TodoStatus `db:"status"`
@@ -31,6 +32,18 @@ func (t *Todo) GetDescription() string {
 	return ""
 }
 
+func (t *Todo) IsOwner(userID uint64) bool {
+	return t != nil && t.UserID == userID
+}
+
+func (t *Todo) IsAssignee(userID uint64) bool {
+	return t != nil && t.AssigneeID != nil && *t.AssigneeID == userID
+}
+
+func TodoStatuses() []TodoStatus {
+	return []TodoStatus{TodoStatusPending, TodoStatusInProgress, TodoStatusCompleted}
+}
+
 func (ts TodoStatus) String() string {
 	switch ts {
 	case TodoStatusPending:
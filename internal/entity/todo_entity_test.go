@@ -45,6 +45,98 @@ func TestTodo_GetDescription(t *testing.T) {
 	}
 }
 
+func TestTodo_IsOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   *entity.Todo
+		userID  uint64
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			userID:  1,
+			wantRes: false,
+		},
+		{
+			name: "other user",
+			model: &entity.Todo{
+				UserID: 2,
+			},
+			userID:  1,
+			wantRes: false,
+		},
+		{
+			name: "owner",
+			model: &entity.Todo{
+				UserID: 1,
+			},
+			userID:  1,
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsOwner(tt.userID)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestTodo_IsAssignee(t *testing.T) {
+	assigneeID := uint64(2)
+
+	tests := []struct {
+		name    string
+		model   *entity.Todo
+		userID  uint64
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			userID:  2,
+			wantRes: false,
+		},
+		{
+			name: "nil assignee",
+			model: &entity.Todo{
+				UserID: 1,
+			},
+			userID:  2,
+			wantRes: false,
+		},
+		{
+			name: "other user",
+			model: &entity.Todo{
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			userID:  3,
+			wantRes: false,
+		},
+		{
+			name: "assignee",
+			model: &entity.Todo{
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			userID:  2,
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsAssignee(tt.userID)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
 func TestTodoStatus_String(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -123,3 +215,13 @@ func TestTodoStatus_ParseTodoStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoStatuses(t *testing.T) {
+	res := entity.TodoStatuses()
+
+	assert.Equal(t, []entity.TodoStatus{
+		entity.TodoStatusPending,
+		entity.TodoStatusInProgress,
+		entity.TodoStatusCompleted,
+	}, res)
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type TOTP struct {
+	UserID      uint64     `db:"user_id"`
+	Secret      string     `db:"secret"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+// IsConfirmed reports whether the enrollment was finished with a first code, only then the second factor is required
+func (t *TOTP) IsConfirmed() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
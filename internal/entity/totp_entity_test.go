@@ -0,0 +1,43 @@
+package entity_test
+
+import (
+	"go-api-example/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTP_IsConfirmed(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		model   *entity.TOTP
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: false,
+		},
+		{
+			name:    "pending",
+			model:   &entity.TOTP{Secret: "dummy"},
+			wantRes: false,
+		},
+		{
+			name:    "confirmed",
+			model:   &entity.TOTP{Secret: "dummy", ConfirmedAt: &now},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsConfirmed()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
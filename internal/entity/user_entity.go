@@ -2,10 +2,51 @@ package entity
 
 import "time"
 
+// discoverability decides who finds a user in the user search, contacts are the users that share a todo with them
+// as its owner or assignee
+const (
+	UserDiscoverabilityPublic   = "public"
+	UserDiscoverabilityContacts = "contacts"
+	UserDiscoverabilityHidden   = "hidden"
+)
+
 type User struct {
-	ID        uint64    `db:"id"`
-	Username  string    `db:"username"`
-	Password  string    `db:"password"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID              uint64     `db:"id"`
+	Username        string     `db:"username"`
+	DisplayName     *string    `db:"display_name"`
+	Bio             *string    `db:"bio"`
+	Timezone        string     `db:"timezone"`
+	Locale          string     `db:"locale"`
+	Discoverability string     `db:"discoverability"`
+	AvatarKey       *string    `db:"avatar_key"`
+	Email           *string    `db:"email"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	Password        string     `db:"password"`
+	Role            string     `db:"role"`
+	TokenVersion    uint64     `db:"token_version"`
+	SuspendedAt     *time.Time `db:"suspended_at"`
+	// PasswordResetRequiredAt is set by an admin, the password no longer logs in until it is reset
+	PasswordResetRequiredAt *time.Time `db:"password_reset_required_at"`
+	CreatedAt               time.Time  `db:"created_at"`
+	UpdatedAt               time.Time  `db:"updated_at"`
+}
+
+func (u *User) GetEmail() string {
+	if u != nil && u.Email != nil {
+		return *u.Email
+	}
+
+	return ""
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u != nil && u.Email != nil && u.EmailVerifiedAt != nil
+}
+
+func (u *User) IsSuspended() bool {
+	return u != nil && u.SuspendedAt != nil
+}
+
+func (u *User) IsPasswordResetRequired() bool {
+	return u != nil && u.PasswordResetRequiredAt != nil
 }
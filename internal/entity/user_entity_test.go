@@ -0,0 +1,162 @@
+package entity_test
+
+import (
+	"go-api-example/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_GetEmail(t *testing.T) {
+	email := "johndoe@example.com"
+
+	tests := []struct {
+		name    string
+		model   *entity.User
+		wantRes string
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: "",
+		},
+		{
+			name:    "nil email",
+			model:   &entity.User{},
+			wantRes: "",
+		},
+		{
+			name: "success",
+			model: &entity.User{
+				Email: &email,
+			},
+			wantRes: email,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.GetEmail()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestUser_IsEmailVerified(t *testing.T) {
+	email := "johndoe@example.com"
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		model   *entity.User
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: false,
+		},
+		{
+			name:    "nil email",
+			model:   &entity.User{},
+			wantRes: false,
+		},
+		{
+			name: "unverified email",
+			model: &entity.User{
+				Email: &email,
+			},
+			wantRes: false,
+		},
+		{
+			name: "verified email",
+			model: &entity.User{
+				Email:           &email,
+				EmailVerifiedAt: &now,
+			},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsEmailVerified()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestUser_IsSuspended(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		model   *entity.User
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: false,
+		},
+		{
+			name:    "not suspended",
+			model:   &entity.User{},
+			wantRes: false,
+		},
+		{
+			name: "suspended",
+			model: &entity.User{
+				SuspendedAt: &now,
+			},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsSuspended()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestUser_IsPasswordResetRequired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		model   *entity.User
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: false,
+		},
+		{
+			name:    "not required",
+			model:   &entity.User{},
+			wantRes: false,
+		},
+		{
+			name: "required",
+			model: &entity.User{
+				PasswordResetRequiredAt: &now,
+			},
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.model.IsPasswordResetRequired()
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
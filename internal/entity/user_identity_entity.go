@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// UserIdentity links a user to the subject of an external OpenID Connect provider
+type UserIdentity struct {
+	ID        uint64    `db:"id"`
+	UserID    uint64    `db:"user_id"`
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	Email     *string   `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileMailer writes every message as an .eml file, handy for local development and tests
+type fileMailer struct {
+	Dir  string
+	From string
+}
+
+func NewFileMailer(dir string, from string) Mailer {
+	return &fileMailer{
+		Dir:  dir,
+		From: from,
+	}
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	if msg.From == "" {
+		msg.From = m.From
+	}
+
+	err := os.MkdirAll(m.Dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create mail dir: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("From: %s\r\n", msg.From))
+	sb.WriteString(fmt.Sprintf("To: %s\r\n", msg.To))
+	sb.WriteString(fmt.Sprintf("Subject: %s\r\n", msg.Subject))
+	sb.WriteString("\r\n")
+	sb.WriteString(msg.Body)
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(msg.To))
+	err = os.WriteFile(filepath.Join(m.Dir, name), []byte(sb.String()), 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+
+	return nil
+}
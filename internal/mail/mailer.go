@@ -0,0 +1,63 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate mockery --name=Mailer --structname Mailer --outpkg=mocks --output=./../mocks
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type logMailer struct {
+	Log  *zap.Logger
+	From string
+}
+
+func NewLogMailer(log *zap.Logger, from string) Mailer {
+	return &logMailer{
+		Log:  log,
+		From: from,
+	}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	if msg.From == "" {
+		msg.From = m.From
+	}
+
+	m.Log.Info("sending mail",
+		zap.String("from", msg.From),
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+
+	return nil
+}
+
+func NewMailer(driver string, log *zap.Logger, from string, fileDir string) (Mailer, error) {
+	switch driver {
+	case DriverLog:
+		return NewLogMailer(log, from), nil
+	case DriverFile:
+		return NewFileMailer(fileDir, from), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", driver)
+	}
+}
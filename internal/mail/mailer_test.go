@@ -0,0 +1,90 @@
+package mail_test
+
+import (
+	"context"
+	"go-api-example/internal/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogMailer_Send(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	m := mail.NewLogMailer(zap.New(core), "noreply@example.com")
+
+	err := m.Send(context.Background(), &mail.Message{
+		To:      "johndoe@example.com",
+		Subject: "dummy subject",
+		Body:    "dummy body",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "noreply@example.com", fields["from"])
+	assert.Equal(t, "johndoe@example.com", fields["to"])
+	assert.Equal(t, "dummy subject", fields["subject"])
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mails")
+	m := mail.NewFileMailer(dir, "noreply@example.com")
+
+	err := m.Send(context.Background(), &mail.Message{
+		To:      "johndoe@example.com",
+		Subject: "dummy subject",
+		Body:    "dummy body",
+	})
+	assert.Nil(t, err)
+
+	files, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Contains(t, files[0].Name(), "johndoe_at_example.com")
+
+	content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	assert.Nil(t, err)
+	assert.Equal(t, "From: noreply@example.com\r\nTo: johndoe@example.com\r\nSubject: dummy subject\r\n\r\ndummy body", string(content))
+}
+
+func TestNewMailer(t *testing.T) {
+	tests := []struct {
+		name       string
+		driver     string
+		wantErrMsg string
+	}{
+		{
+			name:       "log driver",
+			driver:     mail.DriverLog,
+			wantErrMsg: "",
+		},
+		{
+			name:       "file driver",
+			driver:     mail.DriverFile,
+			wantErrMsg: "",
+		},
+		{
+			name:       "unknown driver",
+			driver:     "smtp",
+			wantErrMsg: "unknown mail driver: smtp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := mail.NewMailer(tt.driver, zap.NewNop(), "noreply@example.com", t.TempDir())
+
+			if tt.wantErrMsg == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, res)
+			} else {
+				assert.Nil(t, res)
+				assert.Equal(t, tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"go-api-example/internal/model"
+
+	"go.uber.org/zap"
+)
+
+type SecurityEventProducer struct {
+	Producer[*model.SecurityEvent]
+}
+
+func NewSecurityEventProducer(logger *zap.Logger, kProducer KafkaProducer, topic string) *SecurityEventProducer {
+	return &SecurityEventProducer{
+		Producer: &producer[*model.SecurityEvent]{
+			Producer: kProducer,
+			Topic:    topic,
+			Log:      logger,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package messaging_test
+
+import (
+	"errors"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SecurityEventProducerSuite struct {
+	suite.Suite
+	logger   *zap.Logger
+	kafka    *mocks.KafkaProducer
+	producer messaging.Producer[*model.SecurityEvent]
+	topic    string
+}
+
+func (s *SecurityEventProducerSuite) SetupTest() {
+	s.logger, _ = zap.NewDevelopment()
+	s.kafka = mocks.NewKafkaProducer(s.T())
+	s.topic = "security-event"
+	s.producer = messaging.NewSecurityEventProducer(s.logger, s.kafka, s.topic)
+}
+
+func (s *SecurityEventProducerSuite) TearDownTest() {
+	s.kafka = mocks.NewKafkaProducer(s.T())
+}
+
+func (s *SecurityEventProducerSuite) TestSecurityEventProducer_GetTopic() {
+	t := s.producer.GetTopic()
+
+	s.Equal("security-event", *t)
+}
+
+func (s *SecurityEventProducerSuite) TestSecurityEventProducer_Send() {
+	tests := []struct {
+		name       string
+		mockFunc   func(k *mocks.KafkaProducer)
+		param      *model.SecurityEvent
+		wantErrMsg string
+	}{
+		{
+			name: "error on produce",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			param: &model.SecurityEvent{
+				Type:       model.SecurityEventRefreshTokenReused,
+				UserID:     1,
+				SessionID:  "qwe-123",
+				OccurredAt: time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "failed to produce message for security-event: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			param: &model.SecurityEvent{
+				Type:       model.SecurityEventRefreshTokenReused,
+				UserID:     1,
+				SessionID:  "qwe-123",
+				OccurredAt: time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.kafka = mocks.NewKafkaProducer(s.T())
+			s.producer = messaging.NewSecurityEventProducer(s.logger, s.kafka, s.topic)
+			tt.mockFunc(s.kafka)
+
+			err := s.producer.Send(tt.param)
+
+			if tt.wantErrMsg == "" {
+				s.Nil(err)
+			} else {
+				s.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestSecurityEventProducerSuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventProducerSuite))
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"go-api-example/internal/model"
+
+	"go.uber.org/zap"
+)
+
+type TodoProducer struct {
+	Producer[*model.TodoAssignedEvent]
+}
+
+func NewTodoProducer(logger *zap.Logger, kProducer KafkaProducer, topic string) *TodoProducer {
+	return &TodoProducer{
+		Producer: &producer[*model.TodoAssignedEvent]{
+			Producer: kProducer,
+			Topic:    topic,
+			Log:      logger,
+		},
+	}
+}
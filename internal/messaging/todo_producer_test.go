@@ -0,0 +1,100 @@
+package messaging_test
+
+import (
+	"errors"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type TodoProducerSuite struct {
+	suite.Suite
+	logger   *zap.Logger
+	kafka    *mocks.KafkaProducer
+	producer messaging.Producer[*model.TodoAssignedEvent]
+	topic    string
+}
+
+func (s *TodoProducerSuite) SetupTest() {
+	s.logger, _ = zap.NewDevelopment()
+	s.kafka = mocks.NewKafkaProducer(s.T())
+	s.topic = "todo-assigned"
+	s.producer = messaging.NewTodoProducer(s.logger, s.kafka, s.topic)
+}
+
+func (s *TodoProducerSuite) TearDownTest() {
+	s.kafka = mocks.NewKafkaProducer(s.T())
+}
+
+func (s *TodoProducerSuite) TestTodoProducer_GetTopic() {
+	t := s.producer.GetTopic()
+
+	s.Equal("todo-assigned", *t)
+}
+
+func (s *TodoProducerSuite) TestTodoProducer_Send() {
+	tests := []struct {
+		name       string
+		mockFunc   func(k *mocks.KafkaProducer)
+		param      *model.TodoAssignedEvent
+		wantErrMsg string
+	}{
+		{
+			name: "error on produce",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			param: &model.TodoAssignedEvent{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: 2,
+				Title:      "dummy title",
+				Status:     "pending",
+				AssignedAt: time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "failed to produce message for todo-assigned: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			param: &model.TodoAssignedEvent{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: 2,
+				Title:      "dummy title",
+				Status:     "pending",
+				AssignedAt: time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.kafka = mocks.NewKafkaProducer(s.T())
+			s.producer = messaging.NewTodoProducer(s.logger, s.kafka, s.topic)
+			tt.mockFunc(s.kafka)
+
+			err := s.producer.Send(tt.param)
+
+			if tt.wantErrMsg == "" {
+				s.Nil(err)
+			} else {
+				s.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestTodoProducerSuite(t *testing.T) {
+	suite.Run(t, new(TodoProducerSuite))
+}
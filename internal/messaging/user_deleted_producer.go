@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"go-api-example/internal/model"
+
+	"go.uber.org/zap"
+)
+
+type UserDeletedProducer struct {
+	Producer[*model.UserDeletedEvent]
+}
+
+func NewUserDeletedProducer(logger *zap.Logger, kProducer KafkaProducer, topic string) *UserDeletedProducer {
+	return &UserDeletedProducer{
+		Producer: &producer[*model.UserDeletedEvent]{
+			Producer: kProducer,
+			Topic:    topic,
+			Log:      logger,
+		},
+	}
+}
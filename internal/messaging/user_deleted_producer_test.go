@@ -0,0 +1,94 @@
+package messaging_test
+
+import (
+	"errors"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type UserDeletedProducerSuite struct {
+	suite.Suite
+	logger   *zap.Logger
+	kafka    *mocks.KafkaProducer
+	producer messaging.Producer[*model.UserDeletedEvent]
+	topic    string
+}
+
+func (s *UserDeletedProducerSuite) SetupTest() {
+	s.logger, _ = zap.NewDevelopment()
+	s.kafka = mocks.NewKafkaProducer(s.T())
+	s.topic = "user-deleted"
+	s.producer = messaging.NewUserDeletedProducer(s.logger, s.kafka, s.topic)
+}
+
+func (s *UserDeletedProducerSuite) TearDownTest() {
+	s.kafka = mocks.NewKafkaProducer(s.T())
+}
+
+func (s *UserDeletedProducerSuite) TestUserDeletedProducer_GetTopic() {
+	t := s.producer.GetTopic()
+
+	s.Equal("user-deleted", *t)
+}
+
+func (s *UserDeletedProducerSuite) TestUserDeletedProducer_Send() {
+	tests := []struct {
+		name       string
+		mockFunc   func(k *mocks.KafkaProducer)
+		param      *model.UserDeletedEvent
+		wantErrMsg string
+	}{
+		{
+			name: "error on produce",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			param: &model.UserDeletedEvent{
+				ID:        1,
+				Username:  "johndoe",
+				DeletedAt: time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "failed to produce message for user-deleted: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			param: &model.UserDeletedEvent{
+				ID:        1,
+				Username:  "johndoe",
+				DeletedAt: time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.kafka = mocks.NewKafkaProducer(s.T())
+			s.producer = messaging.NewUserDeletedProducer(s.logger, s.kafka, s.topic)
+			tt.mockFunc(s.kafka)
+
+			err := s.producer.Send(tt.param)
+
+			if tt.wantErrMsg == "" {
+				s.Nil(err)
+			} else {
+				s.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestUserDeletedProducerSuite(t *testing.T) {
+	suite.Run(t, new(UserDeletedProducerSuite))
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"go-api-example/internal/model"
+
+	"go.uber.org/zap"
+)
+
+type UserUpdatedProducer struct {
+	Producer[*model.UserUpdatedEvent]
+}
+
+func NewUserUpdatedProducer(logger *zap.Logger, kProducer KafkaProducer, topic string) *UserUpdatedProducer {
+	return &UserUpdatedProducer{
+		Producer: &producer[*model.UserUpdatedEvent]{
+			Producer: kProducer,
+			Topic:    topic,
+			Log:      logger,
+		},
+	}
+}
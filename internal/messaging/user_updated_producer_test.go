@@ -0,0 +1,100 @@
+package messaging_test
+
+import (
+	"errors"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type UserUpdatedProducerSuite struct {
+	suite.Suite
+	logger   *zap.Logger
+	kafka    *mocks.KafkaProducer
+	producer messaging.Producer[*model.UserUpdatedEvent]
+	topic    string
+}
+
+func (s *UserUpdatedProducerSuite) SetupTest() {
+	s.logger, _ = zap.NewDevelopment()
+	s.kafka = mocks.NewKafkaProducer(s.T())
+	s.topic = "user-updated"
+	s.producer = messaging.NewUserUpdatedProducer(s.logger, s.kafka, s.topic)
+}
+
+func (s *UserUpdatedProducerSuite) TearDownTest() {
+	s.kafka = mocks.NewKafkaProducer(s.T())
+}
+
+func (s *UserUpdatedProducerSuite) TestUserUpdatedProducer_GetTopic() {
+	t := s.producer.GetTopic()
+
+	s.Equal("user-updated", *t)
+}
+
+func (s *UserUpdatedProducerSuite) TestUserUpdatedProducer_Send() {
+	tests := []struct {
+		name       string
+		mockFunc   func(k *mocks.KafkaProducer)
+		param      *model.UserUpdatedEvent
+		wantErrMsg string
+	}{
+		{
+			name: "error on produce",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			param: &model.UserUpdatedEvent{
+				ID:            1,
+				Username:      "johndoe",
+				Timezone:      "Asia/Jakarta",
+				Locale:        "id",
+				ChangedFields: []string{"timezone", "locale"},
+				UpdatedAt:     time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "failed to produce message for user-updated: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			param: &model.UserUpdatedEvent{
+				ID:            1,
+				Username:      "johndoe",
+				Timezone:      "Asia/Jakarta",
+				Locale:        "id",
+				ChangedFields: []string{"timezone", "locale"},
+				UpdatedAt:     time.Now().Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.kafka = mocks.NewKafkaProducer(s.T())
+			s.producer = messaging.NewUserUpdatedProducer(s.logger, s.kafka, s.topic)
+			tt.mockFunc(s.kafka)
+
+			err := s.producer.Send(tt.param)
+
+			if tt.wantErrMsg == "" {
+				s.Nil(err)
+			} else {
+				s.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestUserUpdatedProducerSuite(t *testing.T) {
+	suite.Run(t, new(UserUpdatedProducerSuite))
+}
@@ -7,6 +7,8 @@ import (
 	model "go-api-example/internal/model"
 
 	mock "github.com/stretchr/testify/mock"
+
+	auth "go-api-example/internal/auth"
 )
 
 // AuthUsecase is an autogenerated mock type for the AuthUsecase type
@@ -14,6 +16,116 @@ type AuthUsecase struct {
 	mock.Mock
 }
 
+// AuthorizeOIDC provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) AuthorizeOIDC(ctx context.Context, req *model.AuthorizeOIDCRequest) (*model.AuthorizeOIDCResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeOIDC")
+	}
+
+	var r0 *model.AuthorizeOIDCResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthorizeOIDCRequest) (*model.AuthorizeOIDCResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthorizeOIDCRequest) *model.AuthorizeOIDCResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AuthorizeOIDCResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AuthorizeOIDCRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with given fields: ctx
+func (_m *AuthUsecase) JWKS(ctx context.Context) *auth.JSONWebKeySet {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 *auth.JSONWebKeySet
+	if rf, ok := ret.Get(0).(func(context.Context) *auth.JSONWebKeySet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.JSONWebKeySet)
+		}
+	}
+
+	return r0
+}
+
+// ListIdentities provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) ListIdentities(ctx context.Context, req *model.ListIdentityRequest) ([]model.IdentityResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIdentities")
+	}
+
+	var r0 []model.IdentityResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListIdentityRequest) ([]model.IdentityResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListIdentityRequest) []model.IdentityResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.IdentityResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListIdentityRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessions provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) ListSessions(ctx context.Context, req *model.ListSessionRequest) ([]model.SessionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []model.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListSessionRequest) ([]model.SessionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListSessionRequest) []model.SessionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListSessionRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, req
 func (_m *AuthUsecase) Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -44,6 +156,66 @@ func (_m *AuthUsecase) Login(ctx context.Context, req *model.LoginRequest) (*mod
 	return r0, r1
 }
 
+// LoginMagicLink provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) LoginMagicLink(ctx context.Context, req *model.MagicLinkLoginRequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginMagicLink")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MagicLinkLoginRequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MagicLinkLoginRequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.MagicLinkLoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginOIDC provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) LoginOIDC(ctx context.Context, req *model.OIDCCallbackRequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginOIDC")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.OIDCCallbackRequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.OIDCCallbackRequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.OIDCCallbackRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Logout provides a mock function with given fields: ctx, req
 func (_m *AuthUsecase) Logout(ctx context.Context, req *model.LogoutRequest) error {
 	ret := _m.Called(ctx, req)
@@ -92,6 +264,144 @@ func (_m *AuthUsecase) Refresh(ctx context.Context, req *model.RefreshRequest) (
 	return r0, r1
 }
 
+// RequestMagicLink provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) RequestMagicLink(ctx context.Context, req *model.MagicLinkRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestMagicLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MagicLinkRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAllSessions provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) RevokeAllSessions(ctx context.Context, req *model.RevokeAllSessionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RevokeAllSessionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) RevokeSession(ctx context.Context, req *model.RevokeSessionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RevokeSessionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlinkIdentity provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) UnlinkIdentity(ctx context.Context, req *model.UnlinkIdentityRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlinkIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UnlinkIdentityRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockLogin provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) UnlockLogin(ctx context.Context, req *model.UnlockLoginRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UnlockLoginRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) VerifyMFA(ctx context.Context, req *model.VerifyMFARequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VerifyMFARequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VerifyMFARequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.VerifyMFARequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTokenVersion provides a mock function with given fields: ctx, claims
+func (_m *AuthUsecase) VerifyTokenVersion(ctx context.Context, claims *auth.JWTClaims) error {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTokenVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.JWTClaims) error); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAuthUsecase creates a new instance of AuthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthUsecase(t interface {
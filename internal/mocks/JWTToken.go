@@ -13,32 +13,61 @@ type JWTToken struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: userID
-func (_m *JWTToken) Create(userID string) (string, error) {
-	ret := _m.Called(userID)
+// Create provides a mock function with given fields: subject
+func (_m *JWTToken) Create(subject *auth.Subject) (string, *auth.JWTClaims, error) {
+	ret := _m.Called(subject)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(userID)
+	var r1 *auth.JWTClaims
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*auth.Subject) (string, *auth.JWTClaims, error)); ok {
+		return rf(subject)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(*auth.Subject) string); ok {
+		r0 = rf(subject)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(*auth.Subject) *auth.JWTClaims); ok {
+		r1 = rf(subject)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*auth.JWTClaims)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(*auth.Subject) error); ok {
+		r2 = rf(subject)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JWKS provides a mock function with no fields
+func (_m *JWTToken) JWKS() *auth.JSONWebKeySet {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 *auth.JSONWebKeySet
+	if rf, ok := ret.Get(0).(func() *auth.JSONWebKeySet); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.JSONWebKeySet)
+		}
+	}
+
+	return r0
 }
 
 // Parse provides a mock function with given fields: jwtToken
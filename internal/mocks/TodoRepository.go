@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "go-api-example/internal/model"
+
+	db "go-api-example/internal/db"
 )
 
 // TodoRepository is an autogenerated mock type for the TodoRepository type
@@ -16,6 +18,45 @@ type TodoRepository struct {
 	mock.Mock
 }
 
+// Board provides a mock function with given fields: ctx, req
+func (_m *TodoRepository) Board(ctx context.Context, req *model.BoardTodoRequest) ([]entity.Todo, map[entity.TodoStatus]int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Board")
+	}
+
+	var r0 []entity.Todo
+	var r1 map[entity.TodoStatus]int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.BoardTodoRequest) ([]entity.Todo, map[entity.TodoStatus]int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.BoardTodoRequest) []entity.Todo); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.BoardTodoRequest) map[entity.TodoStatus]int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[entity.TodoStatus]int)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.BoardTodoRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, user
 func (_m *TodoRepository) Create(ctx context.Context, user *entity.Todo) error {
 	ret := _m.Called(ctx, user)
@@ -34,6 +75,42 @@ func (_m *TodoRepository) Create(ctx context.Context, user *entity.Todo) error {
 	return r0
 }
 
+// DeleteByID provides a mock function with given fields: ctx, id
+func (_m *TodoRepository) DeleteByID(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, exec, userID
+func (_m *TodoRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	ret := _m.Called(ctx, exec, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64) error); ok {
+		r0 = rf(ctx, exec, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *TodoRepository) FindByID(ctx context.Context, id uint64) (*entity.Todo, error) {
 	ret := _m.Called(ctx, id)
@@ -101,6 +178,42 @@ func (_m *TodoRepository) List(ctx context.Context, req *model.SearchTodoRequest
 	return r0, r1, r2
 }
 
+// UnassignByAssigneeID provides a mock function with given fields: ctx, exec, assigneeID
+func (_m *TodoRepository) UnassignByAssigneeID(ctx context.Context, exec db.Executor, assigneeID uint64) error {
+	ret := _m.Called(ctx, exec, assigneeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignByAssigneeID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64) error); ok {
+		r0 = rf(ctx, exec, assigneeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAssigneeByID provides a mock function with given fields: ctx, todo
+func (_m *TodoRepository) UpdateAssigneeByID(ctx context.Context, todo *entity.Todo) error {
+	ret := _m.Called(ctx, todo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAssigneeByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Todo) error); ok {
+		r0 = rf(ctx, todo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateByID provides a mock function with given fields: ctx, req
 func (_m *TodoRepository) UpdateByID(ctx context.Context, req *model.UpdateTodoRequest) error {
 	ret := _m.Called(ctx, req)
@@ -119,6 +232,24 @@ func (_m *TodoRepository) UpdateByID(ctx context.Context, req *model.UpdateTodoR
 	return r0
 }
 
+// UpdateStatusByID provides a mock function with given fields: ctx, id, status
+func (_m *TodoRepository) UpdateStatusByID(ctx context.Context, id uint64, status entity.TodoStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, entity.TodoStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTodoRepository creates a new instance of TodoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTodoRepository(t interface {
@@ -14,6 +14,66 @@ type TodoUsecase struct {
 	mock.Mock
 }
 
+// AssignByID provides a mock function with given fields: ctx, req
+func (_m *TodoUsecase) AssignByID(ctx context.Context, req *model.AssignTodoRequest) (*model.TodoResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignByID")
+	}
+
+	var r0 *model.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AssignTodoRequest) (*model.TodoResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AssignTodoRequest) *model.TodoResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AssignTodoRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Board provides a mock function with given fields: ctx, req
+func (_m *TodoUsecase) Board(ctx context.Context, req *model.BoardTodoRequest) (*model.BoardTodoResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Board")
+	}
+
+	var r0 *model.BoardTodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.BoardTodoRequest) (*model.BoardTodoResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.BoardTodoRequest) *model.BoardTodoResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BoardTodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.BoardTodoRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, req
 func (_m *TodoUsecase) Create(ctx context.Context, req *model.CreateTodoRequest) (*model.TodoResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -44,6 +104,24 @@ func (_m *TodoUsecase) Create(ctx context.Context, req *model.CreateTodoRequest)
 	return r0, r1
 }
 
+// DeleteByID provides a mock function with given fields: ctx, req
+func (_m *TodoUsecase) DeleteByID(ctx context.Context, req *model.DeleteTodoRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeleteTodoRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByID provides a mock function with given fields: ctx, req
 func (_m *TodoUsecase) FindByID(ctx context.Context, req *model.GetTodoRequest) (*model.TodoResponse, error) {
 	ret := _m.Called(ctx, req)
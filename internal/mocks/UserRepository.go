@@ -10,6 +10,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "go-api-example/internal/model"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -17,27 +19,27 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// CountByUsername provides a mock function with given fields: ctx, username
-func (_m *UserRepository) CountByUsername(ctx context.Context, username string) (int, error) {
-	ret := _m.Called(ctx, username)
+// ChangePasswordByID provides a mock function with given fields: ctx, exec, id, password
+func (_m *UserRepository) ChangePasswordByID(ctx context.Context, exec db.Executor, id uint64, password string) (uint64, error) {
+	ret := _m.Called(ctx, exec, id, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountByUsername")
+		panic("no return value specified for ChangePasswordByID")
 	}
 
-	var r0 int
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
-		return rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, string) (uint64, error)); ok {
+		return rf(ctx, exec, id, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
-		r0 = rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, string) uint64); ok {
+		r0 = rf(ctx, exec, id, password)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, username)
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64, string) error); ok {
+		r1 = rf(ctx, exec, id, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,6 +65,54 @@ func (_m *UserRepository) Create(ctx context.Context, exec db.Executor, user *en
 	return r0
 }
 
+// DeleteByID provides a mock function with given fields: ctx, exec, id
+func (_m *UserRepository) DeleteByID(ctx context.Context, exec db.Executor, id uint64) error {
+	ret := _m.Called(ctx, exec, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64) error); ok {
+		r0 = rf(ctx, exec, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEmail")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User, error) {
 	ret := _m.Called(ctx, id)
@@ -123,6 +173,36 @@ func (_m *UserRepository) FindByUsername(ctx context.Context, username string) (
 	return r0, r1
 }
 
+// FindDiscoverableByID provides a mock function with given fields: ctx, viewerID, id
+func (_m *UserRepository) FindDiscoverableByID(ctx context.Context, viewerID uint64, id uint64) (*entity.User, error) {
+	ret := _m.Called(ctx, viewerID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDiscoverableByID")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.User, error)); ok {
+		return rf(ctx, viewerID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.User); ok {
+		r0 = rf(ctx, viewerID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, viewerID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, req
 func (_m *UserRepository) List(ctx context.Context, req *model.SearchUserRequest) ([]entity.User, int, error) {
 	ret := _m.Called(ctx, req)
@@ -160,6 +240,98 @@ func (_m *UserRepository) List(ctx context.Context, req *model.SearchUserRequest
 	return r0, r1, r2
 }
 
+// RequirePasswordResetByID provides a mock function with given fields: ctx, exec, id, requiredAt
+func (_m *UserRepository) RequirePasswordResetByID(ctx context.Context, exec db.Executor, id uint64, requiredAt time.Time) (uint64, error) {
+	ret := _m.Called(ctx, exec, id, requiredAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequirePasswordResetByID")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time) (uint64, error)); ok {
+		return rf(ctx, exec, id, requiredAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time) uint64); ok {
+		r0 = rf(ctx, exec, id, requiredAt)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64, time.Time) error); ok {
+		r1 = rf(ctx, exec, id, requiredAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuspendByID provides a mock function with given fields: ctx, exec, id, suspendedAt
+func (_m *UserRepository) SuspendByID(ctx context.Context, exec db.Executor, id uint64, suspendedAt time.Time) (uint64, error) {
+	ret := _m.Called(ctx, exec, id, suspendedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuspendByID")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time) (uint64, error)); ok {
+		return rf(ctx, exec, id, suspendedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time) uint64); ok {
+		r0 = rf(ctx, exec, id, suspendedAt)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64, time.Time) error); ok {
+		r1 = rf(ctx, exec, id, suspendedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnsuspendByID provides a mock function with given fields: ctx, exec, id
+func (_m *UserRepository) UnsuspendByID(ctx context.Context, exec db.Executor, id uint64) error {
+	ret := _m.Called(ctx, exec, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsuspendByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64) error); ok {
+		r0 = rf(ctx, exec, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAvatarByID provides a mock function with given fields: ctx, exec, id, avatarKey
+func (_m *UserRepository) UpdateAvatarByID(ctx context.Context, exec db.Executor, id uint64, avatarKey *string) error {
+	ret := _m.Called(ctx, exec, id, avatarKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAvatarByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, *string) error); ok {
+		r0 = rf(ctx, exec, id, avatarKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateByID provides a mock function with given fields: ctx, req
 func (_m *UserRepository) UpdateByID(ctx context.Context, req *model.UpdateUserRequest) error {
 	ret := _m.Called(ctx, req)
@@ -178,6 +350,42 @@ func (_m *UserRepository) UpdateByID(ctx context.Context, req *model.UpdateUserR
 	return r0
 }
 
+// UpdateEmailByID provides a mock function with given fields: ctx, id, email, verifiedAt
+func (_m *UserRepository) UpdateEmailByID(ctx context.Context, id uint64, email string, verifiedAt time.Time) error {
+	ret := _m.Called(ctx, id, email, verifiedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmailByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, email, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, exec, user
+func (_m *UserRepository) UpdateProfile(ctx context.Context, exec db.Executor, user *entity.User) error {
+	ret := _m.Called(ctx, exec, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.User) error); ok {
+		r0 = rf(ctx, exec, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
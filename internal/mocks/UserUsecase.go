@@ -14,6 +14,91 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// AdminDeleteByID provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) AdminDeleteByID(ctx context.Context, req *model.AdminUserActionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminDeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AdminUserActionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminFindByID provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) AdminFindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminFindByID")
+	}
+
+	var r0 *model.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetUserRequest) (*model.UserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetUserRequest) *model.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminList provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) AdminList(ctx context.Context, req *model.SearchUserRequest) ([]model.UserResponse, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminList")
+	}
+
+	var r0 []model.UserResponse
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SearchUserRequest) ([]model.UserResponse, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SearchUserRequest) []model.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SearchUserRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.SearchUserRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) Create(ctx context.Context, req *model.CreateUserRequest) (*model.UserResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -44,6 +129,42 @@ func (_m *UserUsecase) Create(ctx context.Context, req *model.CreateUserRequest)
 	return r0, r1
 }
 
+// DeleteAvatar provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) DeleteAvatar(ctx context.Context, req *model.DeleteAvatarRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAvatar")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeleteAvatarRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByID provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) DeleteByID(ctx context.Context, req *model.DeleteUserRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeleteUserRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByID provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) FindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -74,6 +195,24 @@ func (_m *UserUsecase) FindByID(ctx context.Context, req *model.GetUserRequest)
 	return r0, r1
 }
 
+// ForcePasswordReset provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) ForcePasswordReset(ctx context.Context, req *model.AdminUserActionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForcePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AdminUserActionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // List provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) List(ctx context.Context, req *model.SearchUserRequest) ([]model.UserResponse, int, error) {
 	ret := _m.Called(ctx, req)
@@ -111,6 +250,72 @@ func (_m *UserUsecase) List(ctx context.Context, req *model.SearchUserRequest) (
 	return r0, r1, r2
 }
 
+// Suspend provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) Suspend(ctx context.Context, req *model.AdminUserActionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suspend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AdminUserActionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unsuspend provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) Unsuspend(ctx context.Context, req *model.AdminUserActionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsuspend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AdminUserActionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAvatar provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) UpdateAvatar(ctx context.Context, req *model.UpdateAvatarRequest) (*model.UserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAvatar")
+	}
+
+	var r0 *model.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateAvatarRequest) (*model.UserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateAvatarRequest) *model.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateAvatarRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateByID provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) UpdateByID(ctx context.Context, req *model.UpdateUserRequest) error {
 	ret := _m.Called(ctx, req)
@@ -3,25 +3,104 @@ package model
 import "go-api-example/internal/auth"
 
 type LoginRequest struct {
-	Username string `json:"username" validate:"required,min=4,max=64"`
-	Password string `json:"password" validate:"required,min=4,max=64"`
+	Username   string `json:"username" validate:"required,min=4,max=64"`
+	Password   string `json:"password" validate:"required,min=4,max=64"`
+	DeviceName string `json:"device_name" validate:"max=100"`
+	// UseCookies asks for the tokens as session cookies instead of in the response body
+	UseCookies bool   `json:"use_cookies"`
+	UserAgent  string `json:"-"`
+	IP         string `json:"-"`
+	RequestID  string `json:"-"`
 }
 
 type LogoutRequest struct {
 	Claims       *auth.JWTClaims `json:"claims"`
 	RefreshToken string          `json:"refresh_token" validate:"required"`
+	UserAgent    string          `json:"-"`
+	IP           string          `json:"-"`
+	RequestID    string          `json:"-"`
 }
 
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
+	UserAgent    string `json:"-"`
+	IP           string `json:"-"`
+	RequestID    string `json:"-"`
 }
 
+// VerifyMFARequest finishes a login that was answered with an mfa token, either a totp code or a recovery code is required
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code,omitempty,max=32"`
+	UseCookies   bool   `json:"use_cookies"`
+	UserAgent    string `json:"-"`
+	IP           string `json:"-"`
+	RequestID    string `json:"-"`
+}
+
+type MagicLinkRequest struct {
+	Email      string `json:"email" validate:"required,email,max=255"`
+	DeviceName string `json:"device_name" validate:"max=100"`
+	IP         string `json:"-"`
+}
+
+type MagicLinkLoginRequest struct {
+	Token      string `json:"token" validate:"required,max=255"`
+	UseCookies bool   `json:"use_cookies"`
+	UserAgent  string `json:"-"`
+	IP         string `json:"-"`
+	RequestID  string `json:"-"`
+}
+
+type AuthorizeOIDCRequest struct {
+	Provider   string `json:"provider" validate:"required,max=50"`
+	DeviceName string `json:"device_name" validate:"max=100"`
+}
+
+// OIDCCallbackRequest is what the provider redirects the browser back with after the user signed in
+type OIDCCallbackRequest struct {
+	Provider  string `json:"provider" validate:"required,max=50"`
+	Code      string `json:"code" validate:"required,max=2048"`
+	State     string `json:"state" validate:"required,max=255"`
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+type ListIdentityRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type UnlinkIdentityRequest struct {
+	UserID   uint64 `json:"user_id"`
+	Provider string `json:"provider" validate:"required,max=50"`
+}
+
+type UnlockLoginRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+// LoginResponse carries either the tokens or, for users with two-factor authentication, the mfa token to finish the login with
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
 }
 
+// RefreshResponse is empty for browser sessions, their tokens are only set as cookies
 type RefreshResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+type AuthorizeOIDCResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type IdentityResponse struct {
+	Provider  string  `json:"provider"`
+	Email     *string `json:"email"`
+	CreatedAt string  `json:"created_at"`
 }
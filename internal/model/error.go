@@ -10,14 +10,60 @@ var (
 	ErrMissingOrInvalidAuthHeader = NewCustomError(http.StatusUnauthorized, 104, "missing or invalid auth header")
 	ErrInvalidAuthToken           = NewCustomError(http.StatusUnauthorized, 105, "invalid auth token")
 	ErrTokenRevoked               = NewCustomError(http.StatusUnauthorized, 106, "token revoked")
+	ErrInvalidClient              = NewCustomError(http.StatusUnauthorized, 107, "invalid client credentials")
 
-	ErrUsernameAlreadyExist = NewCustomError(http.StatusBadRequest, 1000, "username already exist")
-	ErrUserNotFound         = NewCustomError(http.StatusNotFound, 1002, "username not found")
-	ErrInvalidPassword      = NewCustomError(http.StatusUnauthorized, 1003, "invalid password")
-	ErrInvalidRefreshToken  = NewCustomError(http.StatusUnauthorized, 1004, "invalid refresh token")
-	ErrInvalidLogoutSession = NewCustomError(http.StatusUnauthorized, 1005, "invalid logout session")
-	ErrInvalidUserID        = NewCustomError(http.StatusUnprocessableEntity, 1006, "invalid user id")
-	ErrInvalidOldPassword   = NewCustomError(http.StatusBadRequest, 1007, "invalid old password")
+	ErrUsernameAlreadyExist      = NewCustomError(http.StatusBadRequest, 1000, "username already exist")
+	ErrUserNotFound              = NewCustomError(http.StatusNotFound, 1002, "username not found")
+	ErrInvalidPassword           = NewCustomError(http.StatusUnauthorized, 1003, "invalid password")
+	ErrInvalidRefreshToken       = NewCustomError(http.StatusUnauthorized, 1004, "invalid refresh token")
+	ErrInvalidLogoutSession      = NewCustomError(http.StatusUnauthorized, 1005, "invalid logout session")
+	ErrInvalidUserID             = NewCustomError(http.StatusUnprocessableEntity, 1006, "invalid user id")
+	ErrInvalidOldPassword        = NewCustomError(http.StatusBadRequest, 1007, "invalid old password")
+	ErrEmailAlreadyExist         = NewCustomError(http.StatusBadRequest, 1008, "email already exist")
+	ErrEmailAlreadyVerified      = NewCustomError(http.StatusBadRequest, 1009, "email already verified")
+	ErrInvalidEmailToken         = NewCustomError(http.StatusBadRequest, 1010, "invalid or expired email verification token")
+	ErrInvalidPasswordResetToken = NewCustomError(http.StatusBadRequest, 1011, "invalid or expired password reset token")
+	ErrSessionNotFound           = NewCustomError(http.StatusNotFound, 1012, "session not found")
+	ErrInvalidCredentials        = NewCustomError(http.StatusUnauthorized, 1013, "invalid username or password")
+	ErrTooManyLoginAttempts      = NewCustomError(http.StatusTooManyRequests, 1014, "too many failed login attempts, try again later")
+	ErrTOTPAlreadyEnabled        = NewCustomError(http.StatusBadRequest, 1015, "two-factor authentication already enabled")
+	ErrTOTPNotEnrolled           = NewCustomError(http.StatusBadRequest, 1016, "two-factor authentication not enrolled")
+	ErrInvalidTOTPCode           = NewCustomError(http.StatusBadRequest, 1017, "invalid two-factor code")
+	ErrInvalidMFAToken           = NewCustomError(http.StatusUnauthorized, 1018, "invalid or expired mfa token")
+	ErrInvalidMFACode            = NewCustomError(http.StatusUnauthorized, 1019, "invalid two-factor code")
+	ErrAccessTokenNotFound       = NewCustomError(http.StatusNotFound, 1020, "personal access token not found")
+	ErrScopeNotGranted           = NewCustomError(http.StatusForbidden, 1021, "scope not granted to user role")
+	ErrOIDCProviderNotFound      = NewCustomError(http.StatusNotFound, 1022, "login provider not found")
+	ErrInvalidOIDCState          = NewCustomError(http.StatusBadRequest, 1023, "invalid or expired login state")
+	ErrOIDCLoginFailed           = NewCustomError(http.StatusUnauthorized, 1024, "login with provider failed")
+	ErrOIDCAccountNotLinked      = NewCustomError(http.StatusForbidden, 1025, "no account linked to this provider login")
+	ErrIdentityNotFound          = NewCustomError(http.StatusNotFound, 1026, "linked identity not found")
+	ErrIdentityAlreadyLinked     = NewCustomError(http.StatusConflict, 1027, "account already linked to another login of this provider")
+	ErrPasswordTooShort          = NewCustomError(http.StatusBadRequest, 1028, "password is too short")
+	ErrPasswordTooWeak           = NewCustomError(http.StatusBadRequest, 1029, "password misses a required character class")
+	ErrPasswordTooCommon         = NewCustomError(http.StatusBadRequest, 1030, "password is too common or known to be breached")
+	ErrInvalidAvatar             = NewCustomError(http.StatusBadRequest, 1031, "avatar must be a png, jpeg or gif image")
+	ErrAvatarTooLarge            = NewCustomError(http.StatusRequestEntityTooLarge, 1032, "avatar is too large")
+	ErrImpersonationForbidden    = NewCustomError(http.StatusForbidden, 1033, "action not allowed while impersonating")
+	ErrImpersonationNotAllowed   = NewCustomError(http.StatusForbidden, 1034, "user can not be impersonated")
+	ErrInvalidCSRFToken          = NewCustomError(http.StatusForbidden, 1035, "missing or invalid csrf token")
+	ErrTooManyMagicLinkRequests  = NewCustomError(http.StatusTooManyRequests, 1036, "too many magic link requests, try again later")
+	ErrInvalidMagicLinkToken     = NewCustomError(http.StatusUnauthorized, 1037, "invalid or expired magic link")
+	ErrInvalidUsername           = NewCustomError(http.StatusBadRequest, 1038, "username may only contain letters, digits, dots, dashes and underscores")
+	ErrUsernameReserved          = NewCustomError(http.StatusBadRequest, 1039, "username is reserved")
+	ErrSearchQueryTooShort       = NewCustomError(http.StatusBadRequest, 1040, "search query is too short")
+	ErrTooManySearchRequests     = NewCustomError(http.StatusTooManyRequests, 1041, "too many search requests, try again later")
+	ErrUserSuspended             = NewCustomError(http.StatusForbidden, 1042, "account suspended")
+	ErrPasswordResetRequired     = NewCustomError(http.StatusForbidden, 1043, "password reset required, reset it with the forgot password link")
+	ErrUserAlreadySuspended      = NewCustomError(http.StatusConflict, 1044, "user already suspended")
+	ErrUserNotSuspended          = NewCustomError(http.StatusConflict, 1045, "user not suspended")
+	ErrAdminActionNotAllowed     = NewCustomError(http.StatusForbidden, 1046, "action not allowed on this user")
+
+	ErrTodoNotFound        = NewCustomError(http.StatusNotFound, 2000, "todo not found")
+	ErrAssigneeNotFound    = NewCustomError(http.StatusBadRequest, 2001, "assignee not found")
+	ErrAssigneeFieldUpdate = NewCustomError(http.StatusForbidden, 2002, "assignee can only update todo status")
+
+	ErrNotificationNotFound = NewCustomError(http.StatusNotFound, 3000, "notification not found")
 )
 
 type ErrorItem struct {
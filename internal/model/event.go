@@ -16,3 +16,70 @@ type UserEvent struct {
 func (u *UserEvent) GetID() string {
 	return fmt.Sprintf("%d-%s", u.ID, u.Username)
 }
+
+type UserUpdatedEvent struct {
+	ID               uint64   `json:"id"`
+	Username         string   `json:"username"`
+	PreviousUsername string   `json:"previous_username,omitempty"`
+	DisplayName      *string  `json:"display_name"`
+	Timezone         string   `json:"timezone"`
+	Locale           string   `json:"locale"`
+	ChangedFields    []string `json:"changed_fields"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+// GetID keys the events by user only, a username change must not move the events of one user to another partition
+func (u *UserUpdatedEvent) GetID() string {
+	return fmt.Sprintf("%d", u.ID)
+}
+
+type TodoAssignedEvent struct {
+	ID         uint64 `json:"id"`
+	UserID     uint64 `json:"user_id"`
+	AssigneeID uint64 `json:"assignee_id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	AssignedAt string `json:"assigned_at"`
+}
+
+func (t *TodoAssignedEvent) GetID() string {
+	return fmt.Sprintf("%d-%d", t.ID, t.AssigneeID)
+}
+
+type UserDeletedEvent struct {
+	ID        uint64 `json:"id"`
+	Username  string `json:"username"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+func (u *UserDeletedEvent) GetID() string {
+	return fmt.Sprintf("%d-%s", u.ID, u.Username)
+}
+
+const (
+	SecurityEventLogin              = "login"
+	SecurityEventLoginFailed        = "login_failed"
+	SecurityEventTokenRefreshed     = "token_refreshed"
+	SecurityEventLogout             = "logout"
+	SecurityEventPasswordChanged    = "password_changed"
+	SecurityEventSessionRevoked     = "session_revoked"
+	SecurityEventAllSessionsRevoked = "all_sessions_revoked"
+	SecurityEventRefreshTokenReused = "refresh_token_reused"
+	SecurityEventIdentityLinked     = "identity_linked"
+)
+
+type SecurityEvent struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	UserID     uint64 `json:"user_id"`
+	SessionID  string `json:"session_id,omitempty"`
+	IP         string `json:"ip,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+// GetID keys security events by user, so the events of one user stay ordered in a partition
+func (s *SecurityEvent) GetID() string {
+	return fmt.Sprintf("%d", s.UserID)
+}
@@ -35,3 +35,113 @@ func TestUserEvent_GetID(t *testing.T) {
 	}
 
 }
+
+func TestUserUpdatedEvent_GetID(t *testing.T) {
+	tests := []struct {
+		name      string
+		userEvent *model.UserUpdatedEvent
+		wantID    string
+	}{
+		{
+			name: "success",
+			userEvent: &model.UserUpdatedEvent{
+				ID:               1,
+				Username:         "johndoe2",
+				PreviousUsername: "johndoe",
+				ChangedFields:    []string{"username"},
+				UpdatedAt:        time.Now().Format(time.RFC3339),
+			},
+			wantID: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.userEvent.GetID()
+
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestTodoAssignedEvent_GetID(t *testing.T) {
+	tests := []struct {
+		name      string
+		todoEvent *model.TodoAssignedEvent
+		wantID    string
+	}{
+		{
+			name: "success",
+			todoEvent: &model.TodoAssignedEvent{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: 2,
+				Title:      "dummy title",
+				Status:     "pending",
+				AssignedAt: time.Now().Format(time.RFC3339),
+			},
+			wantID: "1-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.todoEvent.GetID()
+
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestUserDeletedEvent_GetID(t *testing.T) {
+	tests := []struct {
+		name      string
+		userEvent *model.UserDeletedEvent
+		wantID    string
+	}{
+		{
+			name: "success",
+			userEvent: &model.UserDeletedEvent{
+				ID:        1,
+				Username:  "johndoe",
+				DeletedAt: time.Now().Format(time.RFC3339),
+			},
+			wantID: "1-johndoe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.userEvent.GetID()
+
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestSecurityEvent_GetID(t *testing.T) {
+	tests := []struct {
+		name          string
+		securityEvent *model.SecurityEvent
+		wantID        string
+	}{
+		{
+			name: "success",
+			securityEvent: &model.SecurityEvent{
+				Type:       model.SecurityEventRefreshTokenReused,
+				UserID:     1,
+				SessionID:  "qwe-123",
+				OccurredAt: time.Now().Format(time.RFC3339),
+			},
+			wantID: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.securityEvent.GetID()
+
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
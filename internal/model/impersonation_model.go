@@ -0,0 +1,27 @@
+package model
+
+import "go-api-example/internal/auth"
+
+type ImpersonateRequest struct {
+	ActorID   uint64 `json:"-"`
+	UserID    uint64 `json:"user_id"`
+	Method    string `json:"-"`
+	Path      string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// RecordImpersonationRequest describes one request made with an impersonation token
+type RecordImpersonationRequest struct {
+	Claims    *auth.JWTClaims
+	Method    string
+	Path      string
+	IP        string
+	RequestID string
+}
+
+// ImpersonateResponse carries a short lived access token, no refresh token is issued for an impersonation
+type ImpersonateResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresAt   string `json:"expires_at"`
+}
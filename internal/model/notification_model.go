@@ -0,0 +1,38 @@
+package model
+
+import (
+	"go-api-example/internal/entity"
+)
+
+type CreateNotificationRequest struct {
+	UserID   uint64                  `json:"user_id" validate:"required"`
+	Type     entity.NotificationType `json:"type" validate:"required"`
+	EventKey string                  `json:"event_key" validate:"required"`
+	Title    string                  `json:"title" validate:"required"`
+	Body     *string                 `json:"body"`
+}
+
+type NotificationResponse struct {
+	ID        uint64  `json:"id"`
+	Type      string  `json:"type"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body"`
+	ReadAt    *string `json:"read_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type SearchNotificationRequest struct {
+	UserID     uint64 `json:"user_id"`
+	UnreadOnly bool   `json:"unread_only"`
+	Limit      int    `json:"limit" validate:"min=1,max=20"`
+	Offset     int    `json:"offset" validate:"min=0"`
+}
+
+type ReadNotificationRequest struct {
+	ID     uint64 `json:"id"`
+	UserID uint64 `json:"user_id"`
+}
+
+type ReadAllNotificationRequest struct {
+	UserID uint64 `json:"user_id"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "go-api-example/internal/auth"
+
+// token types of RFC 7662 and RFC 7009, personal access tokens are our own extension
+const (
+	TokenTypeAccessToken         = "access_token"
+	TokenTypeRefreshToken        = "refresh_token"
+	TokenTypePersonalAccessToken = "personal_access_token"
+)
+
+// IntrospectTokenRequest follows RFC 7662, the token type hint is optional and only used for logging
+type IntrospectTokenRequest struct {
+	Token         string `form:"token" validate:"required,max=4096"`
+	TokenTypeHint string `form:"token_type_hint" validate:"max=50"`
+	ClientID      string `form:"-"`
+}
+
+// RevokeTokenRequest follows RFC 7009
+type RevokeTokenRequest struct {
+	Token         string `form:"token" validate:"required,max=4096"`
+	TokenTypeHint string `form:"token_type_hint" validate:"max=50"`
+	ClientID      string `form:"-"`
+}
+
+// IntrospectTokenResponse only carries active for tokens that are unknown, expired or revoked
+type IntrospectTokenResponse struct {
+	Active    bool        `json:"active"`
+	TokenType string      `json:"token_type,omitempty"`
+	Scope     string      `json:"scope,omitempty"`
+	Sub       string      `json:"sub,omitempty"`
+	Exp       int64       `json:"exp,omitempty"`
+	Iat       int64       `json:"iat,omitempty"`
+	Jti       string      `json:"jti,omitempty"`
+	Act       *auth.Actor `json:"act,omitempty"`
+}
@@ -0,0 +1,32 @@
+package model
+
+type CreatePersonalAccessTokenRequest struct {
+	UserID        uint64   `json:"user_id"`
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=users:read todos:read todos:write notifications:read notifications:write"`
+	ExpiresInDays *int     `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+type ListPersonalAccessTokenRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type RevokePersonalAccessTokenRequest struct {
+	ID     uint64 `json:"id"`
+	UserID uint64 `json:"user_id"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID         uint64   `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	LastUsedAt *string  `json:"last_used_at"`
+	ExpiresAt  *string  `json:"expires_at"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// CreatePersonalAccessTokenResponse is the only response that carries the plain token, only its hash is stored
+type CreatePersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
@@ -11,6 +11,14 @@ type MetaWithPage struct {
 	HTTPStatus int `json:"http_status"`
 }
 
+type MetaWithUnread struct {
+	Limit      int `json:"limit"`
+	Offset     int `json:"offset"`
+	Total      int `json:"total"`
+	Unread     int `json:"unread"`
+	HTTPStatus int `json:"http_status"`
+}
+
 type SuccessResponse[T any] struct {
 	Data T    `json:"data"`
 	Meta Meta `json:"meta"`
@@ -21,6 +29,11 @@ type SuccessListResponse[T any] struct {
 	MetaWithPage MetaWithPage `json:"meta"`
 }
 
+type SuccessListWithUnreadResponse[T any] struct {
+	Data           []T            `json:"data"`
+	MetaWithUnread MetaWithUnread `json:"meta"`
+}
+
 type SuccessMessageResponse struct {
 	Message string `json:"message"`
 	Meta    Meta   `json:"meta"`
@@ -47,6 +60,13 @@ func NewSuccessListResponse[T any](data []T, meta MetaWithPage) SuccessListRespo
 	}
 }
 
+func NewSuccessListWithUnreadResponse[T any](data []T, meta MetaWithUnread) SuccessListWithUnreadResponse[T] {
+	return SuccessListWithUnreadResponse[T]{
+		Data:           data,
+		MetaWithUnread: meta,
+	}
+}
+
 func NewSuccessMessageResponse(msg string, httpStatus int) SuccessMessageResponse {
 	return SuccessMessageResponse{
 		Message: msg,
@@ -0,0 +1,17 @@
+package model
+
+type SearchSecurityEventRequest struct {
+	UserID uint64 `json:"user_id"`
+	Limit  int    `json:"limit" validate:"min=1,max=50"`
+	Offset int    `json:"offset" validate:"min=0"`
+}
+
+type SecurityEventResponse struct {
+	ID         uint64 `json:"id"`
+	Type       string `json:"type"`
+	SessionID  string `json:"session_id,omitempty"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	RequestID  string `json:"request_id,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
@@ -0,0 +1,25 @@
+package serializer
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+)
+
+func IdentityToResponse(i *entity.UserIdentity) *model.IdentityResponse {
+	return &model.IdentityResponse{
+		Provider:  i.Provider,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ListIdentityToResponse(identities []entity.UserIdentity) []model.IdentityResponse {
+	res := make([]model.IdentityResponse, len(identities))
+
+	for i, identity := range identities {
+		res[i] = *IdentityToResponse(&identity)
+	}
+
+	return res
+}
@@ -0,0 +1,29 @@
+package serializer_test
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentitySerializer_ListIdentityToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	email := "user@example.com"
+
+	param := []entity.UserIdentity{
+		{ID: 2, UserID: 1, Provider: "github", Subject: "subject-2", CreatedAt: now},
+		{ID: 1, UserID: 1, Provider: "google", Subject: "subject-1", Email: &email, CreatedAt: now},
+	}
+	wantRes := []model.IdentityResponse{
+		{Provider: "github", Email: nil, CreatedAt: now.Format(time.RFC3339)},
+		{Provider: "google", Email: &email, CreatedAt: now.Format(time.RFC3339)},
+	}
+
+	res := serializer.ListIdentityToResponse(param)
+
+	assert.Equal(t, wantRes, res)
+}
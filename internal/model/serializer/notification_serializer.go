@@ -0,0 +1,34 @@
+package serializer
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+)
+
+func NotificationToResponse(n *entity.Notification) *model.NotificationResponse {
+	var readAt *string
+	if n.ReadAt != nil {
+		str := n.ReadAt.Format(time.RFC3339)
+		readAt = &str
+	}
+
+	return &model.NotificationResponse{
+		ID:        n.ID,
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Body:      n.GetBody(),
+		ReadAt:    readAt,
+		CreatedAt: n.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ListNotificationToResponse(notifications []entity.Notification) []model.NotificationResponse {
+	res := make([]model.NotificationResponse, len(notifications))
+
+	for i, n := range notifications {
+		res[i] = *NotificationToResponse(&n)
+	}
+
+	return res
+}
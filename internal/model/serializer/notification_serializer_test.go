@@ -0,0 +1,89 @@
+package serializer_test
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationSerializer_NotificationToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	nowStr := now.Format(time.RFC3339)
+	body := "dummy body"
+
+	tests := []struct {
+		name    string
+		param   *entity.Notification
+		wantRes *model.NotificationResponse
+	}{
+		{
+			name: "success unread",
+			param: &entity.Notification{
+				ID:        1,
+				UserID:    1,
+				Type:      entity.NotificationTypeTodoAssigned,
+				EventKey:  "1-1",
+				Title:     "dummy title",
+				Body:      &body,
+				CreatedAt: now,
+			},
+			wantRes: &model.NotificationResponse{
+				ID:        1,
+				Type:      "todo_assigned",
+				Title:     "dummy title",
+				Body:      "dummy body",
+				ReadAt:    nil,
+				CreatedAt: nowStr,
+			},
+		},
+		{
+			name: "success read",
+			param: &entity.Notification{
+				ID:        1,
+				UserID:    1,
+				Type:      entity.NotificationTypeUserRegistered,
+				EventKey:  "1",
+				Title:     "dummy title",
+				ReadAt:    &now,
+				CreatedAt: now,
+			},
+			wantRes: &model.NotificationResponse{
+				ID:        1,
+				Type:      "user_registered",
+				Title:     "dummy title",
+				Body:      "",
+				ReadAt:    &nowStr,
+				CreatedAt: nowStr,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.NotificationToResponse(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestNotificationSerializer_ListNotificationToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+
+	param := []entity.Notification{
+		{ID: 1, Type: entity.NotificationTypeUserRegistered, Title: "title 1", CreatedAt: now},
+		{ID: 2, Type: entity.NotificationTypeTodoAssigned, Title: "title 2", CreatedAt: now},
+	}
+	wantRes := []model.NotificationResponse{
+		{ID: 1, Type: "user_registered", Title: "title 1", CreatedAt: now.Format(time.RFC3339)},
+		{ID: 2, Type: "todo_assigned", Title: "title 2", CreatedAt: now.Format(time.RFC3339)},
+	}
+
+	res := serializer.ListNotificationToResponse(param)
+
+	assert.Equal(t, wantRes, res)
+}
@@ -0,0 +1,40 @@
+package serializer
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+)
+
+func PersonalAccessTokenToResponse(t *entity.PersonalAccessToken) *model.PersonalAccessTokenResponse {
+	var lastUsedAt *string
+	if t.LastUsedAt != nil {
+		str := t.LastUsedAt.Format(time.RFC3339)
+		lastUsedAt = &str
+	}
+
+	var expiresAt *string
+	if t.ExpiresAt != nil {
+		str := t.ExpiresAt.Format(time.RFC3339)
+		expiresAt = &str
+	}
+
+	return &model.PersonalAccessTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     t.Scopes,
+		LastUsedAt: lastUsedAt,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ListPersonalAccessTokenToResponse(tokens []entity.PersonalAccessToken) []model.PersonalAccessTokenResponse {
+	res := make([]model.PersonalAccessTokenResponse, len(tokens))
+
+	for i, t := range tokens {
+		res[i] = *PersonalAccessTokenToResponse(&t)
+	}
+
+	return res
+}
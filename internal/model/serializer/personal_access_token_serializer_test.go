@@ -0,0 +1,88 @@
+package serializer_test
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessTokenSerializer_PersonalAccessTokenToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	nowStr := now.Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		param   *entity.PersonalAccessToken
+		wantRes *model.PersonalAccessTokenResponse
+	}{
+		{
+			name: "success unused without expiry",
+			param: &entity.PersonalAccessToken{
+				ID:        1,
+				UserID:    1,
+				Name:      "ci",
+				TokenHash: "dummy-hash",
+				Scopes:    []string{"todos:read"},
+				CreatedAt: now,
+			},
+			wantRes: &model.PersonalAccessTokenResponse{
+				ID:         1,
+				Name:       "ci",
+				Scopes:     []string{"todos:read"},
+				LastUsedAt: nil,
+				ExpiresAt:  nil,
+				CreatedAt:  nowStr,
+			},
+		},
+		{
+			name: "success used with expiry",
+			param: &entity.PersonalAccessToken{
+				ID:         1,
+				UserID:     1,
+				Name:       "ci",
+				TokenHash:  "dummy-hash",
+				Scopes:     []string{"todos:read", "todos:write"},
+				LastUsedAt: &now,
+				ExpiresAt:  &now,
+				CreatedAt:  now,
+			},
+			wantRes: &model.PersonalAccessTokenResponse{
+				ID:         1,
+				Name:       "ci",
+				Scopes:     []string{"todos:read", "todos:write"},
+				LastUsedAt: &nowStr,
+				ExpiresAt:  &nowStr,
+				CreatedAt:  nowStr,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.PersonalAccessTokenToResponse(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestPersonalAccessTokenSerializer_ListPersonalAccessTokenToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+
+	param := []entity.PersonalAccessToken{
+		{ID: 2, Name: "deploy", Scopes: []string{"todos:write"}, CreatedAt: now},
+		{ID: 1, Name: "ci", Scopes: []string{"todos:read"}, CreatedAt: now},
+	}
+	wantRes := []model.PersonalAccessTokenResponse{
+		{ID: 2, Name: "deploy", Scopes: []string{"todos:write"}, CreatedAt: now.Format(time.RFC3339)},
+		{ID: 1, Name: "ci", Scopes: []string{"todos:read"}, CreatedAt: now.Format(time.RFC3339)},
+	}
+
+	res := serializer.ListPersonalAccessTokenToResponse(param)
+
+	assert.Equal(t, wantRes, res)
+}
@@ -0,0 +1,29 @@
+package serializer
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+)
+
+func SecurityEventToResponse(e *entity.SecurityEvent) *model.SecurityEventResponse {
+	return &model.SecurityEventResponse{
+		ID:         e.ID,
+		Type:       e.Type,
+		SessionID:  e.SessionID,
+		IP:         e.IP,
+		UserAgent:  e.UserAgent,
+		RequestID:  e.RequestID,
+		OccurredAt: e.OccurredAt.Format(time.RFC3339),
+	}
+}
+
+func ListSecurityEventToResponse(events []entity.SecurityEvent) []model.SecurityEventResponse {
+	res := make([]model.SecurityEventResponse, len(events))
+
+	for i, e := range events {
+		res[i] = *SecurityEventToResponse(&e)
+	}
+
+	return res
+}
@@ -0,0 +1,58 @@
+package serializer_test
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityEventSerializer_SecurityEventToResponse(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 20, 0, 0, time.UTC)
+
+	param := &entity.SecurityEvent{
+		ID:         1,
+		EventID:    "event-123",
+		UserID:     1,
+		Type:       "login",
+		SessionID:  "qwe-123",
+		IP:         "127.0.0.1",
+		UserAgent:  "curl/8.0",
+		RequestID:  "req-123",
+		OccurredAt: now,
+		CreatedAt:  now.Add(time.Second),
+	}
+	wantRes := &model.SecurityEventResponse{
+		ID:         1,
+		Type:       "login",
+		SessionID:  "qwe-123",
+		IP:         "127.0.0.1",
+		UserAgent:  "curl/8.0",
+		RequestID:  "req-123",
+		OccurredAt: "2026-10-18T09:20:00Z",
+	}
+
+	res := serializer.SecurityEventToResponse(param)
+
+	assert.Equal(t, wantRes, res)
+}
+
+func TestSecurityEventSerializer_ListSecurityEventToResponse(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 20, 0, 0, time.UTC)
+
+	param := []entity.SecurityEvent{
+		{ID: 2, Type: "logout", SessionID: "qwe-123", IP: "127.0.0.1", UserAgent: "curl/8.0", OccurredAt: now},
+		{ID: 1, Type: "login_failed", IP: "127.0.0.1", UserAgent: "curl/8.0", OccurredAt: now},
+	}
+	wantRes := []model.SecurityEventResponse{
+		{ID: 2, Type: "logout", SessionID: "qwe-123", IP: "127.0.0.1", UserAgent: "curl/8.0", OccurredAt: now.Format(time.RFC3339)},
+		{ID: 1, Type: "login_failed", IP: "127.0.0.1", UserAgent: "curl/8.0", OccurredAt: now.Format(time.RFC3339)},
+	}
+
+	res := serializer.ListSecurityEventToResponse(param)
+
+	assert.Equal(t, wantRes, res)
+}
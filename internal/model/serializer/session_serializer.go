@@ -0,0 +1,29 @@
+package serializer
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+)
+
+func SessionToResponse(s *entity.Session, currentID string) *model.SessionResponse {
+	return &model.SessionResponse{
+		ID:         s.ID,
+		DeviceName: s.DeviceName,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		Current:    s.ID == currentID,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+		LastUsedAt: s.LastUsedAt.Format(time.RFC3339),
+	}
+}
+
+func ListSessionToResponse(sessions []entity.Session, currentID string) []model.SessionResponse {
+	res := make([]model.SessionResponse, len(sessions))
+
+	for i, s := range sessions {
+		res[i] = *SessionToResponse(&s, currentID)
+	}
+
+	return res
+}
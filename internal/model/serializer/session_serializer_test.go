@@ -0,0 +1,56 @@
+package serializer_test
+
+import (
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionSerializer_ListSessionToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	nowStr := now.Format(time.RFC3339)
+
+	param := []entity.Session{
+		{
+			ID:           "session-1",
+			UserID:       "1",
+			RefreshToken: "zxc-123",
+			DeviceName:   "laptop",
+			UserAgent:    "curl/8.0",
+			IP:           "127.0.0.1",
+			CreatedAt:    now,
+			LastUsedAt:   now,
+		},
+		{
+			ID:           "session-2",
+			UserID:       "1",
+			RefreshToken: "asd-123",
+			CreatedAt:    now,
+			LastUsedAt:   now,
+		},
+	}
+
+	res := serializer.ListSessionToResponse(param, "session-2")
+
+	assert.Equal(t, []model.SessionResponse{
+		{
+			ID:         "session-1",
+			DeviceName: "laptop",
+			UserAgent:  "curl/8.0",
+			IP:         "127.0.0.1",
+			Current:    false,
+			CreatedAt:  nowStr,
+			LastUsedAt: nowStr,
+		},
+		{
+			ID:         "session-2",
+			Current:    true,
+			CreatedAt:  nowStr,
+			LastUsedAt: nowStr,
+		},
+	}, res)
+}
@@ -10,6 +10,7 @@ func TodoToResponse(t *entity.Todo) *model.TodoResponse {
 	return &model.TodoResponse{
 		ID:          t.ID,
 		UserID:      t.UserID,
+		AssigneeID:  t.AssigneeID,
 		Title:       t.Title,
 		Description: t.GetDescription(),
 		Status:      t.Status.String(),
@@ -27,3 +28,19 @@ func ListTodoToResponse(todos []entity.Todo) []model.TodoResponse {
 
 	return res
 }
+
+func TodoToAssignedEvent(t *entity.Todo) *model.TodoAssignedEvent {
+	var assigneeID uint64
+	if t.AssigneeID != nil {
+		assigneeID = *t.AssigneeID
+	}
+
+	return &model.TodoAssignedEvent{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		AssigneeID: assigneeID,
+		Title:      t.Title,
+		Status:     t.Status.String(),
+		AssignedAt: t.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -13,6 +13,7 @@ import (
 func TestTodoSerializer_TodoToResponse(t *testing.T) {
 	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
 	description := "dummy description"
+	assigneeID := uint64(2)
 
 	tests := []struct {
 		name    string
@@ -40,6 +41,29 @@ func TestTodoSerializer_TodoToResponse(t *testing.T) {
 				UpdatedAt:   now.Format(time.RFC3339),
 			},
 		},
+		{
+			name: "success with assignee",
+			param: &entity.Todo{
+				ID:          3,
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "dummy title",
+				Description: &description,
+				Status:      entity.TodoStatusInProgress,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+			wantRes: &model.TodoResponse{
+				ID:          3,
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "dummy title",
+				Description: description,
+				Status:      "in_progress",
+				CreatedAt:   now.Format(time.RFC3339),
+				UpdatedAt:   now.Format(time.RFC3339),
+			},
+		},
 		{
 			name: "success without description",
 			param: &entity.Todo{
@@ -134,3 +158,43 @@ func TestTodoSerializer_ListTodoToResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoSerializer_TodoToAssignedEvent(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	assigneeID := uint64(2)
+
+	tests := []struct {
+		name    string
+		param   *entity.Todo
+		wantRes *model.TodoAssignedEvent
+	}{
+		{
+			name: "success",
+			param: &entity.Todo{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+				Title:      "dummy title",
+				Status:     entity.TodoStatusPending,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			},
+			wantRes: &model.TodoAssignedEvent{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: 2,
+				Title:      "dummy title",
+				Status:     "pending",
+				AssignedAt: now.Format(time.RFC3339),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.TodoToAssignedEvent(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
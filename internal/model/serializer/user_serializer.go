@@ -1,18 +1,56 @@
 package serializer
 
 import (
+	"go-api-example/internal/avatar"
 	"go-api-example/internal/entity"
 	"go-api-example/internal/model"
+	"strconv"
 	"time"
 )
 
 func UserToResponse(u *entity.User) *model.UserResponse {
 	return &model.UserResponse{
-		ID:        u.ID,
-		Username:  u.Username,
-		CreatedAt: u.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: u.UpdatedAt.Format(time.RFC3339),
+		ID:          u.ID,
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		AvatarURLs:  AvatarURLs(u.AvatarKey),
+		CreatedAt:   u.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   u.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// UserToProfileResponse includes the email, role and preferences, only meant for the user's own profile
+func UserToProfileResponse(u *entity.User) *model.UserResponse {
+	res := UserToResponse(u)
+	res.Timezone = u.Timezone
+	res.Locale = u.Locale
+	res.Discoverability = u.Discoverability
+	res.Email = u.Email
+	res.Role = u.Role
+
+	if u.EmailVerifiedAt != nil {
+		verifiedAt := u.EmailVerifiedAt.Format(time.RFC3339)
+		res.EmailVerifiedAt = &verifiedAt
+	}
+
+	return res
+}
+
+// UserToAdminResponse adds the account state to the profile, only meant for admins
+func UserToAdminResponse(u *entity.User) *model.UserResponse {
+	res := UserToProfileResponse(u)
+
+	if u.SuspendedAt != nil {
+		suspendedAt := u.SuspendedAt.Format(time.RFC3339)
+		res.SuspendedAt = &suspendedAt
+	}
+	if u.PasswordResetRequiredAt != nil {
+		requiredAt := u.PasswordResetRequiredAt.Format(time.RFC3339)
+		res.PasswordResetRequiredAt = &requiredAt
 	}
+
+	return res
 }
 
 func ListUserToResponse(users []entity.User) []model.UserResponse {
@@ -25,6 +63,16 @@ func ListUserToResponse(users []entity.User) []model.UserResponse {
 	return res
 }
 
+func ListUserToAdminResponse(users []entity.User) []model.UserResponse {
+	res := make([]model.UserResponse, len(users))
+
+	for i, u := range users {
+		res[i] = *UserToAdminResponse(&u)
+	}
+
+	return res
+}
+
 func UserToEvent(u *entity.User) *model.UserEvent {
 	return &model.UserEvent{
 		ID:        u.ID,
@@ -33,3 +81,38 @@ func UserToEvent(u *entity.User) *model.UserEvent {
 		UpdatedAt: u.UpdatedAt.Format(time.RFC3339),
 	}
 }
+
+// AvatarURLs maps every avatar size to the path it is served at
+func AvatarURLs(key *string) map[string]string {
+	if key == nil {
+		return nil
+	}
+
+	urls := make(map[string]string, len(avatar.Sizes))
+	for _, size := range avatar.Sizes {
+		urls[strconv.Itoa(size)] = avatar.URLPath + "/" + avatar.FileName(*key, size)
+	}
+
+	return urls
+}
+
+func UserToUpdatedEvent(u *entity.User, previousUsername string, changedFields []string) *model.UserUpdatedEvent {
+	return &model.UserUpdatedEvent{
+		ID:               u.ID,
+		Username:         u.Username,
+		PreviousUsername: previousUsername,
+		DisplayName:      u.DisplayName,
+		Timezone:         u.Timezone,
+		Locale:           u.Locale,
+		ChangedFields:    changedFields,
+		UpdatedAt:        u.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func UserToDeletedEvent(u *entity.User, deletedAt time.Time) *model.UserDeletedEvent {
+	return &model.UserDeletedEvent{
+		ID:        u.ID,
+		Username:  u.Username,
+		DeletedAt: deletedAt.Format(time.RFC3339),
+	}
+}
@@ -45,6 +45,163 @@ func TestUserSerializer_UserToResponse(t *testing.T) {
 	}
 }
 
+func TestUserSerializer_UserToProfileResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	email := "johndoe@example.com"
+	verifiedAt := now.Format(time.RFC3339)
+	displayName := "John Doe"
+	bio := "dummy bio"
+	avatarKey := "1-qwe"
+
+	tests := []struct {
+		name    string
+		param   *entity.User
+		wantRes *model.UserResponse
+	}{
+		{
+			name: "without email",
+			param: &entity.User{
+				ID:        1,
+				Username:  "johndoe",
+				Password:  "password",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantRes: &model.UserResponse{
+				ID:        1,
+				Username:  "johndoe",
+				CreatedAt: now.Format(time.RFC3339),
+				UpdatedAt: now.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "with verified email",
+			param: &entity.User{
+				ID:              1,
+				Username:        "johndoe",
+				Email:           &email,
+				EmailVerifiedAt: &now,
+				Password:        "password",
+				Role:            "admin",
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			},
+			wantRes: &model.UserResponse{
+				ID:              1,
+				Username:        "johndoe",
+				Email:           &email,
+				EmailVerifiedAt: &verifiedAt,
+				Role:            "admin",
+				CreatedAt:       now.Format(time.RFC3339),
+				UpdatedAt:       now.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "with profile",
+			param: &entity.User{
+				ID:          1,
+				Username:    "johndoe",
+				DisplayName: &displayName,
+				Bio:         &bio,
+				Timezone:    "Asia/Jakarta",
+				Locale:      "id",
+				AvatarKey:   &avatarKey,
+				Password:    "password",
+				Role:        "user",
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+			wantRes: &model.UserResponse{
+				ID:          1,
+				Username:    "johndoe",
+				DisplayName: &displayName,
+				Bio:         &bio,
+				AvatarURLs: map[string]string{
+					"32":  "/avatars/1-qwe_32.png",
+					"64":  "/avatars/1-qwe_64.png",
+					"128": "/avatars/1-qwe_128.png",
+					"256": "/avatars/1-qwe_256.png",
+				},
+				Timezone:  "Asia/Jakarta",
+				Locale:    "id",
+				Role:      "user",
+				CreatedAt: now.Format(time.RFC3339),
+				UpdatedAt: now.Format(time.RFC3339),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.UserToProfileResponse(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestUserSerializer_UserToAdminResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	formatted := now.Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		param   *entity.User
+		wantRes *model.UserResponse
+	}{
+		{
+			name: "active user",
+			param: &entity.User{
+				ID:              1,
+				Username:        "johndoe",
+				Discoverability: "public",
+				Password:        "password",
+				Role:            "user",
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			},
+			wantRes: &model.UserResponse{
+				ID:              1,
+				Username:        "johndoe",
+				Discoverability: "public",
+				Role:            "user",
+				CreatedAt:       formatted,
+				UpdatedAt:       formatted,
+			},
+		},
+		{
+			name: "suspended user with required password reset",
+			param: &entity.User{
+				ID:                      1,
+				Username:                "johndoe",
+				Password:                "password",
+				Role:                    "user",
+				SuspendedAt:             &now,
+				PasswordResetRequiredAt: &now,
+				CreatedAt:               now,
+				UpdatedAt:               now,
+			},
+			wantRes: &model.UserResponse{
+				ID:                      1,
+				Username:                "johndoe",
+				Role:                    "user",
+				SuspendedAt:             &formatted,
+				PasswordResetRequiredAt: &formatted,
+				CreatedAt:               formatted,
+				UpdatedAt:               formatted,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.UserToAdminResponse(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
 func TestUserSerializer_ListUserToResponse(t *testing.T) {
 	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
 
@@ -98,3 +255,53 @@ func TestUserSerializer_ListUserToResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestUserSerializer_UserToDeletedEvent(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	user := &entity.User{
+		ID:        1,
+		Username:  "johndoe",
+		Password:  "password",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	res := serializer.UserToDeletedEvent(user, deletedAt)
+
+	assert.Equal(t, &model.UserDeletedEvent{
+		ID:        1,
+		Username:  "johndoe",
+		DeletedAt: "2025-09-01T10:00:00Z",
+	}, res)
+}
+
+func TestUserSerializer_UserToUpdatedEvent(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	displayName := "John Doe"
+
+	user := &entity.User{
+		ID:          1,
+		Username:    "johndoe2",
+		DisplayName: &displayName,
+		Timezone:    "UTC",
+		Locale:      "en",
+		Password:    "password",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	res := serializer.UserToUpdatedEvent(user, "johndoe", []string{"username", "display_name"})
+
+	assert.Equal(t, &model.UserUpdatedEvent{
+		ID:               1,
+		Username:         "johndoe2",
+		PreviousUsername: "johndoe",
+		DisplayName:      &displayName,
+		Timezone:         "UTC",
+		Locale:           "en",
+		ChangedFields:    []string{"username", "display_name"},
+		UpdatedAt:        "2025-08-13T10:00:00Z",
+	}, res)
+}
@@ -0,0 +1,32 @@
+package model
+
+import "go-api-example/internal/auth"
+
+type ListSessionRequest struct {
+	Claims *auth.JWTClaims `json:"claims"`
+}
+
+type RevokeSessionRequest struct {
+	ID        string          `json:"id"`
+	Claims    *auth.JWTClaims `json:"claims"`
+	UserAgent string          `json:"-"`
+	IP        string          `json:"-"`
+	RequestID string          `json:"-"`
+}
+
+type RevokeAllSessionRequest struct {
+	Claims    *auth.JWTClaims `json:"claims"`
+	UserAgent string          `json:"-"`
+	IP        string          `json:"-"`
+	RequestID string          `json:"-"`
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	DeviceName string `json:"device_name"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	Current    bool   `json:"current"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+}
@@ -6,25 +6,28 @@ import (
 
 type CreateTodoRequest struct {
 	UserID      uint64  `json:"user_id"`
+	AssigneeID  *uint64 `json:"assignee_id"`
 	Title       string  `json:"title" validate:"required"`
 	Description *string `json:"description"`
 }
 
 type TodoResponse struct {
-	ID          uint64 `json:"id"`
-	UserID      uint64 `json:"user_id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          uint64  `json:"id"`
+	UserID      uint64  `json:"user_id"`
+	AssigneeID  *uint64 `json:"assignee_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
 }
 
 type SearchTodoRequest struct {
-	UserID uint64             `json:"user_id"`
-	Status *entity.TodoStatus `json:"status"`
-	Limit  int                `json:"limit" validate:"min=1,max=20"`
-	Offset int                `json:"offset" validate:"min=0"`
+	UserID       uint64             `json:"user_id"`
+	AssignedToMe bool               `json:"assigned_to_me"`
+	Status       *entity.TodoStatus `json:"status"`
+	Limit        int                `json:"limit" validate:"min=1,max=20"`
+	Offset       int                `json:"offset" validate:"min=0"`
 }
 
 type GetTodoRequest struct {
@@ -40,3 +43,38 @@ type UpdateTodoRequest struct {
 	Status      string            `json:"status" validate:"required"`
 	IntStatus   entity.TodoStatus `json:"int_status"`
 }
+
+type AssignTodoRequest struct {
+	ID         uint64  `json:"id"`
+	UserID     uint64  `json:"user_id"`
+	AssigneeID *uint64 `json:"assignee_id"`
+}
+
+type DeleteTodoRequest struct {
+	ID     uint64 `json:"id"`
+	UserID uint64 `json:"user_id"`
+}
+
+type BoardTodoRequest struct {
+	UserID       uint64                   `json:"user_id"`
+	AssignedToMe bool                     `json:"assigned_to_me"`
+	Columns      []BoardTodoColumnRequest `json:"columns" validate:"dive"`
+}
+
+type BoardTodoColumnRequest struct {
+	Status entity.TodoStatus `json:"status"`
+	Limit  int               `json:"limit" validate:"min=1,max=20"`
+	Offset int               `json:"offset" validate:"min=0"`
+}
+
+type BoardTodoResponse struct {
+	Columns []BoardTodoColumnResponse `json:"columns"`
+}
+
+type BoardTodoColumnResponse struct {
+	Status string         `json:"status"`
+	Todos  []TodoResponse `json:"todos"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	Total  int            `json:"total"`
+}
@@ -0,0 +1,24 @@
+package model
+
+type EnrollTOTPRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type ConfirmTOTPRequest struct {
+	UserID uint64 `json:"user_id"`
+	Code   string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type DisableTOTPRequest struct {
+	UserID   uint64 `json:"user_id"`
+	Password string `json:"password" validate:"required"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
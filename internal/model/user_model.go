@@ -1,30 +1,113 @@
 package model
 
+import "go-api-example/internal/auth"
+
 type CreateUserRequest struct {
 	Username string `json:"username" validate:"required,min=4,max=64"`
-	Password string `json:"password" validate:"required,min=4,max=64"`
+	Password string `json:"password" validate:"required,max=64"`
 }
 
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+)
+
+// SearchUserRequest only finds the users that are discoverable by the viewer, unless it is unrestricted for admins
 type SearchUserRequest struct {
 	ID       *uint64 `json:"id"`
 	Username *string `json:"username"`
-	Limit    int     `json:"limit" validate:"min=1,max=20"`
-	Offset   int     `json:"offset" validate:"min=0"`
+	// Query matches the start of usernames, for mentions and autocomplete
+	Query *string `json:"q"`
+	// Email, Role and Status are only offered to admins
+	Email        *string `json:"email" validate:"omitempty,max=255"`
+	Role         *string `json:"role" validate:"omitempty,max=50"`
+	Status       *string `json:"status" validate:"omitempty,oneof=active suspended"`
+	Limit        int     `json:"limit" validate:"min=1,max=20"`
+	Offset       int     `json:"offset" validate:"min=0"`
+	ViewerID     uint64  `json:"-"`
+	Unrestricted bool    `json:"-"`
 }
 
 type GetUserRequest struct {
 	ID uint64 `json:"id"`
 }
 
+// UpdateUserRequest changes only the fields that are set, an empty display name or bio clears it. The password is
+// changed when old_password and new_password are both given
 type UpdateUserRequest struct {
-	ID          uint64 `json:"id"`
-	OldPassword string `json:"old_password" validate:"required"`
-	NewPassword string `json:"new_password" validate:"required"`
+	ID          uint64  `json:"id"`
+	Username    *string `json:"username" validate:"omitempty,min=4,max=64"`
+	DisplayName *string `json:"display_name" validate:"omitempty,max=100"`
+	Bio         *string `json:"bio" validate:"omitempty,max=500"`
+	Timezone    *string `json:"timezone" validate:"omitempty,max=64,timezone"`
+	Locale      *string `json:"locale" validate:"omitempty,max=35,bcp47_language_tag"`
+	// Discoverability is one of public, contacts or hidden
+	Discoverability *string `json:"discoverability" validate:"omitempty,oneof=public contacts hidden"`
+	OldPassword     string  `json:"old_password" validate:"required_with=NewPassword"`
+	NewPassword     string  `json:"new_password" validate:"required_with=OldPassword,max=64"`
+	UserAgent       string  `json:"-"`
+	IP              string  `json:"-"`
+	RequestID       string  `json:"-"`
+}
+
+type UpdateAvatarRequest struct {
+	UserID uint64 `json:"user_id"`
+	Image  []byte `json:"-"`
+}
+
+type DeleteAvatarRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type DeleteUserRequest struct {
+	ID       uint64          `json:"id"`
+	Claims   *auth.JWTClaims `json:"claims"`
+	Password string          `json:"password" validate:"required"`
+}
+
+// AdminUserActionRequest is an admin acting on the account of another user, the reason goes to the audit log
+type AdminUserActionRequest struct {
+	ID      uint64 `json:"-"`
+	ActorID uint64 `json:"-"`
+	Reason  string `json:"reason" validate:"max=500"`
+}
+
+type VerifyEmailRequest struct {
+	UserID uint64 `json:"user_id"`
+	Email  string `json:"email" validate:"required,email,max=255"`
+}
+
+type ConfirmEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max=64"`
+	UserAgent   string `json:"-"`
+	IP          string `json:"-"`
+	RequestID   string `json:"-"`
 }
 
 type UserResponse struct {
-	ID        uint64 `json:"id"`
-	Username  string `json:"username"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID              uint64            `json:"id"`
+	Username        string            `json:"username"`
+	DisplayName     *string           `json:"display_name,omitempty"`
+	Bio             *string           `json:"bio,omitempty"`
+	AvatarURLs      map[string]string `json:"avatar_urls,omitempty"`
+	Timezone        string            `json:"timezone,omitempty"`
+	Locale          string            `json:"locale,omitempty"`
+	Discoverability string            `json:"discoverability,omitempty"`
+	Email           *string           `json:"email,omitempty"`
+	EmailVerifiedAt *string           `json:"email_verified_at,omitempty"`
+	Role            string            `json:"role,omitempty"`
+	// SuspendedAt and PasswordResetRequiredAt are only shown to admins
+	SuspendedAt             *string `json:"suspended_at,omitempty"`
+	PasswordResetRequiredAt *string `json:"password_reset_required_at,omitempty"`
+	CreatedAt               string  `json:"created_at"`
+	UpdatedAt               string  `json:"updated_at"`
 }
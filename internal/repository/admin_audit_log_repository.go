@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"time"
+)
+
+type AdminAuditLogRepository struct {
+	DB *sql.DB
+}
+
+func NewAdminAuditLogRepository(db *sql.DB) *AdminAuditLogRepository {
+	return &AdminAuditLogRepository{
+		DB: db,
+	}
+}
+
+// Create takes the executor of the change it records, so a change is never made without its log
+func (r *AdminAuditLogRepository) Create(ctx context.Context, exec db.Executor, log *entity.AdminAuditLog) error {
+	now := time.Now()
+	query := `INSERT INTO admin_audit_logs (actor_id, user_id, action, reason, created_at) VALUES (?, ?, ?, ?, ?)`
+
+	res, err := exec.ExecContext(ctx, query, log.ActorID, log.UserID, log.Action, log.Reason, now)
+	if err != nil {
+		return err
+	}
+
+	id, _ := res.LastInsertId()
+	log.ID = uint64(id)
+	log.CreatedAt = now
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminAuditLogRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.AdminAuditLogRepository
+	ctx  context.Context
+}
+
+func (s *AdminAuditLogRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewAdminAuditLogRepository(s.db)
+	s.ctx = context.Background()
+}
+
+func (s *AdminAuditLogRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *AdminAuditLogRepositorySuite) TestAdminAuditLogRepository_Create() {
+	query := regexp.QuoteMeta(`INSERT INTO admin_audit_logs (actor_id, user_id, action, reason, created_at) VALUES (?, ?, ?, ?, ?)`)
+	reason := "spam"
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, 2, "suspend", "spam", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			wantID:  3,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, 2, "suspend", "spam", sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			log := &entity.AdminAuditLog{
+				ActorID: 1,
+				UserID:  2,
+				Action:  entity.AdminActionSuspend,
+				Reason:  &reason,
+			}
+			err := s.repo.Create(s.ctx, s.db, log)
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantID, log.ID)
+		})
+	}
+}
+
+func TestAdminAuditLogRepositorySuite(t *testing.T) {
+	suite.Run(t, new(AdminAuditLogRepositorySuite))
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"go-api-example/internal/entity"
+	"time"
+)
+
+type ImpersonationLogRepository struct {
+	DB *sql.DB
+}
+
+func NewImpersonationLogRepository(db *sql.DB) *ImpersonationLogRepository {
+	return &ImpersonationLogRepository{
+		DB: db,
+	}
+}
+
+func (r *ImpersonationLogRepository) Create(ctx context.Context, log *entity.ImpersonationLog) error {
+	now := time.Now()
+	query := `INSERT INTO impersonation_logs (actor_id, user_id, token_id, method, path, ip, request_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	res, err := r.DB.ExecContext(ctx, query, log.ActorID, log.UserID, log.TokenID, log.Method, log.Path, log.IP,
+		log.RequestID, now)
+	if err != nil {
+		return err
+	}
+
+	id, _ := res.LastInsertId()
+	log.ID = uint64(id)
+	log.CreatedAt = now
+
+	return nil
+}
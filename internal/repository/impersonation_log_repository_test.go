@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImpersonationLogRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.ImpersonationLogRepository
+	ctx  context.Context
+}
+
+func (s *ImpersonationLogRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewImpersonationLogRepository(s.db)
+	s.ctx = context.Background()
+}
+
+func (s *ImpersonationLogRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ImpersonationLogRepositorySuite) TestImpersonationLogRepository_Create() {
+	query := regexp.QuoteMeta(`INSERT INTO impersonation_logs (actor_id, user_id, token_id, method, path, ip, request_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, 2, "token-id", "GET", "/api/todos", "127.0.0.1", "request-id", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			wantID:  3,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, 2, "token-id", "GET", "/api/todos", "127.0.0.1", "request-id", sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			log := &entity.ImpersonationLog{
+				ActorID:   1,
+				UserID:    2,
+				TokenID:   "token-id",
+				Method:    "GET",
+				Path:      "/api/todos",
+				IP:        "127.0.0.1",
+				RequestID: "request-id",
+			}
+			err := s.repo.Create(s.ctx, log)
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantID, log.ID)
+		})
+	}
+}
+
+func TestImpersonationLogRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationLogRepositorySuite))
+}
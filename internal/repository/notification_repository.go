@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"strings"
+	"time"
+)
+
+type NotificationRepository struct {
+	DB *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{
+		DB: db,
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	now := time.Now()
+	// events are delivered at least once, a duplicated event key is silently ignored
+	query := `INSERT IGNORE INTO notifications (user_id, type, event_key, title, body, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+
+	res, err := r.DB.ExecContext(ctx, query, notification.UserID, notification.Type, notification.EventKey,
+		notification.Title, notification.Body, now)
+	if err != nil {
+		return err
+	}
+
+	id, _ := res.LastInsertId()
+	notification.ID = uint64(id)
+	notification.CreatedAt = now
+
+	return nil
+}
+
+func (r *NotificationRepository) List(ctx context.Context, req *model.SearchNotificationRequest) ([]entity.Notification, int, error) {
+	conditions := []string{"user_id = ?"}
+	args := []any{req.UserID}
+
+	if req.UnreadOnly {
+		conditions = append(conditions, "read_at IS NULL")
+	}
+
+	var countSb strings.Builder
+	countSb.WriteString("SELECT COUNT(id) FROM notifications")
+
+	if len(conditions) > 0 {
+		countSb.WriteString(" WHERE ")
+		countSb.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	var total int
+	if err := r.DB.QueryRowContext(ctx, countSb.String(), args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications`)
+
+	if len(conditions) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	sb.WriteString(" ORDER BY id DESC LIMIT ? OFFSET ?")
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := r.DB.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var notifications []entity.Notification
+	for rows.Next() {
+		var n entity.Notification
+		err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.EventKey, &n.Title, &n.Body, &n.ReadAt, &n.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, total, nil
+}
+
+func (r *NotificationRepository) CountUnreadByUserID(ctx context.Context, userID uint64) (int, error) {
+	query := `SELECT COUNT(id) FROM notifications WHERE user_id = ? AND read_at IS NULL`
+
+	var total int
+	err := r.DB.QueryRowContext(ctx, query, userID).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *NotificationRepository) FindByID(ctx context.Context, id uint64) (*entity.Notification, error) {
+	query := `SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE id = ? LIMIT 1`
+
+	var n entity.Notification
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&n.ID, &n.UserID, &n.Type, &n.EventKey, &n.Title, &n.Body, &n.ReadAt, &n.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &n, nil
+}
+
+func (r *NotificationRepository) MarkReadByID(ctx context.Context, id uint64) error {
+	now := time.Now()
+	query := `UPDATE notifications SET read_at = ? WHERE id = ? AND read_at IS NULL`
+
+	_, err := r.DB.ExecContext(ctx, query, now, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllReadByUserID(ctx context.Context, userID uint64) error {
+	now := time.Now()
+	query := `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL`
+
+	_, err := r.DB.ExecContext(ctx, query, now, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	query := `DELETE FROM notifications WHERE user_id = ?`
+
+	_, err := exec.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,470 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type NotificationRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.NotificationRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *NotificationRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewNotificationRepository(s.db)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *NotificationRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_Create() {
+	body := "dummy body"
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		param    *entity.Notification
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`INSERT IGNORE INTO notifications (user_id, type, event_key, title, body, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+				)).
+					WithArgs(1, "todo_assigned", "1-2", "dummy title", "dummy body", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			param: &entity.Notification{
+				UserID:   1,
+				Type:     entity.NotificationTypeTodoAssigned,
+				EventKey: "1-2",
+				Title:    "dummy title",
+				Body:     &body,
+			},
+			wantID:  5,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`INSERT IGNORE INTO notifications (user_id, type, event_key, title, body, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+				)).
+					WithArgs(1, "todo_assigned", "1-2", "dummy title", "dummy body", sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &entity.Notification{
+				UserID:   1,
+				Type:     entity.NotificationTypeTodoAssigned,
+				EventKey: "1-2",
+				Title:    "dummy title",
+				Body:     &body,
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Create(s.ctx, tt.param)
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantID, tt.param.ID)
+		})
+	}
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_List() {
+	body := "dummy body"
+	columns := []string{"id", "user_id", "type", "event_key", "title", "body", "read_at", "created_at"}
+
+	tests := []struct {
+		name              string
+		mockFunc          func(sqlmock.Sqlmock)
+		param             *model.SearchNotificationRequest
+		wantNotifications []entity.Notification
+		wantTotal         int
+		wantErr           error
+	}{
+		{
+			name: "success with default param",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM notifications WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, "todo_assigned", "1-1", "dummy title 2", body, nil, s.now).
+					AddRow(1, 1, "user_registered", "1-johndoe", "dummy title 1", body, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE user_id = ? ORDER BY id DESC LIMIT ? OFFSET ?`,
+				)).
+					WithArgs(1, 10, 0).
+					WillReturnRows(rows)
+			},
+			param: &model.SearchNotificationRequest{
+				UserID: 1,
+				Limit:  10,
+				Offset: 0,
+			},
+			wantNotifications: []entity.Notification{
+				{
+					ID:        2,
+					UserID:    1,
+					Type:      entity.NotificationTypeTodoAssigned,
+					EventKey:  "1-1",
+					Title:     "dummy title 2",
+					Body:      &body,
+					CreatedAt: s.now,
+				},
+				{
+					ID:        1,
+					UserID:    1,
+					Type:      entity.NotificationTypeUserRegistered,
+					EventKey:  "1-johndoe",
+					Title:     "dummy title 1",
+					Body:      &body,
+					ReadAt:    &s.now,
+					CreatedAt: s.now,
+				},
+			},
+			wantTotal: 2,
+			wantErr:   nil,
+		},
+		{
+			name: "success with unread only",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM notifications WHERE user_id = ? AND read_at IS NULL`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, "todo_assigned", "1-1", "dummy title 2", body, nil, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE user_id = ? AND read_at IS NULL ORDER BY id DESC LIMIT ? OFFSET ?`,
+				)).
+					WithArgs(1, 10, 0).
+					WillReturnRows(rows)
+			},
+			param: &model.SearchNotificationRequest{
+				UserID:     1,
+				UnreadOnly: true,
+				Limit:      10,
+				Offset:     0,
+			},
+			wantNotifications: []entity.Notification{
+				{
+					ID:        2,
+					UserID:    1,
+					Type:      entity.NotificationTypeTodoAssigned,
+					EventKey:  "1-1",
+					Title:     "dummy title 2",
+					Body:      &body,
+					CreatedAt: s.now,
+				},
+			},
+			wantTotal: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "error on count",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM notifications WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &model.SearchNotificationRequest{
+				UserID: 1,
+				Limit:  10,
+				Offset: 0,
+			},
+			wantNotifications: nil,
+			wantTotal:         0,
+			wantErr:           errors.New("something error"),
+		},
+		{
+			name: "error on select",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM notifications WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE user_id = ? ORDER BY id DESC LIMIT ? OFFSET ?`,
+				)).
+					WithArgs(1, 10, 0).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &model.SearchNotificationRequest{
+				UserID: 1,
+				Limit:  10,
+				Offset: 0,
+			},
+			wantNotifications: nil,
+			wantTotal:         0,
+			wantErr:           errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, total, err := s.repo.List(s.ctx, tt.param)
+			s.Equal(tt.wantNotifications, res)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_CountUnreadByUserID() {
+	tests := []struct {
+		name      string
+		mockFunc  func(sqlmock.Sqlmock)
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM notifications WHERE user_id = ? AND read_at IS NULL`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			wantTotal: 3,
+			wantErr:   nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM notifications WHERE user_id = ? AND read_at IS NULL`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantTotal: 0,
+			wantErr:   errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			total, err := s.repo.CountUnreadByUserID(s.ctx, 1)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_FindByID() {
+	tests := []struct {
+		name             string
+		mockFunc         func(sqlmock.Sqlmock)
+		wantNotification *entity.Notification
+		wantErr          error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "type", "event_key", "title", "body", "read_at", "created_at"}).
+					AddRow(1, 1, "user_registered", "1-johndoe", "dummy title", nil, nil, s.now)
+				m.ExpectQuery(`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE id = \? LIMIT 1`).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			wantNotification: &entity.Notification{
+				ID:        1,
+				UserID:    1,
+				Type:      entity.NotificationTypeUserRegistered,
+				EventKey:  "1-johndoe",
+				Title:     "dummy title",
+				CreatedAt: s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE id = \? LIMIT 1`).
+					WithArgs(1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantNotification: nil,
+			wantErr:          nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(`SELECT id, user_id, type, event_key, title, body, read_at, created_at FROM notifications WHERE id = \? LIMIT 1`).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantNotification: nil,
+			wantErr:          errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, 1)
+			s.Equal(tt.wantNotification, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_MarkReadByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE notifications SET read_at = \? WHERE id = \? AND read_at IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE notifications SET read_at = \? WHERE id = \? AND read_at IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.MarkReadByID(s.ctx, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_MarkAllReadByUserID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE notifications SET read_at = \? WHERE user_id = \? AND read_at IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE notifications SET read_at = \? WHERE user_id = \? AND read_at IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.MarkAllReadByUserID(s.ctx, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *NotificationRepositorySuite) TestNotificationRepository_DeleteByUserID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM notifications WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM notifications WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByUserID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestNotificationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(NotificationRepositorySuite))
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"time"
+)
+
+type PersonalAccessTokenRepository struct {
+	DB *sql.DB
+}
+
+func NewPersonalAccessTokenRepository(db *sql.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		DB: db,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPersonalAccessToken(row rowScanner) (*entity.PersonalAccessToken, error) {
+	var t entity.PersonalAccessToken
+	var scopes []byte
+	err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.TokenHash, &scopes, &t.LastUsedAt, &t.ExpiresAt, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(scopes, &t.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *PersonalAccessTokenRepository) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	now := time.Now()
+	query := `INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.DB.ExecContext(ctx, query, token.UserID, token.Name, token.TokenHash, scopes, token.ExpiresAt, now)
+	if err != nil {
+		return err
+	}
+
+	id, _ := res.LastInsertId()
+	token.ID = uint64(id)
+	token.CreatedAt = now
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID uint64) ([]entity.PersonalAccessToken, error) {
+	query := `SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at FROM personal_access_tokens WHERE user_id = ? ORDER BY id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []entity.PersonalAccessToken
+	for rows.Next() {
+		t, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+
+	return tokens, nil
+}
+
+func (r *PersonalAccessTokenRepository) FindByID(ctx context.Context, id uint64) (*entity.PersonalAccessToken, error) {
+	query := `SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at FROM personal_access_tokens WHERE id = ? LIMIT 1`
+
+	t, err := scanPersonalAccessToken(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (r *PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	query := `SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at FROM personal_access_tokens WHERE token_hash = ? LIMIT 1`
+
+	t, err := scanPersonalAccessToken(r.DB.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (r *PersonalAccessTokenRepository) UpdateLastUsedAtByID(ctx context.Context, id uint64, lastUsedAt time.Time) error {
+	query := `UPDATE personal_access_tokens SET last_used_at = ? WHERE id = ?`
+
+	_, err := r.DB.ExecContext(ctx, query, lastUsedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) DeleteByID(ctx context.Context, id uint64) error {
+	query := `DELETE FROM personal_access_tokens WHERE id = ?`
+
+	_, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	query := `DELETE FROM personal_access_tokens WHERE user_id = ?`
+
+	_, err := exec.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PersonalAccessTokenRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.PersonalAccessTokenRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *PersonalAccessTokenRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewPersonalAccessTokenRepository(s.db)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_Create() {
+	query := regexp.QuoteMeta(`INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "ci", "dummy-hash", []byte(`["todos:read"]`), s.now, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			wantID:  5,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "ci", "dummy-hash", []byte(`["todos:read"]`), s.now, sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			token := &entity.PersonalAccessToken{
+				UserID:    1,
+				Name:      "ci",
+				TokenHash: "dummy-hash",
+				Scopes:    []string{"todos:read"},
+				ExpiresAt: &s.now,
+			}
+			err := s.repo.Create(s.ctx, token)
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantID, token.ID)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_ListByUserID() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at FROM personal_access_tokens WHERE user_id = ? ORDER BY id DESC`)
+	columns := []string{"id", "user_id", "name", "token_hash", "scopes", "last_used_at", "expires_at", "created_at"}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(sqlmock.Sqlmock)
+		wantTokens []entity.PersonalAccessToken
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, "deploy", "hash-2", []byte(`["todos:read","todos:write"]`), s.now, nil, s.now).
+					AddRow(1, 1, "ci", "hash-1", []byte(`["todos:read"]`), nil, s.now, s.now)
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			wantTokens: []entity.PersonalAccessToken{
+				{
+					ID:         2,
+					UserID:     1,
+					Name:       "deploy",
+					TokenHash:  "hash-2",
+					Scopes:     []string{"todos:read", "todos:write"},
+					LastUsedAt: &s.now,
+					CreatedAt:  s.now,
+				},
+				{
+					ID:        1,
+					UserID:    1,
+					Name:      "ci",
+					TokenHash: "hash-1",
+					Scopes:    []string{"todos:read"},
+					ExpiresAt: &s.now,
+					CreatedAt: s.now,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid scopes",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "ci", "hash-1", []byte(`todos:read`), nil, nil, s.now)
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByUserID(s.ctx, 1)
+			s.Equal(tt.wantTokens, res)
+			s.Equal(tt.wantErr, err != nil)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_FindByID() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at FROM personal_access_tokens WHERE id = ? LIMIT 1`)
+
+	tests := []struct {
+		name      string
+		mockFunc  func(sqlmock.Sqlmock)
+		wantToken *entity.PersonalAccessToken
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "token_hash", "scopes", "last_used_at", "expires_at", "created_at"}).
+					AddRow(1, 1, "ci", "hash-1", []byte(`["todos:read"]`), nil, nil, s.now)
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			wantToken: &entity.PersonalAccessToken{
+				ID:        1,
+				UserID:    1,
+				Name:      "ci",
+				TokenHash: "hash-1",
+				Scopes:    []string{"todos:read"},
+				CreatedAt: s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantToken: nil,
+			wantErr:   nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantToken: nil,
+			wantErr:   errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, 1)
+			s.Equal(tt.wantToken, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_FindByTokenHash() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at FROM personal_access_tokens WHERE token_hash = ? LIMIT 1`)
+
+	tests := []struct {
+		name      string
+		mockFunc  func(sqlmock.Sqlmock)
+		wantToken *entity.PersonalAccessToken
+		wantErr   error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "token_hash", "scopes", "last_used_at", "expires_at", "created_at"}).
+					AddRow(1, 1, "ci", "hash-1", []byte(`["todos:read"]`), s.now, s.now, s.now)
+				m.ExpectQuery(query).
+					WithArgs("hash-1").
+					WillReturnRows(rows)
+			},
+			wantToken: &entity.PersonalAccessToken{
+				ID:         1,
+				UserID:     1,
+				Name:       "ci",
+				TokenHash:  "hash-1",
+				Scopes:     []string{"todos:read"},
+				LastUsedAt: &s.now,
+				ExpiresAt:  &s.now,
+				CreatedAt:  s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs("hash-1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantToken: nil,
+			wantErr:   nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs("hash-1").
+					WillReturnError(errors.New("something error"))
+			},
+			wantToken: nil,
+			wantErr:   errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByTokenHash(s.ctx, "hash-1")
+			s.Equal(tt.wantToken, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_UpdateLastUsedAtByID() {
+	query := regexp.QuoteMeta(`UPDATE personal_access_tokens SET last_used_at = ? WHERE id = ?`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(s.now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(s.now, 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateLastUsedAtByID(s.ctx, 1, s.now)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_DeleteByID() {
+	query := regexp.QuoteMeta(`DELETE FROM personal_access_tokens WHERE id = ?`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByID(s.ctx, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_DeleteByUserID() {
+	query := regexp.QuoteMeta(`DELETE FROM personal_access_tokens WHERE user_id = ?`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByUserID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestPersonalAccessTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenRepositorySuite))
+}
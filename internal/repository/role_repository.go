@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"go-api-example/internal/entity"
+)
+
+type RoleRepository struct {
+	DB *sql.DB
+}
+
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{
+		DB: db,
+	}
+}
+
+func (r *RoleRepository) FindByName(ctx context.Context, name string) (*entity.Role, error) {
+	query := `SELECT id, name, permissions, created_at, updated_at FROM roles WHERE name = ? LIMIT 1`
+
+	var role entity.Role
+	var permissions []byte
+	err := r.DB.QueryRowContext(ctx, query, name).Scan(&role.ID, &role.Name, &permissions, &role.CreatedAt, &role.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	err = json.Unmarshal(permissions, &role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.RoleRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *RoleRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewRoleRepository(s.db)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *RoleRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *RoleRepositorySuite) TestRoleRepository_FindByName() {
+	query := `SELECT id, name, permissions, created_at, updated_at FROM roles WHERE name = ? LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantRole *entity.Role
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "permissions", "created_at", "updated_at"}).
+					AddRow(1, "user", []byte(`["todos:read", "todos:write"]`), s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user").
+					WillReturnRows(rows)
+			},
+			wantRole: &entity.Role{
+				ID:          1,
+				Name:        "user",
+				Permissions: []string{"todos:read", "todos:write"},
+				CreatedAt:   s.now,
+				UpdatedAt:   s.now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantRole: nil,
+			wantErr:  false,
+		},
+		{
+			name: "invalid permissions",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "permissions", "created_at", "updated_at"}).
+					AddRow(1, "user", []byte(`todos:read`), s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user").
+					WillReturnRows(rows)
+			},
+			wantRole: nil,
+			wantErr:  true,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user").
+					WillReturnError(errors.New("something error"))
+			},
+			wantRole: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByName(s.ctx, "user")
+			s.Equal(tt.wantRole, res)
+			s.Equal(tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestRoleRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RoleRepositorySuite))
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+)
+
+type SecurityEventRepository struct {
+	DB *sql.DB
+}
+
+func NewSecurityEventRepository(db *sql.DB) *SecurityEventRepository {
+	return &SecurityEventRepository{
+		DB: db,
+	}
+}
+
+func (r *SecurityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	now := time.Now()
+	// events are delivered at least once, a duplicated event id is silently ignored
+	query := `INSERT IGNORE INTO security_events (event_id, user_id, type, session_id, ip, user_agent, request_id, occurred_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	res, err := r.DB.ExecContext(ctx, query, event.EventID, event.UserID, event.Type, event.SessionID, event.IP,
+		event.UserAgent, event.RequestID, event.OccurredAt, now)
+	if err != nil {
+		return err
+	}
+
+	id, _ := res.LastInsertId()
+	event.ID = uint64(id)
+	event.CreatedAt = now
+
+	return nil
+}
+
+func (r *SecurityEventRepository) List(ctx context.Context, req *model.SearchSecurityEventRequest) ([]entity.SecurityEvent, int, error) {
+	countQuery := `SELECT COUNT(id) FROM security_events WHERE user_id = ?`
+
+	var total int
+	if err := r.DB.QueryRowContext(ctx, countQuery, req.UserID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT id, event_id, user_id, type, session_id, ip, user_agent, request_id, occurred_at, created_at FROM security_events WHERE user_id = ? ORDER BY occurred_at DESC, id DESC LIMIT ? OFFSET ?`
+
+	rows, err := r.DB.QueryContext(ctx, query, req.UserID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []entity.SecurityEvent
+	for rows.Next() {
+		var e entity.SecurityEvent
+		err := rows.Scan(&e.ID, &e.EventID, &e.UserID, &e.Type, &e.SessionID, &e.IP, &e.UserAgent, &e.RequestID,
+			&e.OccurredAt, &e.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, e)
+	}
+
+	return events, total, nil
+}
+
+func (r *SecurityEventRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	query := `DELETE FROM security_events WHERE user_id = ?`
+
+	_, err := exec.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
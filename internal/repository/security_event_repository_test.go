@@ -0,0 +1,234 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityEventRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.SecurityEventRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *SecurityEventRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewSecurityEventRepository(s.db)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *SecurityEventRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *SecurityEventRepositorySuite) TestSecurityEventRepository_Create() {
+	query := `INSERT IGNORE INTO security_events (event_id, user_id, type, session_id, ip, user_agent, request_id, occurred_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("evt-123", 1, "login", "qwe-123", "127.0.0.1", "curl/8.0", "req-123", s.now, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			wantID:  5,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("evt-123", 1, "login", "qwe-123", "127.0.0.1", "curl/8.0", "req-123", s.now, sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			event := &entity.SecurityEvent{
+				EventID:    "evt-123",
+				UserID:     1,
+				Type:       "login",
+				SessionID:  "qwe-123",
+				IP:         "127.0.0.1",
+				UserAgent:  "curl/8.0",
+				RequestID:  "req-123",
+				OccurredAt: s.now,
+			}
+			err := s.repo.Create(s.ctx, event)
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantID, event.ID)
+		})
+	}
+}
+
+func (s *SecurityEventRepositorySuite) TestSecurityEventRepository_List() {
+	columns := []string{"id", "event_id", "user_id", "type", "session_id", "ip", "user_agent", "request_id", "occurred_at", "created_at"}
+	countQuery := `SELECT COUNT(id) FROM security_events WHERE user_id = ?`
+	selectQuery := `SELECT id, event_id, user_id, type, session_id, ip, user_agent, request_id, occurred_at, created_at FROM security_events WHERE user_id = ? ORDER BY occurred_at DESC, id DESC LIMIT ? OFFSET ?`
+	param := &model.SearchSecurityEventRequest{
+		UserID: 1,
+		Limit:  10,
+		Offset: 0,
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(sqlmock.Sqlmock)
+		wantEvents []entity.SecurityEvent
+		wantTotal  int
+		wantErr    error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, "evt-2", 1, "logout", "qwe-123", "127.0.0.1", "curl/8.0", "req-2", s.now, s.now).
+					AddRow(1, "evt-1", 1, "login", "qwe-123", "127.0.0.1", "curl/8.0", "req-1", s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(1, 10, 0).
+					WillReturnRows(rows)
+			},
+			wantEvents: []entity.SecurityEvent{
+				{
+					ID:         2,
+					EventID:    "evt-2",
+					UserID:     1,
+					Type:       "logout",
+					SessionID:  "qwe-123",
+					IP:         "127.0.0.1",
+					UserAgent:  "curl/8.0",
+					RequestID:  "req-2",
+					OccurredAt: s.now,
+					CreatedAt:  s.now,
+				},
+				{
+					ID:         1,
+					EventID:    "evt-1",
+					UserID:     1,
+					Type:       "login",
+					SessionID:  "qwe-123",
+					IP:         "127.0.0.1",
+					UserAgent:  "curl/8.0",
+					RequestID:  "req-1",
+					OccurredAt: s.now,
+					CreatedAt:  s.now,
+				},
+			},
+			wantTotal: 2,
+			wantErr:   nil,
+		},
+		{
+			name: "error on count",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantEvents: nil,
+			wantTotal:  0,
+			wantErr:    errors.New("something error"),
+		},
+		{
+			name: "error on select",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(1, 10, 0).
+					WillReturnError(errors.New("something error"))
+			},
+			wantEvents: nil,
+			wantTotal:  0,
+			wantErr:    errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, total, err := s.repo.List(s.ctx, param)
+			s.Equal(tt.wantEvents, res)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *SecurityEventRepositorySuite) TestSecurityEventRepository_DeleteByUserID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM security_events WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM security_events WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByUserID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestSecurityEventRepositorySuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventRepositorySuite))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"go-api-example/internal/db"
 	"go-api-example/internal/entity"
 	"go-api-example/internal/model"
 	"strings"
@@ -22,9 +23,9 @@ func NewTodoRepository(db *sql.DB) *TodoRepository {
 
 func (r *TodoRepository) Create(ctx context.Context, todo *entity.Todo) error {
 	now := time.Now()
-	query := `INSERT INTO todos (user_id, title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO todos (user_id, assignee_id, title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
 
-	res, err := r.DB.ExecContext(ctx, query, todo.UserID, todo.Title, todo.Description, todo.Status, now, now)
+	res, err := r.DB.ExecContext(ctx, query, todo.UserID, todo.AssigneeID, todo.Title, todo.Description, todo.Status, now, now)
 	if err != nil {
 		return err
 	}
@@ -39,6 +40,9 @@ func (r *TodoRepository) Create(ctx context.Context, todo *entity.Todo) error {
 
 func (r *TodoRepository) List(ctx context.Context, req *model.SearchTodoRequest) ([]entity.Todo, int, error) {
 	conditions := []string{"user_id = ?"}
+	if req.AssignedToMe {
+		conditions = []string{"assignee_id = ?"}
+	}
 	args := []any{req.UserID}
 
 	if req.Status != nil {
@@ -60,7 +64,7 @@ func (r *TodoRepository) List(ctx context.Context, req *model.SearchTodoRequest)
 	}
 
 	var sb strings.Builder
-	sb.WriteString(`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos`)
+	sb.WriteString(`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos`)
 
 	if len(conditions) > 0 {
 		sb.WriteString(" WHERE ")
@@ -79,7 +83,7 @@ func (r *TodoRepository) List(ctx context.Context, req *model.SearchTodoRequest)
 	var todos []entity.Todo
 	for rows.Next() {
 		var t entity.Todo
-		err := rows.Scan(&t.ID, &t.UserID, &t.Title, &t.Description, &t.Status, &t.CreatedAt, &t.UpdatedAt)
+		err := rows.Scan(&t.ID, &t.UserID, &t.AssigneeID, &t.Title, &t.Description, &t.Status, &t.CreatedAt, &t.UpdatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -89,11 +93,68 @@ func (r *TodoRepository) List(ctx context.Context, req *model.SearchTodoRequest)
 	return todos, total, nil
 }
 
+func (r *TodoRepository) Board(ctx context.Context, req *model.BoardTodoRequest) ([]entity.Todo, map[entity.TodoStatus]int, error) {
+	condition := "user_id = ?"
+	if req.AssignedToMe {
+		condition = "assignee_id = ?"
+	}
+
+	countQuery := "SELECT status, COUNT(id) FROM todos WHERE " + condition + " GROUP BY status"
+
+	countRows, err := r.DB.QueryContext(ctx, countQuery, req.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer countRows.Close()
+
+	totals := make(map[entity.TodoStatus]int)
+	for countRows.Next() {
+		var status entity.TodoStatus
+		var total int
+		err := countRows.Scan(&status, &total)
+		if err != nil {
+			return nil, nil, err
+		}
+		totals[status] = total
+	}
+
+	if len(req.Columns) == 0 {
+		return nil, totals, nil
+	}
+
+	// one sub query per column so every column is paginated on its own index range
+	subQueries := make([]string, 0, len(req.Columns))
+	args := make([]any, 0, len(req.Columns)*4)
+	for _, column := range req.Columns {
+		subQueries = append(subQueries, "(SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE "+
+			condition+" AND status = ? ORDER BY id ASC LIMIT ? OFFSET ?)")
+		args = append(args, req.UserID, column.Status, column.Limit, column.Offset)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, strings.Join(subQueries, " UNION ALL "), args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var todos []entity.Todo
+	for rows.Next() {
+		var t entity.Todo
+		err := rows.Scan(&t.ID, &t.UserID, &t.AssigneeID, &t.Title, &t.Description, &t.Status, &t.CreatedAt, &t.UpdatedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+		todos = append(todos, t)
+	}
+
+	return todos, totals, nil
+}
+
 func (r *TodoRepository) FindByID(ctx context.Context, id uint64) (*entity.Todo, error) {
-	query := `SELECT id, user_id, title, description, status, created_at, updated_at FROM todos WHERE id = ? LIMIT 1`
+	query := `SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE id = ? LIMIT 1`
 
 	var t entity.Todo
-	err := r.DB.QueryRowContext(ctx, query, id).Scan(&t.ID, &t.UserID, &t.Title, &t.Description, &t.Status, &t.CreatedAt, &t.UpdatedAt)
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&t.ID, &t.UserID, &t.AssigneeID, &t.Title, &t.Description, &t.Status, &t.CreatedAt, &t.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -116,3 +177,63 @@ func (r *TodoRepository) UpdateByID(ctx context.Context, req *model.UpdateTodoRe
 
 	return nil
 }
+
+func (r *TodoRepository) UpdateStatusByID(ctx context.Context, id uint64, status entity.TodoStatus) error {
+	now := time.Now()
+	query := `UPDATE todos SET status = ?, updated_at = ? WHERE id = ?`
+
+	_, err := r.DB.ExecContext(ctx, query, status, now, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TodoRepository) UpdateAssigneeByID(ctx context.Context, todo *entity.Todo) error {
+	now := time.Now()
+	query := `UPDATE todos SET assignee_id = ?, updated_at = ? WHERE id = ?`
+
+	_, err := r.DB.ExecContext(ctx, query, todo.AssigneeID, now, todo.ID)
+	if err != nil {
+		return err
+	}
+
+	todo.UpdatedAt = now
+
+	return nil
+}
+
+func (r *TodoRepository) DeleteByID(ctx context.Context, id uint64) error {
+	query := `DELETE FROM todos WHERE id = ?`
+
+	_, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TodoRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	query := `DELETE FROM todos WHERE user_id = ?`
+
+	_, err := exec.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TodoRepository) UnassignByAssigneeID(ctx context.Context, exec db.Executor, assigneeID uint64) error {
+	now := time.Now()
+	query := `UPDATE todos SET assignee_id = NULL, updated_at = ? WHERE assignee_id = ?`
+
+	_, err := exec.ExecContext(ctx, query, now, assigneeID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -50,9 +50,9 @@ func (s *TodoRepositorySuite) TestTodoRepository_Create() {
 			name: "success",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectExec(regexp.QuoteMeta(
-					`INSERT INTO todos (user_id, title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+					`INSERT INTO todos (user_id, assignee_id, title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
 				)).
-					WithArgs(1, "dummy title", "dummy description", 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(1, nil, "dummy title", "dummy description", 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			param: &entity.Todo{
@@ -67,9 +67,9 @@ func (s *TodoRepositorySuite) TestTodoRepository_Create() {
 			name: "unexpected error",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectExec(regexp.QuoteMeta(
-					`INSERT INTO todos (user_id, title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+					`INSERT INTO todos (user_id, assignee_id, title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
 				)).
-					WithArgs(1, "dummy title", "dummy description", 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs(1, nil, "dummy title", "dummy description", 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &entity.Todo{
@@ -94,6 +94,7 @@ func (s *TodoRepositorySuite) TestTodoRepository_Create() {
 
 func (s *TodoRepositorySuite) TestTodoRepository_List() {
 	description := "dummy description"
+	assigneeID := uint64(2)
 	status := entity.TodoStatusCompleted
 
 	tests := []struct {
@@ -113,11 +114,11 @@ func (s *TodoRepositorySuite) TestTodoRepository_List() {
 					WithArgs(1).
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-				rows := sqlmock.NewRows([]string{"id", "user_id", "title", "description", "status", "created_at", "updated_at"}).
-					AddRow(1, 1, "dummy title 1", description, 1, s.now, s.now).
-					AddRow(2, 1, "dummy title 2", description, 2, s.now, s.now)
+				rows := sqlmock.NewRows([]string{"id", "user_id", "assignee_id", "title", "description", "status", "created_at", "updated_at"}).
+					AddRow(1, 1, nil, "dummy title 1", description, 1, s.now, s.now).
+					AddRow(2, 1, nil, "dummy title 2", description, 2, s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos
+					`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos
 					WHERE user_id = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(1, 10, 0).
@@ -160,11 +161,11 @@ func (s *TodoRepositorySuite) TestTodoRepository_List() {
 					WithArgs(1, 3).
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-				rows := sqlmock.NewRows([]string{"id", "user_id", "title", "description", "status", "created_at", "updated_at"}).
-					AddRow(1, 1, "dummy title 1", description, 3, s.now, s.now).
-					AddRow(2, 1, "dummy title 2", description, 3, s.now, s.now)
+				rows := sqlmock.NewRows([]string{"id", "user_id", "assignee_id", "title", "description", "status", "created_at", "updated_at"}).
+					AddRow(1, 1, nil, "dummy title 1", description, 3, s.now, s.now).
+					AddRow(2, 1, nil, "dummy title 2", description, 3, s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos
+					`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos
 					WHERE user_id = ? AND status = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(1, 3, 10, 0).
@@ -199,6 +200,45 @@ func (s *TodoRepositorySuite) TestTodoRepository_List() {
 			wantTotal: 2,
 			wantErr:   nil,
 		},
+		{
+			name: "success with assigned to me param",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM todos WHERE assignee_id = ?`,
+				)).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				rows := sqlmock.NewRows([]string{"id", "user_id", "assignee_id", "title", "description", "status", "created_at", "updated_at"}).
+					AddRow(1, 1, 2, "dummy title 1", description, 1, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos
+					WHERE assignee_id = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
+				)).
+					WithArgs(2, 10, 0).
+					WillReturnRows(rows)
+			},
+			param: &model.SearchTodoRequest{
+				UserID:       2,
+				AssignedToMe: true,
+				Limit:        10,
+				Offset:       0,
+			},
+			wantTodos: []entity.Todo{
+				{
+					ID:          1,
+					UserID:      1,
+					AssigneeID:  &assigneeID,
+					Title:       "dummy title 1",
+					Description: &description,
+					Status:      entity.TodoStatusPending,
+					CreatedAt:   s.now,
+					UpdatedAt:   s.now,
+				},
+			},
+			wantTotal: 1,
+			wantErr:   nil,
+		},
 		{
 			name: "not found",
 			mockFunc: func(m sqlmock.Sqlmock) {
@@ -208,9 +248,9 @@ func (s *TodoRepositorySuite) TestTodoRepository_List() {
 					WithArgs(1).
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-				rows := sqlmock.NewRows([]string{"id", "user_id", "title", "description", "status", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{"id", "user_id", "assignee_id", "title", "description", "status", "created_at", "updated_at"})
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos
+					`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos
 					WHERE user_id = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(1, 10, 0).
@@ -253,7 +293,7 @@ func (s *TodoRepositorySuite) TestTodoRepository_List() {
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos
+					`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos
 					WHERE user_id = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(1, 10, 0).
@@ -282,6 +322,119 @@ func (s *TodoRepositorySuite) TestTodoRepository_List() {
 	}
 }
 
+func (s *TodoRepositorySuite) TestTodoRepository_Board() {
+	columns := []string{"id", "user_id", "assignee_id", "title", "description", "status", "created_at", "updated_at"}
+	boardQuery := `(SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE user_id = ? AND status = ? ORDER BY id ASC LIMIT ? OFFSET ?)` +
+		` UNION ALL ` +
+		`(SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE user_id = ? AND status = ? ORDER BY id ASC LIMIT ? OFFSET ?)`
+	request := &model.BoardTodoRequest{
+		UserID: 1,
+		Columns: []model.BoardTodoColumnRequest{
+			{Status: entity.TodoStatusPending, Limit: 10, Offset: 0},
+			{Status: entity.TodoStatusCompleted, Limit: 5, Offset: 5},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(sqlmock.Sqlmock)
+		param      *model.BoardTodoRequest
+		wantTodos  []entity.Todo
+		wantTotals map[entity.TodoStatus]int
+		wantErr    error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT status, COUNT(id) FROM todos WHERE user_id = ? GROUP BY status`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow(1, 1).AddRow(3, 7))
+
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, nil, "dummy title 1", nil, 1, s.now, s.now).
+					AddRow(9, 1, nil, "dummy title 9", nil, 3, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(boardQuery)).
+					WithArgs(1, 1, 10, 0, 1, 3, 5, 5).
+					WillReturnRows(rows)
+			},
+			param: request,
+			wantTodos: []entity.Todo{
+				{ID: 1, UserID: 1, Title: "dummy title 1", Status: entity.TodoStatusPending, CreatedAt: s.now, UpdatedAt: s.now},
+				{ID: 9, UserID: 1, Title: "dummy title 9", Status: entity.TodoStatusCompleted, CreatedAt: s.now, UpdatedAt: s.now},
+			},
+			wantTotals: map[entity.TodoStatus]int{
+				entity.TodoStatusPending:   1,
+				entity.TodoStatusCompleted: 7,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success with assigned to me and no columns",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT status, COUNT(id) FROM todos WHERE assignee_id = ? GROUP BY status`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow(2, 4))
+			},
+			param: &model.BoardTodoRequest{
+				UserID:       1,
+				AssignedToMe: true,
+			},
+			wantTodos: nil,
+			wantTotals: map[entity.TodoStatus]int{
+				entity.TodoStatusInProgress: 4,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error on count",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT status, COUNT(id) FROM todos WHERE user_id = ? GROUP BY status`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			param:      request,
+			wantTodos:  nil,
+			wantTotals: nil,
+			wantErr:    errors.New("something error"),
+		},
+		{
+			name: "error on select",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT status, COUNT(id) FROM todos WHERE user_id = ? GROUP BY status`,
+				)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow(1, 1))
+
+				m.ExpectQuery(regexp.QuoteMeta(boardQuery)).
+					WithArgs(1, 1, 10, 0, 1, 3, 5, 5).
+					WillReturnError(errors.New("something error"))
+			},
+			param:      request,
+			wantTodos:  nil,
+			wantTotals: nil,
+			wantErr:    errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			todos, totals, err := s.repo.Board(s.ctx, tt.param)
+			s.Equal(tt.wantTodos, todos)
+			s.Equal(tt.wantTotals, totals)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
 func (s *TodoRepositorySuite) TestTodoRepository_FindByID() {
 	description := "dummy description"
 
@@ -295,9 +448,9 @@ func (s *TodoRepositorySuite) TestTodoRepository_FindByID() {
 		{
 			name: "success",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "title", "description", "status", "created_at", "updated_at"}).
-					AddRow(1, 1, "dummy title", description, 1, s.now, s.now)
-				m.ExpectQuery(`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos WHERE id = \? LIMIT 1`).
+				rows := sqlmock.NewRows([]string{"id", "user_id", "assignee_id", "title", "description", "status", "created_at", "updated_at"}).
+					AddRow(1, 1, nil, "dummy title", description, 1, s.now, s.now)
+				m.ExpectQuery(`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -316,7 +469,7 @@ func (s *TodoRepositorySuite) TestTodoRepository_FindByID() {
 		{
 			name: "not found",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos WHERE id = \? LIMIT 1`).
+				m.ExpectQuery(`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -327,7 +480,7 @@ func (s *TodoRepositorySuite) TestTodoRepository_FindByID() {
 		{
 			name: "unexpected error",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT id, user_id, title, description, status, created_at, updated_at FROM todos WHERE id = \? LIMIT 1`).
+				m.ExpectQuery(`SELECT id, user_id, assignee_id, title, description, status, created_at, updated_at FROM todos WHERE id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnError(errors.New("something error"))
 			},
@@ -401,6 +554,215 @@ func (s *TodoRepositorySuite) TestTodoRepository_UpdateByID() {
 	}
 }
 
+func (s *TodoRepositorySuite) TestTodoRepository_UpdateStatusByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		paramID  uint64
+		status   entity.TodoStatus
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE todos SET status = \?, updated_at = \? WHERE id = \?`).
+					WithArgs(3, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			paramID: 1,
+			status:  entity.TodoStatusCompleted,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE todos SET status = \?, updated_at = \? WHERE id = \?`).
+					WithArgs(3, sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			paramID: 1,
+			status:  entity.TodoStatusCompleted,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateStatusByID(s.ctx, tt.paramID, tt.status)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TodoRepositorySuite) TestTodoRepository_UpdateAssigneeByID() {
+	assigneeID := uint64(2)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		param    *entity.Todo
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE todos SET assignee_id = \?, updated_at = \? WHERE id = \?`).
+					WithArgs(2, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			param: &entity.Todo{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`UPDATE todos SET assignee_id = \?, updated_at = \? WHERE id = \?`).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &entity.Todo{
+				ID:     1,
+				UserID: 1,
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateAssigneeByID(s.ctx, tt.param)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TodoRepositorySuite) TestTodoRepository_DeleteByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		paramID  uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`DELETE FROM todos WHERE id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			paramID: 1,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(`DELETE FROM todos WHERE id = \?`).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			paramID: 1,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByID(s.ctx, tt.paramID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TodoRepositorySuite) TestTodoRepository_DeleteByUserID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM todos WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM todos WHERE user_id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByUserID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TodoRepositorySuite) TestTodoRepository_UnassignByAssigneeID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE todos SET assignee_id = NULL, updated_at = ? WHERE assignee_id = ?`,
+				)).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE todos SET assignee_id = NULL, updated_at = ? WHERE assignee_id = ?`,
+				)).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UnassignByAssigneeID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
 func TestTodoRepositorySuite(t *testing.T) {
 	suite.Run(t, new(TodoRepositorySuite))
 }
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"strings"
+	"time"
+)
+
+type TOTPRepository struct {
+	DB *sql.DB
+}
+
+func NewTOTPRepository(db *sql.DB) *TOTPRepository {
+	return &TOTPRepository{
+		DB: db,
+	}
+}
+
+func (r *TOTPRepository) FindByUserID(ctx context.Context, userID uint64) (*entity.TOTP, error) {
+	query := `SELECT user_id, secret, confirmed_at, created_at, updated_at FROM user_totps WHERE user_id = ? LIMIT 1`
+
+	var t entity.TOTP
+	err := r.DB.QueryRowContext(ctx, query, userID).Scan(&t.UserID, &t.Secret, &t.ConfirmedAt, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+// Upsert stores a pending secret, enrolling again before confirmation replaces the previous one
+func (r *TOTPRepository) Upsert(ctx context.Context, totp *entity.TOTP) error {
+	now := time.Now()
+	query := `INSERT INTO user_totps (user_id, secret, confirmed_at, created_at, updated_at) VALUES (?, ?, NULL, ?, ?) ` +
+		`ON DUPLICATE KEY UPDATE secret = VALUES(secret), confirmed_at = NULL, updated_at = VALUES(updated_at)`
+
+	_, err := r.DB.ExecContext(ctx, query, totp.UserID, totp.Secret, now, now)
+	if err != nil {
+		return err
+	}
+
+	totp.ConfirmedAt = nil
+	totp.CreatedAt = now
+	totp.UpdatedAt = now
+
+	return nil
+}
+
+func (r *TOTPRepository) ConfirmByUserID(ctx context.Context, exec db.Executor, userID uint64, confirmedAt time.Time) error {
+	query := `UPDATE user_totps SET confirmed_at = ?, updated_at = ? WHERE user_id = ? AND confirmed_at IS NULL`
+
+	_, err := exec.ExecContext(ctx, query, confirmedAt, confirmedAt, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes drops every recovery code of the user and stores the given hashes instead
+func (r *TOTPRepository) ReplaceRecoveryCodes(ctx context.Context, exec db.Executor, userID uint64, codeHashes []string) error {
+	_, err := exec.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = ?`, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	placeholders := make([]string, 0, len(codeHashes))
+	args := make([]any, 0, len(codeHashes)*3)
+	for _, codeHash := range codeHashes {
+		placeholders = append(placeholders, "(?, ?, ?)")
+		args = append(args, userID, codeHash, now)
+	}
+
+	query := `INSERT INTO user_recovery_codes (user_id, code_hash, created_at) VALUES ` + strings.Join(placeholders, ", ")
+	_, err = exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused code as used and reports whether there was one, a code can't be used twice
+func (r *TOTPRepository) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	query := `UPDATE user_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`
+
+	res, err := r.DB.ExecContext(ctx, query, time.Now(), userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *TOTPRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	_, err := exec.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = ?`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec.ExecContext(ctx, `DELETE FROM user_totps WHERE user_id = ?`, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
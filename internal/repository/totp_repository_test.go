@@ -0,0 +1,357 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TOTPRepositorySuite struct {
+	suite.Suite
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	repo *repository.TOTPRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *TOTPRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewTOTPRepository(s.db)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *TOTPRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *TOTPRepositorySuite) TestTOTPRepository_FindByUserID() {
+	query := regexp.QuoteMeta(`SELECT user_id, secret, confirmed_at, created_at, updated_at FROM user_totps WHERE user_id = ? LIMIT 1`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantTOTP *entity.TOTP
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"user_id", "secret", "confirmed_at", "created_at", "updated_at"}).
+					AddRow(1, "dummy-secret", s.now, s.now, s.now)
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			wantTOTP: &entity.TOTP{
+				UserID:      1,
+				Secret:      "dummy-secret",
+				ConfirmedAt: &s.now,
+				CreatedAt:   s.now,
+				UpdatedAt:   s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantTOTP: nil,
+			wantErr:  nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantTOTP: nil,
+			wantErr:  errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByUserID(s.ctx, 1)
+			s.Equal(tt.wantTOTP, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TOTPRepositorySuite) TestTOTPRepository_Upsert() {
+	query := regexp.QuoteMeta(`INSERT INTO user_totps (user_id, secret, confirmed_at, created_at, updated_at) VALUES (?, ?, NULL, ?, ?) ` +
+		`ON DUPLICATE KEY UPDATE secret = VALUES(secret), confirmed_at = NULL, updated_at = VALUES(updated_at)`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "dummy-secret", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "dummy-secret", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			totp := &entity.TOTP{UserID: 1, Secret: "dummy-secret", ConfirmedAt: &s.now}
+			err := s.repo.Upsert(s.ctx, totp)
+			s.Equal(tt.wantErr, err)
+			if tt.wantErr == nil {
+				s.Nil(totp.ConfirmedAt)
+				s.False(totp.CreatedAt.IsZero())
+			}
+		})
+	}
+}
+
+func (s *TOTPRepositorySuite) TestTOTPRepository_ConfirmByUserID() {
+	query := regexp.QuoteMeta(`UPDATE user_totps SET confirmed_at = ?, updated_at = ? WHERE user_id = ? AND confirmed_at IS NULL`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(s.now, s.now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(s.now, s.now, 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.ConfirmByUserID(s.ctx, s.db, 1, s.now)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TOTPRepositorySuite) TestTOTPRepository_ReplaceRecoveryCodes() {
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM user_recovery_codes WHERE user_id = ?`)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO user_recovery_codes (user_id, code_hash, created_at) VALUES (?, ?, ?), (?, ?, ?)`)
+
+	tests := []struct {
+		name       string
+		codeHashes []string
+		mockFunc   func(sqlmock.Sqlmock)
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			codeHashes: []string{"hash-1", "hash-2"},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(insertQuery).
+					WithArgs(1, "hash-1", sqlmock.AnyArg(), 1, "hash-2", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "success without codes",
+			codeHashes: nil,
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "error on delete",
+			codeHashes: []string{"hash-1", "hash-2"},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name:       "error on insert",
+			codeHashes: []string{"hash-1", "hash-2"},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(deleteQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec(insertQuery).
+					WithArgs(1, "hash-1", sqlmock.AnyArg(), 1, "hash-2", sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.ReplaceRecoveryCodes(s.ctx, s.db, 1, tt.codeHashes)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TOTPRepositorySuite) TestTOTPRepository_UseRecoveryCode() {
+	query := regexp.QuoteMeta(`UPDATE user_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(sqlmock.AnyArg(), 1, "hash-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+		{
+			name: "unknown or used code",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(sqlmock.AnyArg(), 1, "hash-1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(sqlmock.AnyArg(), 1, "hash-1").
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.UseRecoveryCode(s.ctx, 1, "hash-1")
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *TOTPRepositorySuite) TestTOTPRepository_DeleteByUserID() {
+	codesQuery := regexp.QuoteMeta(`DELETE FROM user_recovery_codes WHERE user_id = ?`)
+	totpQuery := regexp.QuoteMeta(`DELETE FROM user_totps WHERE user_id = ?`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(codesQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				m.ExpectExec(totpQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error on delete recovery codes",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(codesQuery).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "error on delete totp",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(codesQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				m.ExpectExec(totpQuery).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByUserID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestTOTPRepositorySuite(t *testing.T) {
+	suite.Run(t, new(TOTPRepositorySuite))
+}
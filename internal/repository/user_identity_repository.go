@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type UserIdentityRepository struct {
+	DB *sql.DB
+}
+
+func NewUserIdentityRepository(db *sql.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		DB: db,
+	}
+}
+
+func (r *UserIdentityRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (*entity.UserIdentity, error) {
+	query := `SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE provider = ? AND subject = ? LIMIT 1`
+
+	var i entity.UserIdentity
+	err := r.DB.QueryRowContext(ctx, query, provider, subject).Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &i, nil
+}
+
+func (r *UserIdentityRepository) ListByUserID(ctx context.Context, userID uint64) ([]entity.UserIdentity, error) {
+	query := `SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE user_id = ? ORDER BY provider ASC`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []entity.UserIdentity
+	for rows.Next() {
+		var i entity.UserIdentity
+		err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, i)
+	}
+
+	return identities, nil
+}
+
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	now := time.Now()
+	query := `INSERT INTO user_identities (user_id, provider, subject, email, created_at) VALUES (?, ?, ?, ?, ?)`
+
+	res, err := r.DB.ExecContext(ctx, query, identity.UserID, identity.Provider, identity.Subject, identity.Email, now)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return model.ErrIdentityAlreadyLinked
+		}
+		return err
+	}
+
+	id, _ := res.LastInsertId()
+	identity.ID = uint64(id)
+	identity.CreatedAt = now
+
+	return nil
+}
+
+func (r *UserIdentityRepository) DeleteByUserIDProvider(ctx context.Context, userID uint64, provider string) (bool, error) {
+	query := `DELETE FROM user_identities WHERE user_id = ? AND provider = ?`
+
+	res, err := r.DB.ExecContext(ctx, query, userID, provider)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *UserIdentityRepository) DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error {
+	query := `DELETE FROM user_identities WHERE user_id = ?`
+
+	_, err := exec.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
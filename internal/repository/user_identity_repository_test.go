@@ -0,0 +1,302 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserIdentityRepositorySuite struct {
+	suite.Suite
+	db    *sql.DB
+	mock  sqlmock.Sqlmock
+	repo  *repository.UserIdentityRepository
+	ctx   context.Context
+	now   time.Time
+	email string
+}
+
+func (s *UserIdentityRepositorySuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	s.db = db
+	s.mock = mock
+	s.repo = repository.NewUserIdentityRepository(s.db)
+	s.ctx = context.Background()
+	s.now = time.Now()
+	s.email = "user@example.com"
+}
+
+func (s *UserIdentityRepositorySuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_FindByProviderSubject() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE provider = ? AND subject = ? LIMIT 1`)
+
+	tests := []struct {
+		name         string
+		mockFunc     func(sqlmock.Sqlmock)
+		wantIdentity *entity.UserIdentity
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email", "created_at"}).
+					AddRow(1, 1, "google", "subject-1", s.email, s.now)
+				m.ExpectQuery(query).
+					WithArgs("google", "subject-1").
+					WillReturnRows(rows)
+			},
+			wantIdentity: &entity.UserIdentity{
+				ID:        1,
+				UserID:    1,
+				Provider:  "google",
+				Subject:   "subject-1",
+				Email:     &s.email,
+				CreatedAt: s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs("google", "subject-1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantIdentity: nil,
+			wantErr:      nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs("google", "subject-1").
+					WillReturnError(errors.New("something error"))
+			},
+			wantIdentity: nil,
+			wantErr:      errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByProviderSubject(s.ctx, "google", "subject-1")
+			s.Equal(tt.wantIdentity, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_ListByUserID() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE user_id = ? ORDER BY provider ASC`)
+
+	tests := []struct {
+		name           string
+		mockFunc       func(sqlmock.Sqlmock)
+		wantIdentities []entity.UserIdentity
+		wantErr        error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email", "created_at"}).
+					AddRow(2, 1, "github", "subject-2", nil, s.now).
+					AddRow(1, 1, "google", "subject-1", s.email, s.now)
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			wantIdentities: []entity.UserIdentity{
+				{ID: 2, UserID: 1, Provider: "github", Subject: "subject-2", CreatedAt: s.now},
+				{ID: 1, UserID: 1, Provider: "google", Subject: "subject-1", Email: &s.email, CreatedAt: s.now},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantIdentities: nil,
+			wantErr:        errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByUserID(s.ctx, 1)
+			s.Equal(tt.wantIdentities, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_Create() {
+	query := regexp.QuoteMeta(`INSERT INTO user_identities (user_id, provider, subject, email, created_at) VALUES (?, ?, ?, ?, ?)`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "google", "subject-1", s.email, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			wantID:  3,
+			wantErr: nil,
+		},
+		{
+			name: "error already linked",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "google", "subject-1", s.email, sqlmock.AnyArg()).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+			wantID:  0,
+			wantErr: model.ErrIdentityAlreadyLinked,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "google", "subject-1", s.email, sqlmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			identity := &entity.UserIdentity{
+				UserID:   1,
+				Provider: "google",
+				Subject:  "subject-1",
+				Email:    &s.email,
+			}
+			err := s.repo.Create(s.ctx, identity)
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantID, identity.ID)
+		})
+	}
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_DeleteByUserIDProvider() {
+	query := regexp.QuoteMeta(`DELETE FROM user_identities WHERE user_id = ? AND provider = ?`)
+
+	tests := []struct {
+		name        string
+		mockFunc    func(sqlmock.Sqlmock)
+		wantDeleted bool
+		wantErr     error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "google").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantDeleted: true,
+			wantErr:     nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "google").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantDeleted: false,
+			wantErr:     nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1, "google").
+					WillReturnError(errors.New("something error"))
+			},
+			wantDeleted: false,
+			wantErr:     errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			deleted, err := s.repo.DeleteByUserIDProvider(s.ctx, 1, "google")
+			s.Equal(tt.wantDeleted, deleted)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_DeleteByUserID() {
+	query := regexp.QuoteMeta(`DELETE FROM user_identities WHERE user_id = ?`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(query).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.DeleteByUserID(s.ctx, s.db, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestUserIdentityRepositorySuite(t *testing.T) {
+	suite.Run(t, new(UserIdentityRepositorySuite))
+}
@@ -9,8 +9,20 @@ import (
 	"go-api-example/internal/model"
 	"strings"
 	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
+const mysqlErrDuplicateEntry = 1062
+
+const userColumns = `id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at,
+	password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at`
+
+// discoverableCondition keeps the users the viewer may find: the viewer, public users and contacts-only users that
+// share a todo with the viewer
+const discoverableCondition = `(id = ? OR discoverability = ? OR (discoverability = ? AND EXISTS (
+	SELECT 1 FROM todos WHERE (todos.user_id = ? AND todos.assignee_id = users.id) OR (todos.assignee_id = ? AND todos.user_id = users.id))))`
+
 type UserRepository struct {
 	DB *sql.DB
 }
@@ -23,10 +35,14 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, exec db.Executor, user *entity.User) error {
 	now := time.Now()
-	query := `INSERT INTO users (username, password, created_at, updated_at) VALUES (?, ?, ?, ?)`
+	query := `INSERT INTO users (username, password, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`
 
-	res, err := exec.ExecContext(ctx, query, user.Username, user.Password, now, now)
+	res, err := exec.ExecContext(ctx, query, user.Username, user.Password, user.Role, now, now)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return model.ErrUsernameAlreadyExist
+		}
 		return err
 	}
 
@@ -50,6 +66,30 @@ func (r *UserRepository) List(ctx context.Context, req *model.SearchUserRequest)
 		conditions = append(conditions, "username = ?")
 		args = append(args, *req.Username)
 	}
+	if req.Query != nil {
+		conditions = append(conditions, "username LIKE ?")
+		args = append(args, escapeLike(*req.Query)+"%")
+	}
+	if req.Email != nil {
+		conditions = append(conditions, "email = ?")
+		args = append(args, *req.Email)
+	}
+	if req.Role != nil {
+		conditions = append(conditions, "role = ?")
+		args = append(args, *req.Role)
+	}
+	if req.Status != nil {
+		if *req.Status == model.UserStatusSuspended {
+			conditions = append(conditions, "suspended_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "suspended_at IS NULL")
+		}
+	}
+	if !req.Unrestricted {
+		conditions = append(conditions, "suspended_at IS NULL", discoverableCondition)
+		args = append(args, req.ViewerID, entity.UserDiscoverabilityPublic, entity.UserDiscoverabilityContacts,
+			req.ViewerID, req.ViewerID)
+	}
 
 	var countSb strings.Builder
 	countSb.WriteString("SELECT COUNT(id) FROM users")
@@ -65,7 +105,7 @@ func (r *UserRepository) List(ctx context.Context, req *model.SearchUserRequest)
 	}
 
 	var sb strings.Builder
-	sb.WriteString(`SELECT id, username, password, created_at, updated_at FROM users`)
+	sb.WriteString("SELECT " + userColumns + " FROM users")
 
 	if len(conditions) > 0 {
 		sb.WriteString(" WHERE ")
@@ -84,7 +124,7 @@ func (r *UserRepository) List(ctx context.Context, req *model.SearchUserRequest)
 	var users []entity.User
 	for rows.Next() {
 		var u entity.User
-		err := rows.Scan(&u.ID, &u.Username, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+		err := scanUser(rows, &u)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -95,10 +135,30 @@ func (r *UserRepository) List(ctx context.Context, req *model.SearchUserRequest)
 }
 
 func (r *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User, error) {
-	query := `SELECT id, username, password, created_at, updated_at FROM users WHERE id = ? LIMIT 1`
+	query := "SELECT " + userColumns + " FROM users WHERE id = ? LIMIT 1"
 
 	var u entity.User
-	err := r.DB.QueryRowContext(ctx, query, id).Scan(&u.ID, &u.Username, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	err := scanUser(r.DB.QueryRowContext(ctx, query, id), &u)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &u, nil
+}
+
+// FindDiscoverableByID finds the user only when the viewer may find it in the user search, nil is returned for a
+// hidden user the same way as for a missing one
+func (r *UserRepository) FindDiscoverableByID(ctx context.Context, viewerID uint64, id uint64) (*entity.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE id = ? AND suspended_at IS NULL AND " +
+		discoverableCondition + " LIMIT 1"
+
+	var u entity.User
+	err := scanUser(r.DB.QueryRowContext(ctx, query, id, viewerID, entity.UserDiscoverabilityPublic,
+		entity.UserDiscoverabilityContacts, viewerID, viewerID), &u)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -111,10 +171,26 @@ func (r *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User,
 }
 
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
-	query := `SELECT id, username, password, created_at, updated_at FROM users WHERE username = ? LIMIT 1`
+	query := "SELECT " + userColumns + " FROM users WHERE username = ? LIMIT 1"
 
 	var u entity.User
-	err := r.DB.QueryRowContext(ctx, query, username).Scan(&u.ID, &u.Username, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	err := scanUser(r.DB.QueryRowContext(ctx, query, username), &u)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &u, nil
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE email = ? LIMIT 1"
+
+	var u entity.User
+	err := scanUser(r.DB.QueryRowContext(ctx, query, email), &u)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -138,14 +214,143 @@ func (r *UserRepository) UpdateByID(ctx context.Context, req *model.UpdateUserRe
 	return nil
 }
 
-func (r *UserRepository) CountByUsername(ctx context.Context, username string) (int, error) {
-	query := `SELECT COUNT(id) FROM users WHERE username = ?`
+// ChangePasswordByID also bumps the token version, so every access token issued before the change is rejected, and
+// clears a password reset an admin required. The bumped token version is returned
+func (r *UserRepository) ChangePasswordByID(ctx context.Context, exec db.Executor, id uint64, password string) (uint64, error) {
+	now := time.Now()
+	query := `UPDATE users SET password = ?, token_version = token_version + 1, password_reset_required_at = NULL,
+	updated_at = ? WHERE id = ?`
 
-	var count int
-	err := r.DB.QueryRowContext(ctx, query, username).Scan(&count)
+	_, err := exec.ExecContext(ctx, query, password, now, id)
 	if err != nil {
 		return 0, err
 	}
 
-	return count, nil
+	return findTokenVersion(ctx, exec, id)
+}
+
+// UpdateProfile writes the username and the profile fields of the user
+func (r *UserRepository) UpdateProfile(ctx context.Context, exec db.Executor, user *entity.User) error {
+	now := time.Now()
+	query := `UPDATE users SET username = ?, display_name = ?, bio = ?, timezone = ?, locale = ?, discoverability = ?,
+	updated_at = ? WHERE id = ?`
+
+	_, err := exec.ExecContext(ctx, query, user.Username, user.DisplayName, user.Bio, user.Timezone, user.Locale,
+		user.Discoverability, now, user.ID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return model.ErrUsernameAlreadyExist
+		}
+		return err
+	}
+
+	user.UpdatedAt = now
+
+	return nil
+}
+
+func (r *UserRepository) UpdateAvatarByID(ctx context.Context, exec db.Executor, id uint64, avatarKey *string) error {
+	now := time.Now()
+	query := `UPDATE users SET avatar_key = ?, updated_at = ? WHERE id = ?`
+
+	_, err := exec.ExecContext(ctx, query, avatarKey, now, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateEmailByID(ctx context.Context, id uint64, email string, verifiedAt time.Time) error {
+	now := time.Now()
+	query := `UPDATE users SET email = ?, email_verified_at = ?, updated_at = ? WHERE id = ?`
+
+	_, err := r.DB.ExecContext(ctx, query, email, verifiedAt, now, id)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return model.ErrEmailAlreadyExist
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SuspendByID also bumps the token version, so every access token of the user is rejected right away. The bumped
+// token version is returned
+func (r *UserRepository) SuspendByID(ctx context.Context, exec db.Executor, id uint64, suspendedAt time.Time) (uint64, error) {
+	query := `UPDATE users SET suspended_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`
+
+	_, err := exec.ExecContext(ctx, query, suspendedAt, suspendedAt, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return findTokenVersion(ctx, exec, id)
+}
+
+func (r *UserRepository) UnsuspendByID(ctx context.Context, exec db.Executor, id uint64) error {
+	now := time.Now()
+	query := `UPDATE users SET suspended_at = NULL, updated_at = ? WHERE id = ?`
+
+	_, err := exec.ExecContext(ctx, query, now, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RequirePasswordResetByID also bumps the token version, the user has to reset the password before logging in with
+// one. The bumped token version is returned
+func (r *UserRepository) RequirePasswordResetByID(ctx context.Context, exec db.Executor, id uint64,
+	requiredAt time.Time) (uint64, error) {
+	query := `UPDATE users SET password_reset_required_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`
+
+	_, err := exec.ExecContext(ctx, query, requiredAt, requiredAt, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return findTokenVersion(ctx, exec, id)
+}
+
+// findTokenVersion reads the token version inside the transaction that bumped it, the row is still locked so no other
+// bump can come in between
+func findTokenVersion(ctx context.Context, exec db.Executor, id uint64) (uint64, error) {
+	query := `SELECT token_version FROM users WHERE id = ?`
+
+	var version uint64
+	err := exec.QueryRowContext(ctx, query, id).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (r *UserRepository) DeleteByID(ctx context.Context, exec db.Executor, id uint64) error {
+	query := `DELETE FROM users WHERE id = ?`
+
+	_, err := exec.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanUser(row rowScanner, u *entity.User) error {
+	return row.Scan(&u.ID, &u.Username, &u.DisplayName, &u.Bio, &u.Timezone, &u.Locale, &u.Discoverability, &u.AvatarKey,
+		&u.Email, &u.EmailVerifiedAt, &u.Password, &u.Role, &u.TokenVersion, &u.SuspendedAt, &u.PasswordResetRequiredAt,
+		&u.CreatedAt, &u.UpdatedAt)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike makes the wildcards of a LIKE pattern match themselves
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -51,29 +52,47 @@ func (s *UserRepositorySuite) TestUserRepository_Create() {
 			name: "success",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectExec(regexp.QuoteMeta(
-					`INSERT INTO users (username, password, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+					`INSERT INTO users (username, password, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
 				)).
-					WithArgs("johndoe", "password", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs("johndoe", "password", "user", sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(1, 0))
 			},
 			param: &entity.User{
 				Username: "johndoe",
 				Password: "password",
+				Role:     "user",
 			},
 			wantErr: nil,
 		},
+		{
+			name: "duplicate username",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`INSERT INTO users (username, password, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+				)).
+					WithArgs("johndoe", "password", "user", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+			param: &entity.User{
+				Username: "johndoe",
+				Password: "password",
+				Role:     "user",
+			},
+			wantErr: model.ErrUsernameAlreadyExist,
+		},
 		{
 			name: "unexpected error",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectExec(regexp.QuoteMeta(
-					`INSERT INTO users (username, password, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+					`INSERT INTO users (username, password, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
 				)).
-					WithArgs("johndoe", "password", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs("johndoe", "password", "user", sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &entity.User{
 				Username: "johndoe",
 				Password: "password",
+				Role:     "user",
 			},
 			wantErr: errors.New("something error"),
 		},
@@ -92,6 +111,10 @@ func (s *UserRepositorySuite) TestUserRepository_Create() {
 func (s *UserRepositorySuite) TestUserRepository_List() {
 	id := uint64(1)
 	username := "johndoe"
+	query := "jo_"
+	email := "johndoe@example.com"
+	role := "user"
+	status := model.UserStatusSuspended
 
 	tests := []struct {
 		name      string
@@ -108,34 +131,39 @@ func (s *UserRepositorySuite) TestUserRepository_List() {
 					`SELECT COUNT(id) FROM users`,
 				)).WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-				rows := sqlmock.NewRows([]string{"id", "username", "password", "created_at", "updated_at"}).
-					AddRow(1, "johndoe", "password", s.now, s.now).
-					AddRow(2, "chyntia", "password", s.now, s.now)
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "johndoe", nil, nil, "", "", "public", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now).
+					AddRow(2, "chyntia", nil, nil, "", "", "public", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
 					ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.SearchUserRequest{
-				Limit:  10,
-				Offset: 0,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
 			},
 			wantUsers: []entity.User{
 				{
-					ID:        1,
-					Username:  "johndoe",
-					Password:  "password",
-					CreatedAt: s.now,
-					UpdatedAt: s.now,
+					ID:              1,
+					Username:        "johndoe",
+					Discoverability: "public",
+					Password:        "password",
+					Role:            "user",
+					CreatedAt:       s.now,
+					UpdatedAt:       s.now,
 				},
 				{
-					ID:        2,
-					Username:  "chyntia",
-					Password:  "password",
-					CreatedAt: s.now,
-					UpdatedAt: s.now,
+					ID:              2,
+					Username:        "chyntia",
+					Discoverability: "public",
+					Password:        "password",
+					Role:            "user",
+					CreatedAt:       s.now,
+					UpdatedAt:       s.now,
 				},
 			},
 			wantTotal: 2,
@@ -150,28 +178,115 @@ func (s *UserRepositorySuite) TestUserRepository_List() {
 					WithArgs(1, "johndoe").
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
-				rows := sqlmock.NewRows([]string{"id", "username", "password", "created_at", "updated_at"}).
-					AddRow(1, "johndoe", "password", s.now, s.now)
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "johndoe", nil, nil, "", "", "public", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
 					 WHERE id = ? AND username = ? ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(1, "johndoe", 10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.SearchUserRequest{
-				ID:       &id,
-				Username: &username,
+				ID:           &id,
+				Username:     &username,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
+			},
+			wantUsers: []entity.User{
+				{
+					ID:              1,
+					Username:        "johndoe",
+					Discoverability: "public",
+					Password:        "password",
+					Role:            "user",
+					CreatedAt:       s.now,
+					UpdatedAt:       s.now,
+				},
+			},
+			wantTotal: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "success with prefix query for viewer",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM users WHERE username LIKE ? AND suspended_at IS NULL AND (id = ? OR discoverability = ? OR (discoverability = ? AND EXISTS (
+					SELECT 1 FROM todos WHERE (todos.user_id = ? AND todos.assignee_id = users.id) OR (todos.assignee_id = ? AND todos.user_id = users.id))))`,
+				)).
+					WithArgs(`jo\_%`, 2, "public", "contacts", 2, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "jo_doe", nil, nil, "", "", "contacts", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
+					 WHERE username LIKE ? AND suspended_at IS NULL AND (id = ? OR discoverability = ? OR (discoverability = ? AND EXISTS (
+					SELECT 1 FROM todos WHERE (todos.user_id = ? AND todos.assignee_id = users.id) OR (todos.assignee_id = ? AND todos.user_id = users.id))))
+					 ORDER BY id ASC LIMIT ? OFFSET ?`,
+				)).
+					WithArgs(`jo\_%`, 2, "public", "contacts", 2, 2, 10, 0).
+					WillReturnRows(rows)
+			},
+			param: &model.SearchUserRequest{
+				Query:    &query,
 				Limit:    10,
 				Offset:   0,
+				ViewerID: 2,
 			},
 			wantUsers: []entity.User{
 				{
-					ID:        1,
-					Username:  "johndoe",
-					Password:  "password",
-					CreatedAt: s.now,
-					UpdatedAt: s.now,
+					ID:              1,
+					Username:        "jo_doe",
+					Discoverability: "contacts",
+					Password:        "password",
+					Role:            "user",
+					CreatedAt:       s.now,
+					UpdatedAt:       s.now,
+				},
+			},
+			wantTotal: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "success with admin filters",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT COUNT(id) FROM users WHERE email = ? AND role = ? AND suspended_at IS NOT NULL`,
+				)).
+					WithArgs("johndoe@example.com", "user").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "johndoe", nil, nil, "", "", "public", nil, email, nil, "password", "user", 1, s.now, nil, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
+					 WHERE email = ? AND role = ? AND suspended_at IS NOT NULL ORDER BY id ASC LIMIT ? OFFSET ?`,
+				)).
+					WithArgs("johndoe@example.com", "user", 10, 0).
+					WillReturnRows(rows)
+			},
+			param: &model.SearchUserRequest{
+				Email:        &email,
+				Role:         &role,
+				Status:       &status,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
+			},
+			wantUsers: []entity.User{
+				{
+					ID:              1,
+					Username:        "johndoe",
+					Discoverability: "public",
+					Email:           &email,
+					Password:        "password",
+					Role:            "user",
+					TokenVersion:    1,
+					SuspendedAt:     &s.now,
+					CreatedAt:       s.now,
+					UpdatedAt:       s.now,
 				},
 			},
 			wantTotal: 1,
@@ -184,17 +299,18 @@ func (s *UserRepositorySuite) TestUserRepository_List() {
 					`SELECT COUNT(id) FROM users`,
 				)).WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-				rows := sqlmock.NewRows([]string{"id", "username", "password", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"})
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
 					ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.SearchUserRequest{
-				Limit:  10,
-				Offset: 0,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
 			},
 			wantUsers: nil,
 			wantTotal: 0,
@@ -208,8 +324,9 @@ func (s *UserRepositorySuite) TestUserRepository_List() {
 				)).WithoutArgs().WillReturnError(errors.New("something error"))
 			},
 			param: &model.SearchUserRequest{
-				Limit:  10,
-				Offset: 0,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
 			},
 			wantUsers: nil,
 			wantTotal: 0,
@@ -223,15 +340,16 @@ func (s *UserRepositorySuite) TestUserRepository_List() {
 				)).WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
 					ORDER BY id ASC LIMIT ? OFFSET ?`,
 				)).
 					WithArgs(10, 0).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &model.SearchUserRequest{
-				Limit:  10,
-				Offset: 0,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
 			},
 			wantUsers: nil,
 			wantTotal: 0,
@@ -262,21 +380,23 @@ func (s *UserRepositorySuite) TestUserRepository_FindByID() {
 		{
 			name: "success",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "username", "password", "created_at", "updated_at"}).
-					AddRow(1, "johndoe", "password", s.now, s.now)
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "johndoe", nil, nil, "", "", "public", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users WHERE id = ? LIMIT 1`,
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE id = ? LIMIT 1`,
 				)).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
 			paramID: 1,
 			wantUser: &entity.User{
-				ID:        1,
-				Username:  "johndoe",
-				Password:  "password",
-				CreatedAt: s.now,
-				UpdatedAt: s.now,
+				ID:              1,
+				Username:        "johndoe",
+				Discoverability: "public",
+				Password:        "password",
+				Role:            "user",
+				CreatedAt:       s.now,
+				UpdatedAt:       s.now,
 			},
 			wantErr: nil,
 		},
@@ -284,7 +404,7 @@ func (s *UserRepositorySuite) TestUserRepository_FindByID() {
 			name: "not found",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users WHERE id = ? LIMIT 1`,
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE id = ? LIMIT 1`,
 				)).
 					WithArgs(1).
 					WillReturnError(sql.ErrNoRows)
@@ -297,7 +417,7 @@ func (s *UserRepositorySuite) TestUserRepository_FindByID() {
 			name: "unexpected error",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users WHERE id = ? LIMIT 1`,
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE id = ? LIMIT 1`,
 				)).
 					WithArgs(1).
 					WillReturnError(errors.New("something error"))
@@ -319,6 +439,78 @@ func (s *UserRepositorySuite) TestUserRepository_FindByID() {
 	}
 }
 
+func (s *UserRepositorySuite) TestUserRepository_FindDiscoverableByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantUser *entity.User
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(2, "janedoe", nil, nil, "", "", "contacts", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
+					 WHERE id = ? AND suspended_at IS NULL AND (id = ? OR discoverability = ? OR (discoverability = ? AND EXISTS (
+					SELECT 1 FROM todos WHERE (todos.user_id = ? AND todos.assignee_id = users.id) OR (todos.assignee_id = ? AND todos.user_id = users.id)))) LIMIT 1`,
+				)).
+					WithArgs(2, 1, "public", "contacts", 1, 1).
+					WillReturnRows(rows)
+			},
+			wantUser: &entity.User{
+				ID:              2,
+				Username:        "janedoe",
+				Discoverability: "contacts",
+				Password:        "password",
+				Role:            "user",
+				CreatedAt:       s.now,
+				UpdatedAt:       s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
+					 WHERE id = ? AND suspended_at IS NULL AND (id = ? OR discoverability = ? OR (discoverability = ? AND EXISTS (
+					SELECT 1 FROM todos WHERE (todos.user_id = ? AND todos.assignee_id = users.id) OR (todos.assignee_id = ? AND todos.user_id = users.id)))) LIMIT 1`,
+				)).
+					WithArgs(2, 1, "public", "contacts", 1, 1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantUser: nil,
+			wantErr:  nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users
+					 WHERE id = ? AND suspended_at IS NULL AND (id = ? OR discoverability = ? OR (discoverability = ? AND EXISTS (
+					SELECT 1 FROM todos WHERE (todos.user_id = ? AND todos.assignee_id = users.id) OR (todos.assignee_id = ? AND todos.user_id = users.id)))) LIMIT 1`,
+				)).
+					WithArgs(2, 1, "public", "contacts", 1, 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantUser: nil,
+			wantErr:  errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindDiscoverableByID(s.ctx, 1, 2)
+			s.Equal(tt.wantUser, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
 func (s *UserRepositorySuite) TestUserRepository_FindByUsername() {
 	tests := []struct {
 		name          string
@@ -330,21 +522,23 @@ func (s *UserRepositorySuite) TestUserRepository_FindByUsername() {
 		{
 			name: "success",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "username", "password", "created_at", "updated_at"}).
-					AddRow(1, "johndoe", "password", s.now, s.now)
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "johndoe", nil, nil, "", "", "public", nil, nil, nil, "password", "user", 0, nil, nil, s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users WHERE username = ? LIMIT 1`,
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE username = ? LIMIT 1`,
 				)).
 					WithArgs("johndoe").
 					WillReturnRows(rows)
 			},
 			paramUsername: "johndoe",
 			wantUser: &entity.User{
-				ID:        1,
-				Username:  "johndoe",
-				Password:  "password",
-				CreatedAt: s.now,
-				UpdatedAt: s.now,
+				ID:              1,
+				Username:        "johndoe",
+				Discoverability: "public",
+				Password:        "password",
+				Role:            "user",
+				CreatedAt:       s.now,
+				UpdatedAt:       s.now,
 			},
 			wantErr: nil,
 		},
@@ -352,7 +546,7 @@ func (s *UserRepositorySuite) TestUserRepository_FindByUsername() {
 			name: "not found",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users WHERE username = ? LIMIT 1`,
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE username = ? LIMIT 1`,
 				)).
 					WithArgs("johndoe").
 					WillReturnError(sql.ErrNoRows)
@@ -365,7 +559,7 @@ func (s *UserRepositorySuite) TestUserRepository_FindByUsername() {
 			name: "unexpected error",
 			mockFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, username, password, created_at, updated_at FROM users WHERE username = ? LIMIT 1`,
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE username = ? LIMIT 1`,
 				)).
 					WithArgs("johndoe").
 					WillReturnError(errors.New("something error"))
@@ -387,6 +581,80 @@ func (s *UserRepositorySuite) TestUserRepository_FindByUsername() {
 	}
 }
 
+func (s *UserRepositorySuite) TestUserRepository_FindByEmail() {
+	email := "johndoe@example.com"
+
+	tests := []struct {
+		name       string
+		mockFunc   func(sqlmock.Sqlmock)
+		paramEmail string
+		wantUser   *entity.User
+		wantErr    error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "username", "display_name", "bio", "timezone", "locale", "discoverability", "avatar_key", "email", "email_verified_at", "password", "role", "token_version", "suspended_at", "password_reset_required_at", "created_at", "updated_at"}).
+					AddRow(1, "johndoe", nil, nil, "", "", "public", nil, email, s.now, "password", "user", 0, nil, nil, s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE email = ? LIMIT 1`,
+				)).
+					WithArgs(email).
+					WillReturnRows(rows)
+			},
+			paramEmail: email,
+			wantUser: &entity.User{
+				ID:              1,
+				Username:        "johndoe",
+				Discoverability: "public",
+				Email:           &email,
+				EmailVerifiedAt: &s.now,
+				Password:        "password",
+				Role:            "user",
+				CreatedAt:       s.now,
+				UpdatedAt:       s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE email = ? LIMIT 1`,
+				)).
+					WithArgs(email).
+					WillReturnError(sql.ErrNoRows)
+			},
+			paramEmail: email,
+			wantUser:   nil,
+			wantErr:    nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, username, display_name, bio, timezone, locale, discoverability, avatar_key, email, email_verified_at, password, role, token_version, suspended_at, password_reset_required_at, created_at, updated_at FROM users WHERE email = ? LIMIT 1`,
+				)).
+					WithArgs(email).
+					WillReturnError(errors.New("something error"))
+			},
+			paramEmail: email,
+			wantUser:   nil,
+			wantErr:    errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByEmail(s.ctx, tt.paramEmail)
+			s.Equal(tt.wantUser, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
 func (s *UserRepositorySuite) TestUserRepository_UpdateByID() {
 	tests := []struct {
 		name     string
@@ -438,52 +706,403 @@ func (s *UserRepositorySuite) TestUserRepository_UpdateByID() {
 	}
 }
 
-func (s *UserRepositorySuite) TestUserRepository_CountByUsername() {
+func (s *UserRepositorySuite) TestUserRepository_ChangePasswordByID() {
 	tests := []struct {
-		name          string
-		mockFunc      func(sqlmock.Sqlmock)
-		paramUsername string
-		wantTotal     int
-		wantErr       error
+		name        string
+		mockFunc    func(sqlmock.Sqlmock)
+		wantVersion uint64
+		wantErr     error
 	}{
 		{
 			name: "success",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT COUNT(id) FROM users WHERE username = ?`,
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET password = ?, token_version = token_version + 1, password_reset_required_at = NULL, updated_at = ? WHERE id = ?`,
 				)).
-					WithArgs("johndoe").
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+					WithArgs("newpassword", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT token_version FROM users WHERE id = ?`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(3))
 			},
-			paramUsername: "johndoe",
-			wantTotal:     1,
-			wantErr:       nil,
+			wantVersion: 3,
+			wantErr:     nil,
 		},
 		{
-			name: "not found",
+			name: "error on find token version",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT COUNT(id) FROM users WHERE username = ?`,
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET password = ?, token_version = token_version + 1, password_reset_required_at = NULL, updated_at = ? WHERE id = ?`,
 				)).
-					WithArgs("johndoe").
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+					WithArgs("newpassword", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT token_version FROM users WHERE id = ?`)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
 			},
-			paramUsername: "johndoe",
-			wantTotal:     0,
-			wantErr:       nil,
+			wantErr: errors.New("something error"),
 		},
 		{
 			name: "unexpected error",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT COUNT(id) FROM users WHERE username = ?`,
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET password = ?, token_version = token_version + 1, password_reset_required_at = NULL, updated_at = ? WHERE id = ?`,
 				)).
-					WithArgs("johndoe").
+					WithArgs("newpassword", sqlmock.AnyArg(), 1).
 					WillReturnError(errors.New("something error"))
 			},
-			paramUsername: "johndoe",
-			wantTotal:     0,
-			wantErr:       errors.New("something error"),
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			version, err := s.repo.ChangePasswordByID(s.ctx, s.exec, 1, "newpassword")
+			s.Equal(tt.wantVersion, version)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_UpdateProfile() {
+	displayName := "John Doe"
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET username = ?, display_name = ?, bio = ?, timezone = ?, locale = ?, discoverability = ?,
+					updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs("johndoe", &displayName, nil, "Asia/Jakarta", "id-ID", "public", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "duplicate username",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET username = ?, display_name = ?, bio = ?, timezone = ?, locale = ?, discoverability = ?,
+					updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs("johndoe", &displayName, nil, "Asia/Jakarta", "id-ID", "public", sqlmock.AnyArg(), 1).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+			wantErr: model.ErrUsernameAlreadyExist,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET username = ?, display_name = ?, bio = ?, timezone = ?, locale = ?, discoverability = ?,
+					updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs("johndoe", &displayName, nil, "Asia/Jakarta", "id-ID", "public", sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateProfile(s.ctx, s.exec, &entity.User{
+				ID:              1,
+				Username:        "johndoe",
+				DisplayName:     &displayName,
+				Timezone:        "Asia/Jakarta",
+				Locale:          "id-ID",
+				Discoverability: "public",
+			})
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_UpdateAvatarByID() {
+	avatarKey := "abc123"
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE users SET avatar_key = ?, updated_at = ? WHERE id = ?`)).
+					WithArgs(&avatarKey, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE users SET avatar_key = ?, updated_at = ? WHERE id = ?`)).
+					WithArgs(&avatarKey, sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateAvatarByID(s.ctx, s.exec, 1, &avatarKey)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_UpdateEmailByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET email = ?, email_verified_at = ?, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs("johndoe@example.com", s.now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "duplicate email",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET email = ?, email_verified_at = ?, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs("johndoe@example.com", s.now, sqlmock.AnyArg(), 1).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+			wantErr: model.ErrEmailAlreadyExist,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET email = ?, email_verified_at = ?, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs("johndoe@example.com", s.now, sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateEmailByID(s.ctx, 1, "johndoe@example.com", s.now)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_SuspendByID() {
+	tests := []struct {
+		name        string
+		mockFunc    func(sqlmock.Sqlmock)
+		wantVersion uint64
+		wantErr     error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET suspended_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(s.now, s.now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT token_version FROM users WHERE id = ?`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(3))
+			},
+			wantVersion: 3,
+			wantErr:     nil,
+		},
+		{
+			name: "error on find token version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET suspended_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(s.now, s.now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT token_version FROM users WHERE id = ?`)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET suspended_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(s.now, s.now, 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			version, err := s.repo.SuspendByID(s.ctx, s.exec, 1, s.now)
+			s.Equal(tt.wantVersion, version)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_UnsuspendByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET suspended_at = NULL, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET suspended_at = NULL, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(sqlmock.AnyArg(), 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UnsuspendByID(s.ctx, s.exec, 1)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_RequirePasswordResetByID() {
+	tests := []struct {
+		name        string
+		mockFunc    func(sqlmock.Sqlmock)
+		wantVersion uint64
+		wantErr     error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET password_reset_required_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(s.now, s.now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT token_version FROM users WHERE id = ?`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"token_version"}).AddRow(3))
+			},
+			wantVersion: 3,
+			wantErr:     nil,
+		},
+		{
+			name: "error on find token version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET password_reset_required_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(s.now, s.now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT token_version FROM users WHERE id = ?`)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`UPDATE users SET password_reset_required_at = ?, token_version = token_version + 1, updated_at = ? WHERE id = ?`,
+				)).
+					WithArgs(s.now, s.now, 1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			version, err := s.repo.RequirePasswordResetByID(s.ctx, s.exec, 1, s.now)
+			s.Equal(tt.wantVersion, version)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_DeleteByID() {
+	tests := []struct {
+		name     string
+		mockFunc func(sqlmock.Sqlmock)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM users WHERE id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM users WHERE id = ?`,
+				)).
+					WithArgs(1).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
 		},
 	}
 
@@ -491,8 +1110,7 @@ func (s *UserRepositorySuite) TestUserRepository_CountByUsername() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			res, err := s.repo.CountByUsername(s.ctx, tt.paramUsername)
-			s.Equal(tt.wantTotal, res)
+			err := s.repo.DeleteByID(s.ctx, s.exec, 1)
 			s.Equal(tt.wantErr, err)
 		})
 	}
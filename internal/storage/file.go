@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+//go:generate mockery --name=FileStorage --structname FileStorage --outpkg=mocks --output=./../mocks
+type FileStorage interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Delete(ctx context.Context, names ...string) error
+}
+
+// localFileStorage keeps files in a single directory, the api serves it as static files
+type localFileStorage struct {
+	Dir string
+}
+
+func NewLocalFileStorage(dir string) FileStorage {
+	return &localFileStorage{
+		Dir: dir,
+	}
+}
+
+func (s *localFileStorage) Put(ctx context.Context, name string, data []byte) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.Dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create storage dir: %w", err)
+	}
+
+	// the file is renamed into place, so it is never served half written
+	tmp, err := os.CreateTemp(s.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmp.Name(), 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *localFileStorage) Delete(ctx context.Context, names ...string) error {
+	for _, name := range names {
+		path, err := s.path(name)
+		if err != nil {
+			return err
+		}
+
+		err = os.Remove(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *localFileStorage) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name[0] == '.' {
+		return "", fmt.Errorf("invalid file name %q", name)
+	}
+
+	return filepath.Join(s.Dir, name), nil
+}
@@ -0,0 +1,35 @@
+package storage_test
+
+import (
+	"context"
+	"go-api-example/internal/storage"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalFileStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "avatars")
+	s := storage.NewLocalFileStorage(dir)
+
+	err := s.Put(ctx, "avatar_32.png", []byte("dummy"))
+	assert.Nil(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "avatar_32.png"))
+	assert.Nil(t, err)
+	assert.Equal(t, "dummy", string(data))
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+
+	err = s.Delete(ctx, "avatar_32.png", "missing.png")
+	assert.Nil(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "avatar_32.png"))
+
+	for _, name := range []string{"", "../avatar.png", "a/b.png", ".hidden"} {
+		assert.EqualError(t, s.Put(ctx, name, []byte("dummy")), `invalid file name "`+name+`"`)
+	}
+}
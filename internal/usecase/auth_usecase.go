@@ -5,71 +5,494 @@ import (
 	"errors"
 	"fmt"
 	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mail"
+	"go-api-example/internal/messaging"
 	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
 	"go-api-example/internal/storage"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type authUsecase struct {
-	Log            *zap.Logger
-	RedisClient    storage.RedisClient
-	JWTToken       auth.JWTToken
-	RefreshToken   auth.RefreshToken
-	UserRepository UserRepository
+	Log                    *zap.Logger
+	RedisClient            storage.RedisClient
+	JWTToken               auth.JWTToken
+	RefreshToken           auth.RefreshToken
+	PasswordHasher         auth.PasswordHasher
+	SecurityEventProducer  *messaging.SecurityEventProducer
+	UserRepository         UserRepository
+	RoleRepository         RoleRepository
+	TOTPRepository         TOTPRepository
+	UserIdentityRepository UserIdentityRepository
+	OIDCProviders          map[string]auth.OIDCProvider
+	Mailer                 mail.Mailer
+	OpaqueToken            auth.OpaqueToken
+	AppBaseURL             string
 }
 
 func NewAuthUsecase(log *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken,
-	refreshToken auth.RefreshToken, userRepository UserRepository) AuthUsecase {
+	refreshToken auth.RefreshToken, passwordHasher auth.PasswordHasher, securityEventProducer *messaging.SecurityEventProducer,
+	userRepository UserRepository, roleRepository RoleRepository, totpRepository TOTPRepository,
+	userIdentityRepository UserIdentityRepository, oidcProviders map[string]auth.OIDCProvider, mailer mail.Mailer,
+	opaqueToken auth.OpaqueToken, appBaseURL string) AuthUsecase {
 	return &authUsecase{
-		Log:            log,
-		RedisClient:    redisClient,
-		JWTToken:       jwtToken,
-		RefreshToken:   refreshToken,
-		UserRepository: userRepository,
+		Log:                    log,
+		RedisClient:            redisClient,
+		JWTToken:               jwtToken,
+		RefreshToken:           refreshToken,
+		PasswordHasher:         passwordHasher,
+		SecurityEventProducer:  securityEventProducer,
+		UserRepository:         userRepository,
+		RoleRepository:         roleRepository,
+		TOTPRepository:         totpRepository,
+		UserIdentityRepository: userIdentityRepository,
+		OIDCProviders:          oidcProviders,
+		Mailer:                 mailer,
+		OpaqueToken:            opaqueToken,
+		AppBaseURL:             appBaseURL,
 	}
 }
 
 func (c *authUsecase) Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error) {
-	user, err := c.UserRepository.FindByUsername(ctx, req.Username)
+	username := auth.NormalizeUsername(req.Username)
+
+	locked, err := isLoginLocked(ctx, c.RedisClient, username, req.IP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login lock: %w", err)
+	}
+	if locked {
+		return nil, model.ErrTooManyLoginAttempts
+	}
+
+	user, err := c.UserRepository.FindByUsername(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by username: %w", err)
 	}
-	if user == nil {
-		return nil, model.ErrUserNotFound
+
+	passwordHash := ""
+	if user != nil {
+		passwordHash = user.Password
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = c.PasswordHasher.Compare(passwordHash, req.Password)
+	if user == nil || err != nil {
+		err = recordLoginFailure(ctx, c.RedisClient, username, req.IP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record login failure: %w", err)
+		}
+
+		// an unknown username has nobody whose security log it belongs to
+		if user != nil {
+			sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+				Type:      model.SecurityEventLoginFailed,
+				UserID:    user.ID,
+				IP:        req.IP,
+				UserAgent: req.UserAgent,
+				RequestID: req.RequestID,
+			})
+		}
+		return nil, model.ErrInvalidCredentials
+	}
+
+	// the password is what an admin distrusts when requiring a reset, other login methods keep working
+	if user.IsPasswordResetRequired() {
+		return nil, model.ErrPasswordResetRequired
+	}
+
+	if c.PasswordHasher.NeedsRehash(user.Password) {
+		c.rehashPassword(ctx, user, req.Password)
+	}
+
+	return c.completeLogin(ctx, user, req.DeviceName, req.UserAgent, req.IP, req.RequestID)
+}
+
+// rehashPassword upgrades a hash made with an older algorithm or weaker parameters while the plain password is known,
+// a failure only means the upgrade is tried again on the next login
+func (c *authUsecase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	hash, err := c.PasswordHasher.Hash(password)
 	if err != nil {
-		return nil, model.ErrInvalidPassword
+		c.Log.Warn("failed to rehash password", zap.Error(err))
+		return
 	}
 
-	accessToken, err := c.JWTToken.Create(fmt.Sprint(user.ID))
+	err = c.UserRepository.UpdateByID(ctx, &model.UpdateUserRequest{
+		ID:          user.ID,
+		NewPassword: hash,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create access token: %w", err)
+		c.Log.Warn("failed to update rehashed password", zap.Error(err))
+		return
 	}
 
-	refreshToken := c.RefreshToken.Create()
-	refreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, refreshToken)
+	user.Password = hash
+}
 
-	err = c.RedisClient.SetEx(ctx, refreshKey, fmt.Sprint(user.ID), auth.RefreshTTL).Err()
+// completeLogin starts a session for a user whose first factor passed, or answers with an mfa challenge when the
+// user has two-factor authentication. Suspended users are refused only now, so a wrong password never tells
+// that an account is suspended
+func (c *authUsecase) completeLogin(ctx context.Context, user *entity.User, deviceName string, userAgent string,
+	ip string, requestID string) (*model.LoginResponse, error) {
+	if user.IsSuspended() {
+		return nil, model.ErrUserSuspended
+	}
+
+	totp, err := c.TOTPRepository.FindByUserID(ctx, user.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+		return nil, fmt.Errorf("failed to find totp: %w", err)
 	}
 
-	return &model.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	// failures are only cleared once the second factor passes, otherwise knowing the password resets the lockout
+	if totp.IsConfirmed() {
+		token := c.RefreshToken.Create()
+		err = storeMFAChallenge(ctx, c.RedisClient, token, &entity.MFAChallenge{
+			UserID:     user.ID,
+			Username:   user.Username,
+			DeviceName: deviceName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to store mfa challenge: %w", err)
+		}
+
+		return &model.LoginResponse{
+			MFARequired: true,
+			MFAToken:    token,
+		}, nil
+	}
+
+	err = clearLoginFailures(ctx, c.RedisClient, user.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear login failures: %w", err)
+	}
+
+	return c.createSession(ctx, user, deviceName, userAgent, ip, requestID)
+}
+
+// RequestMagicLink answers the same way whether the email is registered or not, like a password reset, failures
+// after the lookup are only logged
+func (c *authUsecase) RequestMagicLink(ctx context.Context, req *model.MagicLinkRequest) error {
+	email := normalizeEmail(req.Email)
+
+	limited, err := countMagicLinkRequest(ctx, c.RedisClient, email, req.IP)
+	if err != nil {
+		return fmt.Errorf("failed to count magic link request: %w", err)
+	}
+	if limited {
+		return model.ErrTooManyMagicLinkRequests
+	}
+
+	user, err := c.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	if !user.IsEmailVerified() {
+		return nil
+	}
+
+	token, err := c.OpaqueToken.Create()
+	if err != nil {
+		c.Log.Warn("failed to create magic link token", zap.Error(err))
+		return nil
+	}
+
+	magicLinkKey := fmt.Sprintf("%s:%s", auth.PrefixMagicLinkKey, auth.HashToken(token))
+	err = c.RedisClient.SetEx(ctx, magicLinkKey, fmt.Sprintf("%d:%s", user.ID, req.DeviceName), auth.MagicLinkTTL).Err()
+	if err != nil {
+		c.Log.Warn("failed to store magic link token", zap.Error(err))
+		return nil
+	}
+
+	err = c.Mailer.Send(ctx, &mail.Message{
+		To:      user.GetEmail(),
+		Subject: "Your login link",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to log in, it can be used once and expires in 15 minutes.\n\n%s/login/magic-link?token=%s\n\n"+
+			"If you did not request a login link, you can ignore this email.\n",
+			user.Username, c.AppBaseURL, token),
+	})
+	if err != nil {
+		c.Log.Warn("failed to send magic link email", zap.Error(err))
+		return nil
+	}
+
+	return nil
+}
+
+// LoginMagicLink exchanges a magic link for the same response as a password login, so a user with two-factor
+// authentication still gets an mfa challenge. An unknown link counts as a failed login of the ip
+func (c *authUsecase) LoginMagicLink(ctx context.Context, req *model.MagicLinkLoginRequest) (*model.LoginResponse, error) {
+	locked, err := isIPLoginLocked(ctx, c.RedisClient, req.IP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login lock: %w", err)
+	}
+	if locked {
+		return nil, model.ErrTooManyLoginAttempts
+	}
+
+	magicLinkKey := fmt.Sprintf("%s:%s", auth.PrefixMagicLinkKey, auth.HashToken(req.Token))
+	value, err := c.RedisClient.GetDel(ctx, magicLinkKey).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to get magic link token: %w", err)
+		}
+
+		err = recordIPLoginFailure(ctx, c.RedisClient, req.IP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record login failure: %w", err)
+		}
+		return nil, model.ErrInvalidMagicLinkToken
+	}
+
+	rawUserID, deviceName, _ := strings.Cut(value, ":")
+	userID, err := strconv.ParseUint(rawUserID, 10, 64)
+	if err != nil {
+		return nil, model.ErrInvalidMagicLinkToken
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrInvalidMagicLinkToken
+	}
+
+	return c.completeLogin(ctx, user, deviceName, req.UserAgent, req.IP, req.RequestID)
+}
+
+func (c *authUsecase) AuthorizeOIDC(ctx context.Context, req *model.AuthorizeOIDCRequest) (*model.AuthorizeOIDCResponse, error) {
+	provider, ok := c.OIDCProviders[req.Provider]
+	if !ok {
+		return nil, model.ErrOIDCProviderNotFound
+	}
+
+	codeVerifier, err := auth.GeneratePKCEVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create code verifier: %w", err)
+	}
+
+	token := c.RefreshToken.Create()
+	state := &entity.OIDCState{
+		Provider:     req.Provider,
+		Nonce:        c.RefreshToken.Create(),
+		CodeVerifier: codeVerifier,
+		DeviceName:   req.DeviceName,
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, token, state.Nonce, auth.PKCEChallenge(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization url: %w", err)
+	}
+
+	err = storeOIDCState(ctx, c.RedisClient, token, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store oidc state: %w", err)
+	}
+
+	return &model.AuthorizeOIDCResponse{
+		AuthorizationURL: authorizationURL,
 	}, nil
 }
 
+// LoginOIDC finishes the provider login and continues like a password login, so two-factor authentication still applies
+func (c *authUsecase) LoginOIDC(ctx context.Context, req *model.OIDCCallbackRequest) (*model.LoginResponse, error) {
+	provider, ok := c.OIDCProviders[req.Provider]
+	if !ok {
+		return nil, model.ErrOIDCProviderNotFound
+	}
+
+	state, err := consumeOIDCState(ctx, c.RedisClient, req.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oidc state: %w", err)
+	}
+	if state == nil || state.Provider != req.Provider {
+		return nil, model.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		c.Log.Warn(fmt.Sprintf("failed to exchange oidc code: %+v", err), zap.String("provider", req.Provider))
+		return nil, model.ErrOIDCLoginFailed
+	}
+
+	user, err := c.findOIDCUser(ctx, req, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.completeLogin(ctx, user, state.DeviceName, req.UserAgent, req.IP, req.RequestID)
+}
+
+// findOIDCUser resolves the user linked to the provider subject. A login that isn't linked yet is only linked to the
+// user with the same email when both the provider and we verified it, otherwise whoever registers that email at a
+// provider could take the account over
+func (c *authUsecase) findOIDCUser(ctx context.Context, req *model.OIDCCallbackRequest, claims *auth.OIDCClaims) (*entity.User, error) {
+	identity, err := c.UserIdentityRepository.FindByProviderSubject(ctx, req.Provider, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user identity: %w", err)
+	}
+
+	if identity != nil {
+		user, err := c.UserRepository.FindByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user by id: %w", err)
+		}
+		if user == nil {
+			return nil, model.ErrOIDCAccountNotLinked
+		}
+
+		return user, nil
+	}
+
+	email := normalizeEmail(claims.Email)
+	if !claims.EmailVerified || email == "" {
+		return nil, model.ErrOIDCAccountNotLinked
+	}
+
+	user, err := c.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+	if !user.IsEmailVerified() {
+		return nil, model.ErrOIDCAccountNotLinked
+	}
+
+	err = c.UserIdentityRepository.Create(ctx, &entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: req.Provider,
+		Subject:  claims.Subject,
+		Email:    &email,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrIdentityAlreadyLinked) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to link user identity: %w", err)
+	}
+
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventIdentityLinked,
+		UserID:    user.ID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
+
+	return user, nil
+}
+
+func (c *authUsecase) ListIdentities(ctx context.Context, req *model.ListIdentityRequest) ([]model.IdentityResponse, error) {
+	identities, err := c.UserIdentityRepository.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+
+	return serializer.ListIdentityToResponse(identities), nil
+}
+
+func (c *authUsecase) UnlinkIdentity(ctx context.Context, req *model.UnlinkIdentityRequest) error {
+	deleted, err := c.UserIdentityRepository.DeleteByUserIDProvider(ctx, req.UserID, req.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete user identity: %w", err)
+	}
+
+	if !deleted {
+		return model.ErrIdentityNotFound
+	}
+
+	return nil
+}
+
+// VerifyMFA completes a login with a totp code or a recovery code. A wrong code counts as a failed login, so the
+// six digits can't be guessed faster than a password
+func (c *authUsecase) VerifyMFA(ctx context.Context, req *model.VerifyMFARequest) (*model.LoginResponse, error) {
+	challenge, err := findMFAChallenge(ctx, c.RedisClient, req.MFAToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mfa challenge: %w", err)
+	}
+	if challenge == nil {
+		return nil, model.ErrInvalidMFAToken
+	}
+
+	locked, err := isLoginLocked(ctx, c.RedisClient, challenge.Username, req.IP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login lock: %w", err)
+	}
+	if locked {
+		return nil, model.ErrTooManyLoginAttempts
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrInvalidMFAToken
+	}
+	if user.IsSuspended() {
+		return nil, model.ErrUserSuspended
+	}
+
+	var valid bool
+	if req.Code != "" {
+		totp, err := c.TOTPRepository.FindByUserID(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find totp: %w", err)
+		}
+		if !totp.IsConfirmed() {
+			return nil, model.ErrInvalidMFAToken
+		}
+
+		valid, err = verifyTOTPCode(ctx, c.RedisClient, totp, req.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify totp code: %w", err)
+		}
+	} else {
+		codeHash := auth.HashToken(auth.NormalizeRecoveryCode(req.RecoveryCode))
+		valid, err = c.TOTPRepository.UseRecoveryCode(ctx, user.ID, codeHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to use recovery code: %w", err)
+		}
+	}
+
+	if !valid {
+		err = recordLoginFailure(ctx, c.RedisClient, challenge.Username, req.IP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record login failure: %w", err)
+		}
+
+		sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+			Type:      model.SecurityEventLoginFailed,
+			UserID:    user.ID,
+			IP:        req.IP,
+			UserAgent: req.UserAgent,
+			RequestID: req.RequestID,
+		})
+		return nil, model.ErrInvalidMFACode
+	}
+
+	err = deleteMFAChallenge(ctx, c.RedisClient, req.MFAToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+
+	err = clearLoginFailures(ctx, c.RedisClient, challenge.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear login failures: %w", err)
+	}
+
+	return c.createSession(ctx, user, challenge.DeviceName, req.UserAgent, req.IP, req.RequestID)
+}
+
 func (c *authUsecase) Logout(ctx context.Context, req *model.LogoutRequest) error {
 	refreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, req.RefreshToken)
 
-	userID, err := c.RedisClient.Get(ctx, refreshKey).Result()
+	value, err := c.RedisClient.Get(ctx, refreshKey).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return model.ErrInvalidRefreshToken
@@ -78,18 +501,30 @@ func (c *authUsecase) Logout(ctx context.Context, req *model.LogoutRequest) erro
 		}
 	}
 
+	userID, sessionID := parseRefreshValue(value)
 	if userID != req.Claims.UserID {
 		return model.ErrInvalidLogoutSession
 	}
 
-	revokeKey := fmt.Sprintf("%s:%s", auth.PrefixRevokeKey, req.Claims.ID)
-	revokeTTL := time.Until(req.Claims.ExpiresAt.Time)
-	err = c.RedisClient.SetEx(ctx, revokeKey, "true", revokeTTL).Err()
+	err = revokeAccessToken(ctx, c.RedisClient, req.Claims)
 	if err != nil {
 		return fmt.Errorf("failed to set revoke token: %w", err)
 	}
 
-	_ = c.RedisClient.Del(ctx, refreshKey)
+	deleteRefreshToken(ctx, c.RedisClient, userID, req.RefreshToken)
+	if sessionID != "" {
+		deleteSession(ctx, c.RedisClient, userID, sessionID)
+	}
+
+	parsedUserID, _ := strconv.ParseUint(userID, 10, 64)
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventLogout,
+		UserID:    parsedUserID,
+		SessionID: sessionID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
 
 	return nil
 }
@@ -97,36 +532,303 @@ func (c *authUsecase) Logout(ctx context.Context, req *model.LogoutRequest) erro
 func (c *authUsecase) Refresh(ctx context.Context, req *model.RefreshRequest) (*model.RefreshResponse, error) {
 	oldRefreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, req.RefreshToken)
 
-	userID, err := c.RedisClient.Get(ctx, oldRefreshKey).Result()
+	value, err := c.RedisClient.Get(ctx, oldRefreshKey).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
+			err = c.revokeReusedRefreshToken(ctx, req)
+			if err != nil {
+				return nil, fmt.Errorf("failed to revoke reused refresh token: %w", err)
+			}
 			return nil, model.ErrInvalidRefreshToken
 		} else {
 			return nil, fmt.Errorf("failed to get refresh token: %w", err)
 		}
 	}
 
+	claimed, err := claimRefreshToken(ctx, c.RedisClient, req.RefreshToken, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim refresh token: %w", err)
+	}
+	if !claimed {
+		err = c.revokeReusedRefreshToken(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to revoke reused refresh token: %w", err)
+		}
+		return nil, model.ErrInvalidRefreshToken
+	}
+
+	userID, sessionID := parseRefreshValue(value)
 	if len(userID) == 0 {
 		return nil, model.ErrInvalidUserID
 	}
 
-	newAccessToken, err := c.JWTToken.Create(userID)
+	parsedUserID, err := strconv.ParseUint(userID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create access token: %w", err)
+		return nil, model.ErrInvalidUserID
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, parsedUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+	if user.IsSuspended() {
+		return nil, model.ErrUserSuspended
 	}
 
-	newRefreshToken := c.RefreshToken.Create()
-	newRefreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, newRefreshToken)
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user role: %w", err)
+	}
 
-	err = c.RedisClient.SetEx(ctx, newRefreshKey, userID, auth.RefreshTTL).Err()
+	now := time.Now()
+	var session *entity.Session
+	if sessionID != "" {
+		session, err = findSession(ctx, c.RedisClient, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find session: %w", err)
+		}
+		if session == nil {
+			return nil, model.ErrInvalidRefreshToken
+		}
+	} else {
+		// refresh tokens issued before sessions existed start a new session
+		session = &entity.Session{
+			ID:        c.RefreshToken.Create(),
+			UserID:    userID,
+			CreatedAt: now,
+		}
+	}
+	session.UserAgent = req.UserAgent
+	session.IP = req.IP
+	session.LastUsedAt = now
+	subject.SessionID = session.ID
+
+	newAccessToken, claims, err := c.JWTToken.Create(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+	session.AddAccessToken(claims.ID, claims.ExpiresAt.Time)
+
+	session.RefreshToken = c.RefreshToken.Create()
+	err = storeRefreshToken(ctx, c.RedisClient, userID, session.ID, session.RefreshToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
-	_ = c.RedisClient.Del(ctx, oldRefreshKey)
+	err = storeSession(ctx, c.RedisClient, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session: %w", err)
+	}
+
+	err = rotateRefreshToken(ctx, c.RedisClient, userID, session.ID, req.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventTokenRefreshed,
+		UserID:    user.ID,
+		SessionID: session.ID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
 
 	return &model.RefreshResponse{
 		AccessToken:  newAccessToken,
-		RefreshToken: newRefreshToken,
+		RefreshToken: session.RefreshToken,
 	}, nil
 }
+
+func (c *authUsecase) ListSessions(ctx context.Context, req *model.ListSessionRequest) ([]model.SessionResponse, error) {
+	sessions, err := listSessions(ctx, c.RedisClient, req.Claims.UserID)
+	if err != nil {
+		return []model.SessionResponse{}, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return serializer.ListSessionToResponse(sessions, req.Claims.SessionID), nil
+}
+
+func (c *authUsecase) RevokeSession(ctx context.Context, req *model.RevokeSessionRequest) error {
+	session, err := findSession(ctx, c.RedisClient, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session == nil || session.UserID != req.Claims.UserID {
+		return model.ErrSessionNotFound
+	}
+
+	err = revokeSession(ctx, c.RedisClient, session)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	userID, _ := strconv.ParseUint(req.Claims.UserID, 10, 64)
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventSessionRevoked,
+		UserID:    userID,
+		SessionID: session.ID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
+
+	return nil
+}
+
+func (c *authUsecase) RevokeAllSessions(ctx context.Context, req *model.RevokeAllSessionRequest) error {
+	err := revokeAllSessions(ctx, c.RedisClient, req.Claims.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	err = revokeAccessToken(ctx, c.RedisClient, req.Claims)
+	if err != nil {
+		return fmt.Errorf("failed to set revoke token: %w", err)
+	}
+
+	userID, _ := strconv.ParseUint(req.Claims.UserID, 10, 64)
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventAllSessionsRevoked,
+		UserID:    userID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
+
+	return nil
+}
+
+func (c *authUsecase) UnlockLogin(ctx context.Context, req *model.UnlockLoginRequest) error {
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	err = clearLoginFailures(ctx, c.RedisClient, user.Username)
+	if err != nil {
+		return fmt.Errorf("failed to clear login failures: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyTokenVersion rejects an access token issued before the last password change of its user
+func (c *authUsecase) VerifyTokenVersion(ctx context.Context, claims *auth.JWTClaims) error {
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		return model.ErrInvalidAuthToken
+	}
+
+	version, found, err := findTokenVersion(ctx, c.RedisClient, c.UserRepository, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find token version: %w", err)
+	}
+
+	if !found || claims.TokenVersion != version {
+		return model.ErrTokenRevoked
+	}
+
+	return nil
+}
+
+func (c *authUsecase) JWKS(ctx context.Context) *auth.JSONWebKeySet {
+	return c.JWTToken.JWKS()
+}
+
+func (c *authUsecase) createSession(ctx context.Context, user *entity.User, deviceName string, userAgent string,
+	ip string, requestID string) (*model.LoginResponse, error) {
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user role: %w", err)
+	}
+
+	now := time.Now()
+	// session ids come from the same uuid generator as refresh tokens
+	session := &entity.Session{
+		ID:         c.RefreshToken.Create(),
+		UserID:     fmt.Sprint(user.ID),
+		DeviceName: deviceName,
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	subject.SessionID = session.ID
+
+	accessToken, claims, err := c.JWTToken.Create(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+	session.AddAccessToken(claims.ID, claims.ExpiresAt.Time)
+
+	session.RefreshToken = c.RefreshToken.Create()
+	err = storeRefreshToken(ctx, c.RedisClient, session.UserID, session.ID, session.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	err = storeSession(ctx, c.RedisClient, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session: %w", err)
+	}
+
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventLogin,
+		UserID:    user.ID,
+		SessionID: session.ID,
+		IP:        ip,
+		UserAgent: userAgent,
+		RequestID: requestID,
+	})
+
+	return &model.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: session.RefreshToken,
+	}, nil
+}
+
+// revokeReusedRefreshToken looks for a refresh token that was already rotated. Such a token only comes back
+// when it was copied, so the session it belongs to is revoked for the legitimate client as well
+func (c *authUsecase) revokeReusedRefreshToken(ctx context.Context, req *model.RefreshRequest) error {
+	rotatedKey := fmt.Sprintf("%s:%s", auth.PrefixRotatedRefreshKey, req.RefreshToken)
+	value, err := c.RedisClient.Get(ctx, rotatedKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		return err
+	}
+
+	userID, sessionID := parseRefreshValue(value)
+	session, err := findSession(ctx, c.RedisClient, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session != nil {
+		err = revokeSession(ctx, c.RedisClient, session)
+		if err != nil {
+			return err
+		}
+	}
+
+	parsedUserID, _ := strconv.ParseUint(userID, 10, 64)
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventRefreshTokenReused,
+		UserID:    parsedUserID,
+		SessionID: sessionID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
+
+	return nil
+}
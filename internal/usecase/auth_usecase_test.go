@@ -3,14 +3,19 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"go-api-example/internal/auth"
 	"go-api-example/internal/entity"
+	"go-api-example/internal/mail"
+	"go-api-example/internal/messaging"
 	"go-api-example/internal/mocks"
 	"go-api-example/internal/model"
 	"go-api-example/internal/usecase"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/mock"
@@ -21,8 +26,9 @@ import (
 
 type AuthUsecaseSuite struct {
 	suite.Suite
-	log *zap.Logger
-	ctx context.Context
+	log            *zap.Logger
+	passwordHasher auth.PasswordHasher
+	ctx            context.Context
 }
 
 type MockFunc func(
@@ -31,16 +37,58 @@ type MockFunc func(
 	jwt *mocks.JWTToken,
 	rt *mocks.RefreshToken,
 	ur *mocks.UserRepository,
+	rr *mocks.RoleRepository,
+	k *mocks.KafkaProducer,
+	tr *mocks.TOTPRepository,
+)
+
+var (
+	userRole = &entity.Role{
+		ID:          1,
+		Name:        "user",
+		Permissions: []string{"todos:read", "todos:write"},
+	}
+	subject = &auth.Subject{
+		UserID:      "1",
+		Role:        "user",
+		Permissions: []string{"todos:read", "todos:write"},
+		SessionID:   "qwe-123",
+	}
+	accessClaims = &auth.JWTClaims{
+		UserID: "1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+			ID:        "asd-789",
+		},
+	}
+	confirmedTOTP = &entity.TOTP{
+		UserID:      1,
+		Secret:      "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		ConfirmedAt: &time.Time{},
+	}
+	sessionMatcher = mock.MatchedBy(func(v string) bool {
+		return strings.HasPrefix(v, `{"id":"qwe-123","user_id":"1","refresh_token":"zxc-123"`)
+	})
 )
 
 func (s *AuthUsecaseSuite) SetupTest() {
 	s.log, _ = zap.NewDevelopment()
+	s.passwordHasher, _ = auth.NewPasswordHasher(auth.PasswordHashConfig{
+		Algorithm:  auth.PasswordHashBcrypt,
+		BcryptCost: bcrypt.DefaultCost,
+	})
 	s.ctx = context.Background()
 }
 
 func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	outdatedHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
 	now := time.Now()
+	intCmd := func(val int64) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
 
 	tests := []struct {
 		name       string
@@ -61,7 +109,34 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").
+					Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find user by username: something error",
+		},
+		{
+			name: "error on find by normalized username",
+			request: &model.LoginRequest{
+				Username: " JohnDoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
 				ur.On("FindByUsername", mock.Anything, "johndoe").
 					Return(nil, errors.New("something error"))
 			},
@@ -80,12 +155,19 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
 				ur.On("FindByUsername", mock.Anything, "johndoe").
 					Return(nil, nil)
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(intCmd(1))
+				rc.On("Expire", mock.Anything, "login-failure:user:johndoe", auth.LoginFailureWindow).
+					Return(redis.NewBoolCmd(s.ctx))
 			},
 			wantRes:    nil,
-			wantErrMsg: "username not found",
+			wantErrMsg: "invalid username or password",
 		},
 		{
 			name: "error invalid password",
@@ -99,23 +181,32 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
 				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
 					ID:        1,
 					Username:  "johndoe",
 					Password:  string(passwordHash),
+					Role:      "user",
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(intCmd(2))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"login_failed"`)
+				}), mock.Anything).Return(nil)
 			},
 			wantRes:    nil,
-			wantErrMsg: "invalid password",
+			wantErrMsg: "invalid username or password",
 		},
 		{
-			name: "error on create jwt token",
+			name: "error on record login failure",
 			request: &model.LoginRequest{
 				Username: "johndoe",
-				Password: "password",
+				Password: "invalid_password",
 			},
 			mockFunc: func(
 				c context.Context,
@@ -123,21 +214,28 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
 				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
 					ID:        1,
 					Username:  "johndoe",
 					Password:  string(passwordHash),
+					Role:      "user",
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
-				jwt.On("Create", "1").Return("", errors.New("something error"))
+				incrCmd := redis.NewIntCmd(s.ctx)
+				incrCmd.SetErr(errors.New("something error"))
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(incrCmd)
 			},
 			wantRes:    nil,
-			wantErrMsg: "failed to create access token: something error",
+			wantErrMsg: "failed to record login failure: something error",
 		},
 		{
-			name: "error on set refresh token cache",
+			name: "error password reset required",
 			request: &model.LoginRequest{
 				Username: "johndoe",
 				Password: "password",
@@ -148,26 +246,26 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
 				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
-					ID:        1,
-					Username:  "johndoe",
-					Password:  string(passwordHash),
-					CreatedAt: now,
-					UpdatedAt: now,
+					ID:                      1,
+					Username:                "johndoe",
+					Password:                string(passwordHash),
+					Role:                    "user",
+					PasswordResetRequiredAt: &now,
+					CreatedAt:               now,
+					UpdatedAt:               now,
 				}, nil)
-				jwt.On("Create", "1").Return("qwerty-12345", nil)
-				rt.On("Create").Return("zxc-123")
-				setCmd := redis.NewStatusCmd(s.ctx)
-				setCmd.SetErr(errors.New("something error"))
-				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1", mock.Anything).
-					Return(setCmd)
 			},
 			wantRes:    nil,
-			wantErrMsg: "failed to store refresh token: something error",
+			wantErrMsg: "password reset required, reset it with the forgot password link",
 		},
 		{
-			name: "success",
+			name: "error user suspended",
 			request: &model.LoginRequest{
 				Username: "johndoe",
 				Password: "password",
@@ -178,189 +276,30 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
 				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
-					ID:        1,
-					Username:  "johndoe",
-					Password:  string(passwordHash),
-					CreatedAt: now,
-					UpdatedAt: now,
+					ID:          1,
+					Username:    "johndoe",
+					Password:    string(passwordHash),
+					Role:        "user",
+					SuspendedAt: &now,
+					CreatedAt:   now,
+					UpdatedAt:   now,
 				}, nil)
-				jwt.On("Create", "1").Return("qwerty-12345", nil)
-				rt.On("Create").Return("zxc-123")
-				setCmd := redis.NewStatusCmd(s.ctx)
-				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1", mock.Anything).
-					Return(setCmd)
-			},
-			wantRes: &model.LoginResponse{
-				AccessToken:  "qwerty-12345",
-				RefreshToken: "zxc-123",
-			},
-			wantErrMsg: "",
-		},
-	}
-
-	for _, tt := range tests {
-		s.Run(tt.name, func() {
-			rc := mocks.NewRedisClient(s.T())
-			jwt := mocks.NewJWTToken(s.T())
-			rt := mocks.NewRefreshToken(s.T())
-			ur := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, ur)
-			tt.mockFunc(s.ctx, rc, jwt, rt, ur)
-
-			res, err := usecase.Login(s.ctx, tt.request)
-
-			if tt.wantErrMsg != "" {
-				s.Nil(res)
-				s.Equal(tt.wantErrMsg, err.Error())
-			} else {
-				s.Equal(*tt.wantRes, *res)
-				s.Nil(err)
-			}
-		})
-	}
-}
-
-func (s *AuthUsecaseSuite) TestAuthUsecase_Logout() {
-	now := time.Now()
-
-	tests := []struct {
-		name       string
-		request    *model.LogoutRequest
-		mockFunc   func(ctx context.Context, rc *mocks.RedisClient)
-		wantErrMsg string
-	}{
-		{
-			name: "refresh token not found",
-			request: &model.LogoutRequest{
-				Claims: &auth.JWTClaims{
-					UserID: "1",
-				},
-				RefreshToken: "zxc-123",
-			},
-			mockFunc: func(ctx context.Context, rc *mocks.RedisClient) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetErr(redis.Nil)
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
-			},
-			wantErrMsg: "invalid refresh token",
-		},
-		{
-			name: "error on get refresh token cache",
-			request: &model.LogoutRequest{
-				Claims: &auth.JWTClaims{
-					UserID: "1",
-				},
-				RefreshToken: "zxc-123",
-			},
-			mockFunc: func(ctx context.Context, rc *mocks.RedisClient) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetErr(errors.New("something error"))
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
-			},
-			wantErrMsg: "failed to get refresh token: something error",
-		},
-		{
-			name: "error on invalid user id",
-			request: &model.LogoutRequest{
-				Claims: &auth.JWTClaims{
-					UserID: "1",
-				},
-				RefreshToken: "zxc-123",
-			},
-			mockFunc: func(ctx context.Context, rc *mocks.RedisClient) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("2")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
-			},
-			wantErrMsg: "invalid logout session",
-		},
-		{
-			name: "error on set revoke token cache",
-			request: &model.LogoutRequest{
-				Claims: &auth.JWTClaims{
-					UserID: "1",
-					RegisteredClaims: jwt.RegisteredClaims{
-						ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
-						ID:        "asd-789",
-					},
-				},
-				RefreshToken: "zxc-123",
-			},
-			mockFunc: func(ctx context.Context, rc *mocks.RedisClient) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("1")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
-
-				setCmd := redis.NewStatusCmd(s.ctx)
-				setCmd.SetErr(errors.New("something error"))
-				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
-					Return(setCmd)
-			},
-			wantErrMsg: "failed to set revoke token: something error",
-		},
-		{
-			name: "success",
-			request: &model.LogoutRequest{
-				Claims: &auth.JWTClaims{
-					UserID: "1",
-					RegisteredClaims: jwt.RegisteredClaims{
-						ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
-						ID:        "asd-789",
-					},
-				},
-				RefreshToken: "zxc-123",
-			},
-			mockFunc: func(ctx context.Context, rc *mocks.RedisClient) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("1")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
-
-				setCmd := redis.NewStatusCmd(s.ctx)
-				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
-					Return(setCmd)
-
-				delCmd := redis.NewIntCmd(s.ctx)
-				rc.On("Del", mock.Anything, "refresh-token:zxc-123").
-					Return(delCmd)
 			},
-			wantErrMsg: "",
+			wantRes:    nil,
+			wantErrMsg: "account suspended",
 		},
-	}
-
-	for _, tt := range tests {
-		s.Run(tt.name, func() {
-			rc := mocks.NewRedisClient(s.T())
-			jwt := mocks.NewJWTToken(s.T())
-			rt := mocks.NewRefreshToken(s.T())
-			ur := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, ur)
-			tt.mockFunc(s.ctx, rc)
-
-			err := usecase.Logout(s.ctx, tt.request)
-
-			if tt.wantErrMsg != "" {
-				s.Equal(tt.wantErrMsg, err.Error())
-			} else {
-				s.Nil(err)
-			}
-		})
-	}
-}
-
-func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
-	tests := []struct {
-		name       string
-		request    *model.RefreshRequest
-		mockFunc   MockFunc
-		wantRes    *model.RefreshResponse
-		wantErrMsg string
-	}{
 		{
-			name: "refresh token cache not found",
-			request: &model.RefreshRequest{
-				RefreshToken: "zxc-123",
+			name: "error invalid password locks username",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "invalid_password",
+				IP:       "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
@@ -368,19 +307,38 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetErr(redis.Nil)
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
-					Return(getCmd)
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe", "login-lock:ip:127.0.0.1").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(intCmd(5))
+				rc.On("Expire", mock.Anything, "login-failure:user:johndoe", 30*time.Second+auth.LoginFailureWindow).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "login-lock:user:johndoe", "true", 30*time.Second).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Incr", mock.Anything, "login-failure:ip:127.0.0.1").Return(intCmd(1))
+				rc.On("Expire", mock.Anything, "login-failure:ip:127.0.0.1", auth.LoginFailureWindow).
+					Return(redis.NewBoolCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
 			},
 			wantRes:    nil,
-			wantErrMsg: "invalid refresh token",
+			wantErrMsg: "invalid username or password",
 		},
 		{
-			name: "error on get refresh token cache",
-			request: &model.RefreshRequest{
-				RefreshToken: "zxc-123",
+			name: "error locked",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+				IP:       "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
@@ -388,19 +346,20 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetErr(errors.New("something error"))
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
-					Return(getCmd)
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe", "login-lock:ip:127.0.0.1").Return(intCmd(1))
 			},
 			wantRes:    nil,
-			wantErrMsg: "failed to get refresh token: something error",
+			wantErrMsg: "too many failed login attempts, try again later",
 		},
 		{
-			name: "refresh token has invalid user id",
-			request: &model.RefreshRequest{
-				RefreshToken: "zxc-123",
+			name: "error on check login lock",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
 			},
 			mockFunc: func(
 				c context.Context,
@@ -408,19 +367,22 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
-					Return(getCmd)
+				existsCmd := redis.NewIntCmd(s.ctx)
+				existsCmd.SetErr(errors.New("something error"))
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(existsCmd)
 			},
 			wantRes:    nil,
-			wantErrMsg: "invalid user id",
+			wantErrMsg: "failed to check login lock: something error",
 		},
 		{
-			name: "error on create jwt token",
-			request: &model.RefreshRequest{
-				RefreshToken: "zxc-123",
+			name: "error on find totp",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
 			},
 			mockFunc: func(
 				c context.Context,
@@ -428,20 +390,29 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("1")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
-					Return(getCmd)
-				jwt.On("Create", "1").Return("", errors.New("something error"))
-			},
-			wantRes:    nil,
-			wantErrMsg: "failed to create access token: something error",
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find totp: something error",
 		},
 		{
-			name: "error on set new refresh token cache",
-			request: &model.RefreshRequest{
-				RefreshToken: "zxc-123",
+			name: "error on store mfa challenge",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
 			},
 			mockFunc: func(
 				c context.Context,
@@ -449,75 +420,2993 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("1")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
-					Return(getCmd)
-				jwt.On("Create", "1").Return("", nil)
-				rt.On("Create").Return("asd-123")
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rt.On("Create").Return("mfa-123")
+				cmd := redis.NewStatusCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "mfa-challenge:mfa-123", mock.Anything, auth.MFAChallengeTTL).Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to store mfa challenge: something error",
+		},
+		{
+			name: "success with mfa required",
+			request: &model.LoginRequest{
+				Username:   "johndoe",
+				Password:   "password",
+				DeviceName: "phone",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rt.On("Create").Return("mfa-123")
+				rc.On("SetEx", mock.Anything, "mfa-challenge:mfa-123", `{"user_id":1,"username":"johndoe","device_name":"phone"}`,
+					auth.MFAChallengeTTL).Return(redis.NewStatusCmd(s.ctx))
+			},
+			wantRes: &model.LoginResponse{
+				MFARequired: true,
+				MFAToken:    "mfa-123",
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on clear login failures",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				delCmd := redis.NewIntCmd(s.ctx)
+				delCmd.SetErr(errors.New("something error"))
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").Return(delCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to clear login failures: something error",
+		},
+		{
+			name: "error on resolve user role",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(nil, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to resolve user role: role user not found",
+		},
+		{
+			name: "error on create jwt token",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				jwt.On("Create", subject).Return("", nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to create access token: something error",
+		},
+		{
+			name: "error on set refresh token cache",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("zxc-123").Once()
 				setCmd := redis.NewStatusCmd(s.ctx)
 				setCmd.SetErr(errors.New("something error"))
-				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1", mock.Anything).
+				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
 					Return(setCmd)
 			},
 			wantRes:    nil,
 			wantErrMsg: "failed to store refresh token: something error",
 		},
 		{
-			name: "success",
-			request: &model.RefreshRequest{
+			name: "error on index refresh token",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("zxc-123").Once()
+				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				saddCmd := redis.NewIntCmd(s.ctx)
+				saddCmd.SetErr(errors.New("something error"))
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(saddCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to store refresh token: something error",
+		},
+		{
+			name: "error on store session",
+			request: &model.LoginRequest{
+				Username:   "johndoe",
+				Password:   "password",
+				DeviceName: "laptop",
+				UserAgent:  "curl/8.0",
+				IP:         "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe", "login-lock:ip:127.0.0.1").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("zxc-123").Once()
+				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", mock.Anything).
+					Return(redis.NewBoolCmd(s.ctx))
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", mock.MatchedBy(func(v string) bool {
+					return strings.Contains(v, `"device_name":"laptop","user_agent":"curl/8.0","ip":"127.0.0.1"`)
+				}), auth.RefreshTTL).Return(setCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to store session: something error",
+		},
+		{
+			name: "success with rehash of outdated hash",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(outdatedHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				ur.On("UpdateByID", mock.Anything, mock.MatchedBy(func(r *model.UpdateUserRequest) bool {
+					cost, _ := bcrypt.Cost([]byte(r.NewPassword))
+					return r.ID == 1 && cost == bcrypt.DefaultCost && s.passwordHasher.Compare(r.NewPassword, "password") == nil
+				})).Return(nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("zxc-123").Once()
+				setCmd := redis.NewStatusCmd(s.ctx)
+				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
+					Return(setCmd)
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", mock.Anything).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", sessionMatcher, auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantRes: &model.LoginResponse{
+				AccessToken:  "qwerty-12345",
 				RefreshToken: "zxc-123",
 			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success when rehash fails",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
 			mockFunc: func(
 				c context.Context,
 				rc *mocks.RedisClient,
 				jwt *mocks.JWTToken,
 				rt *mocks.RefreshToken,
 				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
 			) {
-				getCmd := redis.NewStringCmd(s.ctx)
-				getCmd.SetVal("1")
-				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
-					Return(getCmd)
-				jwt.On("Create", "1").Return("tyuip-12345", nil)
-				rt.On("Create").Return("asd-123")
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(outdatedHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				ur.On("UpdateByID", mock.Anything, mock.Anything).Return(errors.New("something error"))
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("zxc-123").Once()
 				setCmd := redis.NewStatusCmd(s.ctx)
-				setCmd.SetErr(nil)
-				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1", mock.Anything).
+				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
 					Return(setCmd)
-				delCmd := redis.NewIntCmd(s.ctx)
-				rc.On("Del", mock.Anything, "refresh-token:zxc-123").
-					Return(delCmd)
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", mock.Anything).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", sessionMatcher, auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
 			},
-			wantRes: &model.RefreshResponse{
-				AccessToken:  "tyuip-12345",
-				RefreshToken: "asd-123",
+			wantRes: &model.LoginResponse{
+				AccessToken:  "qwerty-12345",
+				RefreshToken: "zxc-123",
 			},
 			wantErrMsg: "",
 		},
+		{
+			name: "success",
+			request: &model.LoginRequest{
+				Username: "johndoe",
+				Password: "password",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(passwordHash),
+					Role:      "user",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("zxc-123").Once()
+				setCmd := redis.NewStatusCmd(s.ctx)
+				rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
+					Return(setCmd)
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", mock.Anything).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", sessionMatcher, auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"login"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantRes: &model.LoginResponse{
+				AccessToken:  "qwerty-12345",
+				RefreshToken: "zxc-123",
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, producer, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(s.ctx, rc, jwt, rt, ur, rr, k, tr)
+
+			res, err := usecase.Login(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantRes, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_VerifyMFA() {
+	now := time.Now()
+	user := &entity.User{ID: 1, Username: "johndoe", Role: "user", CreatedAt: now, UpdatedAt: now}
+	code, _ := auth.TOTPCode(confirmedTOTP.Secret, auth.TOTPStep(now))
+	tokens := &model.LoginResponse{AccessToken: "qwerty-12345", RefreshToken: "zxc-123"}
+	usedMatcher := mock.MatchedBy(func(v string) bool {
+		return strings.HasPrefix(v, "totp-used:1:")
+	})
+	intCmd := func(val int64) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	challengeCmd := func() *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(`{"user_id":1,"username":"johndoe","device_name":"phone"}`)
+		return cmd
+	}
+	sessionMocks := func(rc *mocks.RedisClient, jwt *mocks.JWTToken, rt *mocks.RefreshToken, rr *mocks.RoleRepository) {
+		rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+		jwt.On("Create", subject).Return("qwerty-12345", accessClaims, nil)
+		rt.On("Create").Return("qwe-123").Once()
+		rt.On("Create").Return("zxc-123").Once()
+		rc.On("SetEx", mock.Anything, "refresh-token:zxc-123", "1:qwe-123", mock.Anything).
+			Return(redis.NewStatusCmd(s.ctx))
+		rc.On("SAdd", mock.Anything, "user-refresh-token:1", "zxc-123").
+			Return(redis.NewIntCmd(s.ctx))
+		rc.On("Expire", mock.Anything, "user-refresh-token:1", mock.Anything).
+			Return(redis.NewBoolCmd(s.ctx))
+		rc.On("SetEx", mock.Anything, "session:qwe-123", sessionMatcher, auth.RefreshTTL).
+			Return(redis.NewStatusCmd(s.ctx))
+		rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+			Return(redis.NewIntCmd(s.ctx))
+		rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+			Return(redis.NewBoolCmd(s.ctx))
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.VerifyMFARequest
+		mockFunc   MockFunc
+		wantRes    *model.LoginResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find challenge",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find mfa challenge: something error",
+		},
+		{
+			name:    "error invalid mfa token",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid or expired mfa token",
+		},
+		{
+			name:    "error login locked",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(1))
+			},
+			wantRes:    nil,
+			wantErrMsg: "too many failed login attempts, try again later",
+		},
+		{
+			name:    "error on find user",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:    "error user deleted",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid or expired mfa token",
+		},
+		{
+			name:    "error user suspended",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:          1,
+					Username:    "johndoe",
+					Role:        "user",
+					SuspendedAt: &now,
+				}, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "account suspended",
+		},
+		{
+			name:    "error on find totp",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find totp: something error",
+		},
+		{
+			name:    "error totp disabled",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid or expired mfa token",
+		},
+		{
+			name:    "error invalid code",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: "000000"},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(intCmd(2))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"login_failed"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid two-factor code",
+		},
+		{
+			name:    "error replayed code",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(intCmd(2))
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(intCmd(2))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid two-factor code",
+		},
+		{
+			name:    "error on record failure",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: "000000"},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				cmd := redis.NewIntCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to record login failure: something error",
+		},
+		{
+			name:    "error on use recovery code",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", RecoveryCode: "ABCDE-12345"},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("UseRecoveryCode", mock.Anything, uint64(1), auth.HashToken("abcde12345")).
+					Return(false, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to use recovery code: something error",
+		},
+		{
+			name:    "error invalid recovery code",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", RecoveryCode: "ABCDE-12345"},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("UseRecoveryCode", mock.Anything, uint64(1), auth.HashToken("abcde12345")).Return(false, nil)
+				rc.On("Incr", mock.Anything, "login-failure:user:johndoe").Return(intCmd(2))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid two-factor code",
+		},
+		{
+			name:    "error on delete challenge",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(intCmd(1))
+				rc.On("Expire", mock.Anything, usedMatcher, auth.TOTPUsedTTL).Return(redis.NewBoolCmd(s.ctx))
+				cmd := redis.NewIntCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Del", mock.Anything, "mfa-challenge:mfa-123").Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to delete mfa challenge: something error",
+		},
+		{
+			name:    "success with code",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", Code: code},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(intCmd(1))
+				rc.On("Expire", mock.Anything, usedMatcher, auth.TOTPUsedTTL).Return(redis.NewBoolCmd(s.ctx))
+				rc.On("Del", mock.Anything, "mfa-challenge:mfa-123").Return(redis.NewIntCmd(s.ctx))
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				sessionMocks(rc, jwt, rt, rr)
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"login"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantRes:    tokens,
+			wantErrMsg: "",
+		},
+		{
+			name:    "success with recovery code",
+			request: &model.VerifyMFARequest{MFAToken: "mfa-123", RecoveryCode: "ABCDE-12345"},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "mfa-challenge:mfa-123").Return(challengeCmd())
+				rc.On("Exists", mock.Anything, "login-lock:user:johndoe").Return(intCmd(0))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("UseRecoveryCode", mock.Anything, uint64(1), auth.HashToken("abcde12345")).Return(true, nil)
+				rc.On("Del", mock.Anything, "mfa-challenge:mfa-123").Return(redis.NewIntCmd(s.ctx))
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+				sessionMocks(rc, jwt, rt, rr)
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantRes:    tokens,
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, producer, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(s.ctx, rc, jwt, rt, ur, rr, k, tr)
+
+			res, err := usecase.VerifyMFA(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantRes, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_RequestMagicLink() {
+	email := "johndoe@example.com"
+	now := time.Now()
+	magicLinkKey := "magic-link-token:b5a2c96250612366ea272ffac6d9744aaf4b45aacd96aa7cfcb931ee3b558259"
+	request := &model.MagicLinkRequest{
+		Email:      "JohnDoe@example.com",
+		DeviceName: "laptop",
+		IP:         "1.2.3.4",
+	}
+	user := &entity.User{
+		ID:              1,
+		Username:        "johndoe",
+		Email:           &email,
+		EmailVerifiedAt: &now,
+	}
+	intCmd := func(val int64, err error) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+	allowRequest := func(rc *mocks.RedisClient) {
+		rc.On("Incr", mock.Anything, "magic-link-request:email:"+email).Return(intCmd(1, nil))
+		rc.On("Expire", mock.Anything, "magic-link-request:email:"+email, auth.MagicLinkRequestWindow).
+			Return(redis.NewBoolCmd(s.ctx))
+		rc.On("Incr", mock.Anything, "magic-link-request:ip:1.2.3.4").Return(intCmd(2, nil))
+	}
+	mailMatcher := mock.MatchedBy(func(msg *mail.Message) bool {
+		return msg.To == email && strings.Contains(msg.Body, "http://localhost:8500/login/magic-link?token=dummy")
+	})
+
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on count request",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				rc.On("Incr", mock.Anything, "magic-link-request:email:"+email).Return(intCmd(0, errors.New("something error")))
+			},
+			wantErrMsg: "failed to count magic link request: something error",
+		},
+		{
+			name: "error too many requests for email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				rc.On("Incr", mock.Anything, "magic-link-request:email:"+email).Return(intCmd(4, nil))
+			},
+			wantErrMsg: "too many magic link requests, try again later",
+		},
+		{
+			name: "error too many requests for ip",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				rc.On("Incr", mock.Anything, "magic-link-request:email:"+email).Return(intCmd(2, nil))
+				rc.On("Incr", mock.Anything, "magic-link-request:ip:1.2.3.4").Return(intCmd(11, nil))
+			},
+			wantErrMsg: "too many magic link requests, try again later",
+		},
+		{
+			name: "error on find by email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				allowRequest(rc)
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by email: something error",
+		},
+		{
+			name: "unknown email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				allowRequest(rc)
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "unverified email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				allowRequest(rc)
+				ur.On("FindByEmail", mock.Anything, email).Return(&entity.User{ID: 1, Email: &email}, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on store token is not returned",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				allowRequest(rc)
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, magicLinkKey, "1:laptop", auth.MagicLinkTTL).Return(setCmd)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on send mail is not returned",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				allowRequest(rc)
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, magicLinkKey, "1:laptop", auth.MagicLinkTTL).Return(redis.NewStatusCmd(s.ctx))
+				m.On("Send", mock.Anything, mailMatcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				allowRequest(rc)
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, magicLinkKey, "1:laptop", auth.MagicLinkTTL).Return(redis.NewStatusCmd(s.ctx))
+				m.On("Send", mock.Anything, mailMatcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			m := mocks.NewMailer(s.T())
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, mocks.NewJWTToken(s.T()), mocks.NewRefreshToken(s.T()), s.passwordHasher,
+				nil, ur, mocks.NewRoleRepository(s.T()), mocks.NewTOTPRepository(s.T()), nil, nil, m, ot, "http://localhost:8500")
+			tt.mockFunc(rc, m, ot, ur)
+
+			err := usecase.RequestMagicLink(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_LoginMagicLink() {
+	magicLinkKey := "magic-link-token:b5a2c96250612366ea272ffac6d9744aaf4b45aacd96aa7cfcb931ee3b558259"
+	request := &model.MagicLinkLoginRequest{
+		Token: "dummy",
+		IP:    "1.2.3.4",
+	}
+	user := &entity.User{ID: 1, Username: "johndoe", Role: "user"}
+	intCmd := func(val int64, err error) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+	getCmd := func(val string, err error) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository)
+		wantRes    *model.LoginResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on check login lock",
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				rc.On("Exists", mock.Anything, "login-lock:ip:1.2.3.4").Return(intCmd(0, errors.New("something error")))
+			},
+			wantErrMsg: "failed to check login lock: something error",
+		},
+		{
+			name: "error ip locked",
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				rc.On("Exists", mock.Anything, "login-lock:ip:1.2.3.4").Return(intCmd(1, nil))
+			},
+			wantErrMsg: "too many failed login attempts, try again later",
+		},
+		{
+			name: "error on get token",
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				rc.On("Exists", mock.Anything, "login-lock:ip:1.2.3.4").Return(intCmd(0, nil))
+				rc.On("GetDel", mock.Anything, magicLinkKey).Return(getCmd("", errors.New("something error")))
+			},
+			wantErrMsg: "failed to get magic link token: something error",
+		},
+		{
+			name: "error unknown token counts as failure",
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				rc.On("Exists", mock.Anything, "login-lock:ip:1.2.3.4").Return(intCmd(0, nil))
+				rc.On("GetDel", mock.Anything, magicLinkKey).Return(getCmd("", redis.Nil))
+				rc.On("Incr", mock.Anything, "login-failure:ip:1.2.3.4").Return(intCmd(1, nil))
+				rc.On("Expire", mock.Anything, "login-failure:ip:1.2.3.4", auth.LoginFailureWindow).
+					Return(redis.NewBoolCmd(s.ctx))
+			},
+			wantErrMsg: "invalid or expired magic link",
+		},
+		{
+			name: "error user not found",
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				rc.On("Exists", mock.Anything, "login-lock:ip:1.2.3.4").Return(intCmd(0, nil))
+				rc.On("GetDel", mock.Anything, magicLinkKey).Return(getCmd("1:laptop", nil))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "invalid or expired magic link",
+		},
+		{
+			name: "success with mfa required",
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				rc.On("Exists", mock.Anything, "login-lock:ip:1.2.3.4").Return(intCmd(0, nil))
+				rc.On("GetDel", mock.Anything, magicLinkKey).Return(getCmd("1:laptop", nil))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+				rt.On("Create").Return("mfa-123")
+				rc.On("SetEx", mock.Anything, "mfa-challenge:mfa-123", `{"user_id":1,"username":"johndoe","device_name":"laptop"}`,
+					auth.MFAChallengeTTL).Return(redis.NewStatusCmd(s.ctx))
+			},
+			wantRes: &model.LoginResponse{
+				MFARequired: true,
+				MFAToken:    "mfa-123",
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, mocks.NewJWTToken(s.T()), rt, s.passwordHasher, nil, ur,
+				mocks.NewRoleRepository(s.T()), tr, nil, nil, nil, nil, "")
+			tt.mockFunc(rc, rt, ur, tr)
+
+			res, err := usecase.LoginMagicLink(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantRes, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_Logout() {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		request    *model.LogoutRequest
+		mockFunc   func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer)
+		wantErrMsg string
+	}{
+		{
+			name: "refresh token not found",
+			request: &model.LogoutRequest{
+				Claims: &auth.JWTClaims{
+					UserID: "1",
+				},
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
+			},
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error on get refresh token cache",
+			request: &model.LogoutRequest{
+				Claims: &auth.JWTClaims{
+					UserID: "1",
+				},
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
+			},
+			wantErrMsg: "failed to get refresh token: something error",
+		},
+		{
+			name: "error on invalid user id",
+			request: &model.LogoutRequest{
+				Claims: &auth.JWTClaims{
+					UserID: "1",
+				},
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("2")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
+			},
+			wantErrMsg: "invalid logout session",
+		},
+		{
+			name: "error on set revoke token cache",
+			request: &model.LogoutRequest{
+				Claims: &auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
+						ID:        "asd-789",
+					},
+				},
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
+
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(setCmd)
+			},
+			wantErrMsg: "failed to set revoke token: something error",
+		},
+		{
+			name: "success",
+			request: &model.LogoutRequest{
+				Claims: &auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
+						ID:        "asd-789",
+					},
+				},
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
+
+				setCmd := redis.NewStatusCmd(s.ctx)
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(setCmd)
+
+				delCmd := redis.NewIntCmd(s.ctx)
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123").
+					Return(delCmd)
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"logout"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success with session",
+			request: &model.LogoutRequest{
+				Claims: &auth.JWTClaims{
+					UserID: "1",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
+						ID:        "asd-789",
+					},
+				},
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(ctx context.Context, rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1:qwe-123")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(getCmd)
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Del", mock.Anything, "session:qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, producer, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(s.ctx, rc, k)
+
+			err := usecase.Logout(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_Refresh() {
+	user := &entity.User{
+		ID:       1,
+		Username: "johndoe",
+		Role:     "user",
+	}
+	refreshCmd := func(val string) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	sessionCmd := func() *redis.StringCmd {
+		return refreshCmd(`{"id":"qwe-123","user_id":"1","refresh_token":"zxc-123","device_name":"laptop",` +
+			`"created_at":"2025-08-13T10:00:00Z","last_used_at":"2025-08-13T10:00:00Z"}`)
+	}
+	claimCmd := func(val bool) *redis.BoolCmd {
+		cmd := redis.NewBoolCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	rotatedMatcher := mock.MatchedBy(func(v string) bool {
+		return strings.HasPrefix(v, `{"id":"qwe-123","user_id":"1","refresh_token":"asd-123",`) &&
+			strings.Contains(v, `"user_agent":"curl/8.0","ip":"127.0.0.1"`)
+	})
+
+	tests := []struct {
+		name       string
+		request    *model.RefreshRequest
+		mockFunc   MockFunc
+		wantRes    *model.RefreshResponse
+		wantErrMsg string
+	}{
+		{
+			name: "refresh token cache not found",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("Get", mock.Anything, "rotated-refresh-token:zxc-123").
+					Return(getCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error on get rotated refresh token",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rotatedCmd := redis.NewStringCmd(s.ctx)
+				rotatedCmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "rotated-refresh-token:zxc-123").
+					Return(rotatedCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to revoke reused refresh token: something error",
+		},
+		{
+			name: "reused refresh token revokes session",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("Get", mock.Anything, "rotated-refresh-token:zxc-123").
+					Return(refreshCmd("1:qwe-123"))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(refreshCmd(fmt.Sprintf(
+					`{"id":"qwe-123","user_id":"1","refresh_token":"asd-123","access_tokens":{"asd-456":%q,"asd-789":%q}}`,
+					time.Now().Add(-time.Minute).Format(time.RFC3339), time.Now().Add(time.Minute).Format(time.RFC3339),
+				)))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Del", mock.Anything, "session:qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return *msg.TopicPartition.Topic == "security-event" && string(msg.Key) == "1" &&
+						strings.Contains(string(msg.Value), `"type":"refresh_token_reused","user_id":1,"session_id":"qwe-123",`+
+							`"ip":"127.0.0.1","user_agent":"curl/8.0"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "reused refresh token of revoked session",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("Get", mock.Anything, "rotated-refresh-token:zxc-123").
+					Return(refreshCmd("1:qwe-123"))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(getCmd)
+				k.On("Produce", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error on get refresh token cache",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to get refresh token: something error",
+		},
+		{
+			name: "error on claim refresh token",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				cmd := redis.NewBoolCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to claim refresh token: something error",
+		},
+		{
+			name: "concurrent use of refresh token revokes session",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				// another request with the same token claimed it after both read the refresh token
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(false))
+				rc.On("Get", mock.Anything, "rotated-refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(refreshCmd(fmt.Sprintf(
+					`{"id":"qwe-123","user_id":"1","refresh_token":"asd-123","access_tokens":{"asd-789":%q}}`,
+					time.Now().Add(time.Minute).Format(time.RFC3339),
+				)))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Del", mock.Anything, "session:qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return *msg.TopicPartition.Topic == "security-event" &&
+						strings.Contains(string(msg.Value), `"type":"refresh_token_reused","user_id":1,"session_id":"qwe-123"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "refresh token has invalid user id",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "", auth.RefreshTTL).Return(claimCmd(true))
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid user id",
+		},
+		{
+			name: "refresh token has non numeric user id",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("abc")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "abc", auth.RefreshTTL).Return(claimCmd(true))
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid user id",
+		},
+		{
+			name: "error on find user by id",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name: "error user not found",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "username not found",
+		},
+		{
+			name: "error user suspended",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(refreshCmd("1"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1", auth.RefreshTTL).Return(claimCmd(true))
+				suspendedAt := time.Now()
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:          1,
+					Username:    "johndoe",
+					Role:        "user",
+					SuspendedAt: &suspendedAt,
+				}, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "account suspended",
+		},
+		{
+			name: "error on resolve user role",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1")
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").
+					Return(getCmd)
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to resolve user role: something error",
+		},
+		{
+			name: "error on find session",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find session: something error",
+		},
+		{
+			name: "error session revoked",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error on create jwt token",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				jwt.On("Create", subject).Return("", nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to create access token: something error",
+		},
+		{
+			name: "error on set new refresh token cache",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				jwt.On("Create", subject).Return("tyuip-12345", accessClaims, nil)
+				rt.On("Create").Return("asd-123")
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1:qwe-123", auth.RefreshTTL).
+					Return(setCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to store refresh token: something error",
+		},
+		{
+			name: "error on store session",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				jwt.On("Create", subject).Return("tyuip-12345", accessClaims, nil)
+				rt.On("Create").Return("asd-123")
+				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1:qwe-123", auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", rotatedMatcher, auth.RefreshTTL).
+					Return(setCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to store session: something error",
+		},
+		{
+			name: "error on rotate refresh token",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				jwt.On("Create", subject).Return("tyuip-12345", accessClaims, nil)
+				rt.On("Create").Return("asd-123")
+				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1:qwe-123", auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", rotatedMatcher, auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				rotateCmd := redis.NewStatusCmd(s.ctx)
+				rotateCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).
+					Return(rotateCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to rotate refresh token: something error",
+		},
+		{
+			name: "success",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1:qwe-123"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				jwt.On("Create", subject).Return("tyuip-12345", accessClaims, nil)
+				rt.On("Create").Return("asd-123")
+				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1:qwe-123", auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", rotatedMatcher, auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"token_refreshed"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantRes: &model.RefreshResponse{
+				AccessToken:  "tyuip-12345",
+				RefreshToken: "asd-123",
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success with refresh token issued before sessions",
+			request: &model.RefreshRequest{
+				RefreshToken: "zxc-123",
+				UserAgent:    "curl/8.0",
+				IP:           "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				rt *mocks.RefreshToken,
+				ur *mocks.UserRepository,
+				rr *mocks.RoleRepository,
+				k *mocks.KafkaProducer,
+				tr *mocks.TOTPRepository,
+			) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(refreshCmd("1"))
+				rc.On("SetNX", mock.Anything, "rotated-refresh-token:zxc-123", "1", auth.RefreshTTL).Return(claimCmd(true))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				jwt.On("Create", subject).Return("tyuip-12345", accessClaims, nil)
+				rt.On("Create").Return("qwe-123").Once()
+				rt.On("Create").Return("asd-123").Once()
+				rc.On("SetEx", mock.Anything, "refresh-token:asd-123", "1:qwe-123", auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-refresh-token:1", "asd-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-refresh-token:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "session:qwe-123", rotatedMatcher, auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-session:1", "qwe-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-session:1", auth.RefreshTTL).
+					Return(redis.NewBoolCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "rotated-refresh-token:zxc-123", "1:qwe-123", auth.RefreshTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantRes: &model.RefreshResponse{
+				AccessToken:  "tyuip-12345",
+				RefreshToken: "asd-123",
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, producer, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(s.ctx, rc, jwt, rt, ur, rr, k, tr)
+
+			res, err := usecase.Refresh(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantRes, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_ListSessions() {
+	claims := &auth.JWTClaims{UserID: "1", SessionID: "qwe-123"}
+	sessionCmd := func(val string) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient)
+		wantRes    []model.SessionResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on list sessions",
+			mockFunc: func(rc *mocks.RedisClient) {
+				cmd := redis.NewStringSliceCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(cmd)
+			},
+			wantRes:    []model.SessionResponse{},
+			wantErrMsg: "failed to list sessions: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient) {
+				cmd := redis.NewStringSliceCmd(s.ctx)
+				cmd.SetVal([]string{"qwe-123", "asd-456", "rty-789"})
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(cmd)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd(
+					`{"id":"qwe-123","user_id":"1","refresh_token":"zxc-123","device_name":"laptop",` +
+						`"created_at":"2025-08-13T10:00:00Z","last_used_at":"2025-08-13T10:00:00Z"}`))
+				rc.On("Get", mock.Anything, "session:asd-456").Return(sessionCmd(
+					`{"id":"asd-456","user_id":"1","refresh_token":"zxc-456","device_name":"phone",` +
+						`"created_at":"2025-08-13T10:00:00Z","last_used_at":"2025-08-13T11:00:00Z"}`))
+				missingCmd := redis.NewStringCmd(s.ctx)
+				missingCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "session:rty-789").Return(missingCmd)
+				rc.On("SRem", mock.Anything, "user-session:1", "rty-789").Return(redis.NewIntCmd(s.ctx))
+			},
+			wantRes: []model.SessionResponse{
+				{
+					ID:         "asd-456",
+					DeviceName: "phone",
+					Current:    false,
+					CreatedAt:  "2025-08-13T10:00:00Z",
+					LastUsedAt: "2025-08-13T11:00:00Z",
+				},
+				{
+					ID:         "qwe-123",
+					DeviceName: "laptop",
+					Current:    true,
+					CreatedAt:  "2025-08-13T10:00:00Z",
+					LastUsedAt: "2025-08-13T10:00:00Z",
+				},
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, nil, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(rc)
+
+			res, err := usecase.ListSessions(s.ctx, &model.ListSessionRequest{Claims: claims})
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_RevokeSession() {
+	now := time.Now()
+	claims := &auth.JWTClaims{
+		UserID:    "1",
+		SessionID: "qwe-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
+			ID:        "asd-789",
+		},
+	}
+	sessionCmd := func(id, userID string) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(fmt.Sprintf(`{"id":"%s","user_id":"%s","refresh_token":"zxc-123","access_tokens":{"asd-789":%q},`+
+			`"created_at":"2025-08-13T10:00:00Z","last_used_at":"2025-08-13T10:00:00Z"}`,
+			id, userID, now.Add(time.Minute).Format(time.RFC3339)))
+		return cmd
+	}
+	deleteSession := func(rc *mocks.RedisClient, id string) {
+		rc.On("Del", mock.Anything, "refresh-token:zxc-123").Return(redis.NewIntCmd(s.ctx))
+		rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").Return(redis.NewIntCmd(s.ctx))
+		rc.On("Del", mock.Anything, "session:"+id).Return(redis.NewIntCmd(s.ctx))
+		rc.On("SRem", mock.Anything, "user-session:1", id).Return(redis.NewIntCmd(s.ctx))
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(rc *mocks.RedisClient, k *mocks.KafkaProducer)
+		wantErrMsg string
+	}{
+		{
+			name: "error on find session",
+			id:   "asd-456",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "session:asd-456").Return(cmd)
+			},
+			wantErrMsg: "failed to find session: something error",
+		},
+		{
+			name: "error session not found",
+			id:   "asd-456",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "session:asd-456").Return(cmd)
+			},
+			wantErrMsg: "session not found",
+		},
+		{
+			name: "error session of another user",
+			id:   "asd-456",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				rc.On("Get", mock.Anything, "session:asd-456").Return(sessionCmd("asd-456", "2"))
+			},
+			wantErrMsg: "session not found",
+		},
+		{
+			name: "error on set revoke token cache",
+			id:   "asd-456",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				rc.On("Get", mock.Anything, "session:asd-456").Return(sessionCmd("asd-456", "1"))
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).Return(setCmd)
+			},
+			wantErrMsg: "failed to revoke session: something error",
+		},
+		{
+			name: "success",
+			id:   "asd-456",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				rc.On("Get", mock.Anything, "session:asd-456").Return(sessionCmd("asd-456", "1"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				deleteSession(rc, "asd-456")
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"session_revoked"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, producer, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(rc, k)
+
+			err := usecase.RevokeSession(s.ctx, &model.RevokeSessionRequest{ID: tt.id, Claims: claims})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_RevokeAllSessions() {
+	now := time.Now()
+	claims := &auth.JWTClaims{
+		UserID:    "1",
+		SessionID: "qwe-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
+			ID:        "asd-789",
+		},
+	}
+	membersCmd := func(val ...string) *redis.StringSliceCmd {
+		cmd := redis.NewStringSliceCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	sessionCmd := func(id, tokenID string) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(fmt.Sprintf(`{"id":"%s","user_id":"1","refresh_token":"zxc-123","access_tokens":{"%s":%q}}`,
+			id, tokenID, now.Add(time.Minute).Format(time.RFC3339)))
+		return cmd
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient, k *mocks.KafkaProducer)
+		wantErrMsg string
+	}{
+		{
+			name: "error on revoke sessions",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				cmd := redis.NewStringSliceCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(cmd)
+			},
+			wantErrMsg: "failed to revoke sessions: something error",
+		},
+		{
+			name: "error on revoke session access tokens",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(membersCmd("zxc-123"))
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(membersCmd("rty-456"))
+				rc.On("Get", mock.Anything, "session:rty-456").Return(sessionCmd("rty-456", "fgh-456"))
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:fgh-456", "true", mock.Anything).Return(setCmd)
+			},
+			wantErrMsg: "failed to revoke sessions: something error",
+		},
+		{
+			name: "error on set revoke token cache",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(membersCmd("zxc-123"))
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(membersCmd("qwe-123"))
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(getCmd)
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "session:qwe-123", "user-refresh-token:1", "user-session:1").
+					Return(redis.NewIntCmd(s.ctx))
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).Return(setCmd)
+			},
+			wantErrMsg: "failed to set revoke token: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, k *mocks.KafkaProducer) {
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(membersCmd("zxc-123"))
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(membersCmd("qwe-123", "rty-456"))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd("qwe-123", "asd-789"))
+				rc.On("Get", mock.Anything, "session:rty-456").Return(sessionCmd("rty-456", "fgh-456"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:fgh-456", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "session:qwe-123", "session:rty-456", "user-refresh-token:1",
+					"user-session:1").Return(redis.NewIntCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"all_sessions_revoked"`)
+				}), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, producer, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(rc, k)
+
+			err := usecase.RevokeAllSessions(s.ctx, &model.RevokeAllSessionRequest{Claims: claims})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_UnlockLogin() {
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient, ur *mocks.UserRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on find user by id",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name: "error user not found",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name: "error on clear login failures",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Username: "JohnDoe"}, nil)
+				delCmd := redis.NewIntCmd(s.ctx)
+				delCmd.SetErr(errors.New("something error"))
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").Return(delCmd)
+			},
+			wantErrMsg: "failed to clear login failures: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Username: "JohnDoe"}, nil)
+				rc.On("Del", mock.Anything, "login-failure:user:johndoe", "login-lock:user:johndoe").
+					Return(redis.NewIntCmd(s.ctx))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, s.passwordHasher, nil, ur, rr, tr, nil, nil, nil, nil, "")
+			tt.mockFunc(rc, ur)
+
+			err := usecase.UnlockLogin(s.ctx, &model.UnlockLoginRequest{UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_AuthorizeOIDC() {
+	stateMatcher := mock.MatchedBy(func(v string) bool {
+		return strings.HasPrefix(v, `{"provider":"mock","nonce":"nonce-123","code_verifier":"`) &&
+			strings.HasSuffix(v, `","device_name":"laptop"}`)
+	})
+
+	tests := []struct {
+		name       string
+		request    *model.AuthorizeOIDCRequest
+		mockFunc   func(rc *mocks.RedisClient, rt *mocks.RefreshToken, op *mocks.OIDCProvider)
+		wantRes    *model.AuthorizeOIDCResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error provider not found",
+			request:    &model.AuthorizeOIDCRequest{Provider: "other", DeviceName: "laptop"},
+			mockFunc:   func(rc *mocks.RedisClient, rt *mocks.RefreshToken, op *mocks.OIDCProvider) {},
+			wantRes:    nil,
+			wantErrMsg: "login provider not found",
+		},
+		{
+			name:    "error on create authorization url",
+			request: &model.AuthorizeOIDCRequest{Provider: "mock", DeviceName: "laptop"},
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, op *mocks.OIDCProvider) {
+				rt.On("Create").Return("state-123").Once()
+				rt.On("Create").Return("nonce-123").Once()
+				op.On("AuthCodeURL", mock.Anything, "state-123", "nonce-123", mock.Anything).
+					Return("", errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to create authorization url: something error",
+		},
+		{
+			name:    "error on store state",
+			request: &model.AuthorizeOIDCRequest{Provider: "mock", DeviceName: "laptop"},
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, op *mocks.OIDCProvider) {
+				rt.On("Create").Return("state-123").Once()
+				rt.On("Create").Return("nonce-123").Once()
+				op.On("AuthCodeURL", mock.Anything, "state-123", "nonce-123", mock.Anything).
+					Return("https://idp.example.com/authorize?state=state-123", nil)
+				cmd := redis.NewStatusCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "oidc-state:state-123", stateMatcher, auth.OIDCStateTTL).Return(cmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to store oidc state: something error",
+		},
+		{
+			name:    "success",
+			request: &model.AuthorizeOIDCRequest{Provider: "mock", DeviceName: "laptop"},
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, op *mocks.OIDCProvider) {
+				rt.On("Create").Return("state-123").Once()
+				rt.On("Create").Return("nonce-123").Once()
+				op.On("AuthCodeURL", mock.Anything, "state-123", "nonce-123", mock.MatchedBy(func(v string) bool {
+					return len(v) == 43
+				})).Return("https://idp.example.com/authorize?state=state-123", nil)
+				rc.On("SetEx", mock.Anything, "oidc-state:state-123", stateMatcher, auth.OIDCStateTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+			},
+			wantRes: &model.AuthorizeOIDCResponse{
+				AuthorizationURL: "https://idp.example.com/authorize?state=state-123",
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			op := mocks.NewOIDCProvider(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, mocks.NewJWTToken(s.T()), rt, s.passwordHasher, nil, mocks.NewUserRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewTOTPRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				map[string]auth.OIDCProvider{"mock": op}, nil, nil, "")
+			tt.mockFunc(rc, rt, op)
+
+			res, err := usecase.AuthorizeOIDC(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_LoginOIDC() {
+	now := time.Now()
+	email := "johndoe@example.com"
+	state := `{"provider":"mock","nonce":"nonce-123","code_verifier":"verifier-123","device_name":"laptop"}`
+	request := &model.OIDCCallbackRequest{
+		Provider:  "mock",
+		Code:      "code-123",
+		State:     "state-123",
+		UserAgent: "curl/8.0",
+		IP:        "127.0.0.1",
+	}
+	claims := &auth.OIDCClaims{
+		Email:            "JohnDoe@example.com",
+		EmailVerified:    true,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "subject-1"},
+	}
+	unverifiedClaims := &auth.OIDCClaims{
+		Email:            "johndoe@example.com",
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "subject-1"},
+	}
+	user := &entity.User{ID: 1, Username: "johndoe", Email: &email, EmailVerifiedAt: &now, Role: "user"}
+	stateCmd := func(value string) *redis.StringCmd {
+		return redis.NewStringResult(value, nil)
+	}
+	mfaChallenge := func(rc *mocks.RedisClient, rt *mocks.RefreshToken, tr *mocks.TOTPRepository) {
+		tr.On("FindByUserID", mock.Anything, uint64(1)).Return(confirmedTOTP, nil)
+		rt.On("Create").Return("mfa-123")
+		rc.On("SetEx", mock.Anything, "mfa-challenge:mfa-123", `{"user_id":1,"username":"johndoe","device_name":"laptop"}`,
+			auth.MFAChallengeTTL).Return(redis.NewStatusCmd(s.ctx))
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.OIDCCallbackRequest
+		mockFunc   func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider)
+		wantRes    *model.LoginResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error provider not found",
+			request: &model.OIDCCallbackRequest{Provider: "other", Code: "code-123", State: "state-123"},
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+			},
+			wantRes:    nil,
+			wantErrMsg: "login provider not found",
+		},
+		{
+			name:    "error on find state",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(redis.NewStringResult("", errors.New("something error")))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find oidc state: something error",
+		},
+		{
+			name:    "error state not found",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(redis.NewStringResult("", redis.Nil))
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid or expired login state",
+		},
+		{
+			name:    "error state of another provider",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").
+					Return(stateCmd(`{"provider":"other","nonce":"nonce-123","code_verifier":"verifier-123"}`))
+			},
+			wantRes:    nil,
+			wantErrMsg: "invalid or expired login state",
+		},
+		{
+			name:    "error on exchange",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").
+					Return(nil, errors.New("id token nonce mismatch"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "login with provider failed",
+		},
+		{
+			name:    "error on find identity",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(claims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find user identity: something error",
+		},
+		{
+			name:    "error linked user not found",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(claims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").
+					Return(&entity.UserIdentity{ID: 1, UserID: 1, Provider: "mock", Subject: "subject-1"}, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "no account linked to this provider login",
+		},
+		{
+			name:    "error provider email not verified",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(unverifiedClaims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").Return(nil, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "no account linked to this provider login",
+		},
+		{
+			name:    "error on find user by email",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(claims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "johndoe@example.com").Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find user by email: something error",
+		},
+		{
+			name:    "error local email not verified",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(claims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "johndoe@example.com").
+					Return(&entity.User{ID: 1, Username: "johndoe", Email: &email}, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "no account linked to this provider login",
+		},
+		{
+			name:    "error already linked to another login",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(claims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "johndoe@example.com").Return(user, nil)
+				ir.On("Create", mock.Anything, mock.Anything).Return(model.ErrIdentityAlreadyLinked)
+			},
+			wantRes:    nil,
+			wantErrMsg: "account already linked to another login of this provider",
+		},
+		{
+			name:    "success linked identity",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(unverifiedClaims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").
+					Return(&entity.UserIdentity{ID: 1, UserID: 1, Provider: "mock", Subject: "subject-1"}, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				mfaChallenge(rc, rt, tr)
+			},
+			wantRes: &model.LoginResponse{
+				MFARequired: true,
+				MFAToken:    "mfa-123",
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:    "success links verified email",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, rt *mocks.RefreshToken, ur *mocks.UserRepository, tr *mocks.TOTPRepository, k *mocks.KafkaProducer, ir *mocks.UserIdentityRepository, op *mocks.OIDCProvider) {
+				rc.On("GetDel", mock.Anything, "oidc-state:state-123").Return(stateCmd(state))
+				op.On("Exchange", mock.Anything, "code-123", "verifier-123", "nonce-123").Return(claims, nil)
+				ir.On("FindByProviderSubject", mock.Anything, "mock", "subject-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "johndoe@example.com").Return(user, nil)
+				ir.On("Create", mock.Anything, &entity.UserIdentity{
+					UserID:   1,
+					Provider: "mock",
+					Subject:  "subject-1",
+					Email:    &email,
+				}).Return(nil)
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return strings.Contains(string(msg.Value), `"type":"identity_linked","user_id":1`)
+				}), mock.Anything).Return(nil)
+				mfaChallenge(rc, rt, tr)
+			},
+			wantRes: &model.LoginResponse{
+				MFARequired: true,
+				MFAToken:    "mfa-123",
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			rt := mocks.NewRefreshToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			ir := mocks.NewUserIdentityRepository(s.T())
+			op := mocks.NewOIDCProvider(s.T())
+			producer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewAuthUsecase(s.log, rc, mocks.NewJWTToken(s.T()), rt, s.passwordHasher, producer, ur,
+				mocks.NewRoleRepository(s.T()), tr, ir, map[string]auth.OIDCProvider{"mock": op}, nil, nil, "")
+			tt.mockFunc(rc, rt, ur, tr, k, ir, op)
+
+			res, err := usecase.LoginOIDC(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_ListIdentities() {
+	now := time.Now()
+	email := "johndoe@example.com"
+
+	tests := []struct {
+		name       string
+		mockFunc   func(ir *mocks.UserIdentityRepository)
+		wantRes    []model.IdentityResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on list identities",
+			mockFunc: func(ir *mocks.UserIdentityRepository) {
+				ir.On("ListByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to list user identities: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(ir *mocks.UserIdentityRepository) {
+				ir.On("ListByUserID", mock.Anything, uint64(1)).Return([]entity.UserIdentity{
+					{ID: 1, UserID: 1, Provider: "mock", Subject: "subject-1", Email: &email, CreatedAt: now},
+				}, nil)
+			},
+			wantRes: []model.IdentityResponse{
+				{Provider: "mock", Email: &email, CreatedAt: now.Format(time.RFC3339)},
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ir := mocks.NewUserIdentityRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, mocks.NewRedisClient(s.T()), mocks.NewJWTToken(s.T()), mocks.NewRefreshToken(s.T()),
+				s.passwordHasher, nil, mocks.NewUserRepository(s.T()), mocks.NewRoleRepository(s.T()), mocks.NewTOTPRepository(s.T()), ir, nil,
+				nil, nil, "")
+			tt.mockFunc(ir)
+
+			res, err := usecase.ListIdentities(s.ctx, &model.ListIdentityRequest{UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_UnlinkIdentity() {
+	tests := []struct {
+		name       string
+		mockFunc   func(ir *mocks.UserIdentityRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on delete identity",
+			mockFunc: func(ir *mocks.UserIdentityRepository) {
+				ir.On("DeleteByUserIDProvider", mock.Anything, uint64(1), "mock").Return(false, errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user identity: something error",
+		},
+		{
+			name: "error identity not found",
+			mockFunc: func(ir *mocks.UserIdentityRepository) {
+				ir.On("DeleteByUserIDProvider", mock.Anything, uint64(1), "mock").Return(false, nil)
+			},
+			wantErrMsg: "linked identity not found",
+		},
+		{
+			name: "success",
+			mockFunc: func(ir *mocks.UserIdentityRepository) {
+				ir.On("DeleteByUserIDProvider", mock.Anything, uint64(1), "mock").Return(true, nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ir := mocks.NewUserIdentityRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, mocks.NewRedisClient(s.T()), mocks.NewJWTToken(s.T()), mocks.NewRefreshToken(s.T()),
+				s.passwordHasher, nil, mocks.NewUserRepository(s.T()), mocks.NewRoleRepository(s.T()), mocks.NewTOTPRepository(s.T()), ir, nil,
+				nil, nil, "")
+			tt.mockFunc(ir)
+
+			err := usecase.UnlinkIdentity(s.ctx, &model.UnlinkIdentityRequest{UserID: 1, Provider: "mock"})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_VerifyTokenVersion() {
+	getCmd := func(val string, err error) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+	setNXCmd := func(val bool, err error) *redis.BoolCmd {
+		cmd := redis.NewBoolCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+
+	tests := []struct {
+		name       string
+		claims     *auth.JWTClaims
+		mockFunc   func(rc *mocks.RedisClient, ur *mocks.UserRepository)
+		wantErrMsg string
+	}{
+		{
+			name:       "error invalid user id",
+			claims:     &auth.JWTClaims{UserID: "abc"},
+			mockFunc:   func(rc *mocks.RedisClient, ur *mocks.UserRepository) {},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:   "error on get cached version",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("", errors.New("something error")))
+			},
+			wantErrMsg: "failed to find token version: something error",
+		},
+		{
+			name:   "error on find user",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("", redis.Nil))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find token version: something error",
+		},
+		{
+			name:   "error user not found",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("", redis.Nil))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "token revoked",
+		},
+		{
+			name:   "error outdated cached version",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("3", nil))
+			},
+			wantErrMsg: "token revoked",
+		},
+		{
+			name:   "success with cached version",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("2", nil))
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:   "error on cache version",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("", redis.Nil))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, TokenVersion: 2}, nil)
+				rc.On("SetNX", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(setNXCmd(false, errors.New("something error")))
+			},
+			wantErrMsg: "failed to find token version: something error",
+		},
+		{
+			name:   "error stale version from database",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("", redis.Nil)).Once()
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, TokenVersion: 2}, nil)
+				// a password change bumped and cached the version after the user was read
+				rc.On("SetNX", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(setNXCmd(false, nil))
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("3", nil)).Once()
+			},
+			wantErrMsg: "token revoked",
+		},
+		{
+			name:   "success with version from database",
+			claims: &auth.JWTClaims{UserID: "1", TokenVersion: 2},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository) {
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(getCmd("", redis.Nil))
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, TokenVersion: 2}, nil)
+				rc.On("SetNX", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(setNXCmd(true, nil))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, mocks.NewJWTToken(s.T()), mocks.NewRefreshToken(s.T()), s.passwordHasher,
+				nil, ur, mocks.NewRoleRepository(s.T()), mocks.NewTOTPRepository(s.T()), nil, nil, nil, nil, "")
+			tt.mockFunc(rc, ur)
+
+			err := usecase.VerifyTokenVersion(s.ctx, tt.claims)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
 	}
-
-	for _, tt := range tests {
-		s.Run(tt.name, func() {
-			rc := mocks.NewRedisClient(s.T())
-			jwt := mocks.NewJWTToken(s.T())
-			rt := mocks.NewRefreshToken(s.T())
-			ur := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, rt, ur)
-			tt.mockFunc(s.ctx, rc, jwt, rt, ur)
-
-			res, err := usecase.Refresh(s.ctx, tt.request)
-
-			if tt.wantErrMsg != "" {
-				s.Nil(res)
-				s.Equal(tt.wantErrMsg, err.Error())
-			} else {
-				s.Equal(*tt.wantRes, *res)
-				s.Nil(err)
-			}
-		})
-	}
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_JWKS() {
+	jwks := &auth.JSONWebKeySet{Keys: []auth.JSONWebKey{{Kty: "OKP", Kid: "key-1", Use: "sig", Alg: "EdDSA"}}}
+	jwt := mocks.NewJWTToken(s.T())
+	jwt.On("JWKS").Return(jwks)
+	usecase := usecase.NewAuthUsecase(s.log, mocks.NewRedisClient(s.T()), jwt, mocks.NewRefreshToken(s.T()), s.passwordHasher, nil,
+		mocks.NewUserRepository(s.T()), mocks.NewRoleRepository(s.T()), mocks.NewTOTPRepository(s.T()), nil, nil, nil, nil, "")
+
+	res := usecase.JWKS(s.ctx)
+
+	s.Equal(jwks, res)
 }
 
 func TestAuthUsecaseSuite(t *testing.T) {
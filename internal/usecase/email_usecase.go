@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/mail"
+	"go-api-example/internal/model"
+	"go-api-example/internal/storage"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type emailUsecase struct {
+	Log            *zap.Logger
+	RedisClient    storage.RedisClient
+	Mailer         mail.Mailer
+	OpaqueToken    auth.OpaqueToken
+	UserRepository UserRepository
+	AppBaseURL     string
+}
+
+func NewEmailUsecase(log *zap.Logger, redisClient storage.RedisClient, mailer mail.Mailer, opaqueToken auth.OpaqueToken,
+	userRepository UserRepository, appBaseURL string) EmailUsecase {
+	return &emailUsecase{
+		Log:            log,
+		RedisClient:    redisClient,
+		Mailer:         mailer,
+		OpaqueToken:    opaqueToken,
+		UserRepository: userRepository,
+		AppBaseURL:     appBaseURL,
+	}
+}
+
+// RequestVerification mails a single use token, the email is only stored on the user once the token is confirmed
+func (c *emailUsecase) RequestVerification(ctx context.Context, req *model.VerifyEmailRequest) error {
+	email := normalizeEmail(req.Email)
+
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	if user.IsEmailVerified() && user.GetEmail() == email {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	owner, err := c.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	if owner != nil && owner.ID != user.ID {
+		return model.ErrEmailAlreadyExist
+	}
+
+	token, err := c.OpaqueToken.Create()
+	if err != nil {
+		return fmt.Errorf("failed to create verification token: %w", err)
+	}
+
+	verifyKey := fmt.Sprintf("%s:%s", auth.PrefixEmailVerifyKey, auth.HashToken(token))
+	err = c.RedisClient.SetEx(ctx, verifyKey, fmt.Sprintf("%d:%s", user.ID, email), auth.EmailVerifyTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	err = c.Mailer.Send(ctx, &mail.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email address, it expires in 24 hours.\n\n%s/verify-email?token=%s\n",
+			user.Username, c.AppBaseURL, token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+func (c *emailUsecase) ConfirmVerification(ctx context.Context, req *model.ConfirmEmailRequest) error {
+	verifyKey := fmt.Sprintf("%s:%s", auth.PrefixEmailVerifyKey, auth.HashToken(req.Token))
+	value, err := c.RedisClient.GetDel(ctx, verifyKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return model.ErrInvalidEmailToken
+		}
+		return fmt.Errorf("failed to get verification token: %w", err)
+	}
+
+	rawUserID, email, ok := strings.Cut(value, ":")
+	if !ok {
+		return model.ErrInvalidEmailToken
+	}
+
+	userID, err := strconv.ParseUint(rawUserID, 10, 64)
+	if err != nil {
+		return model.ErrInvalidEmailToken
+	}
+
+	err = c.UserRepository.UpdateEmailByID(ctx, userID, email, time.Now())
+	if err != nil {
+		if errors.Is(err, model.ErrEmailAlreadyExist) {
+			return err
+		}
+		return fmt.Errorf("failed to update user email: %w", err)
+	}
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
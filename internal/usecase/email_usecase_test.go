@@ -0,0 +1,263 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mail"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+const emailVerifyKey = "email-verify-token:b5a2c96250612366ea272ffac6d9744aaf4b45aacd96aa7cfcb931ee3b558259"
+
+type EmailUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type EmailMockFunc func(
+	rc *mocks.RedisClient,
+	m *mocks.Mailer,
+	ot *mocks.OpaqueToken,
+	ur *mocks.UserRepository,
+)
+
+func (s *EmailUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *EmailUsecaseSuite) TestEmailUsecase_RequestVerification() {
+	email := "johndoe@example.com"
+	now := time.Now()
+	request := &model.VerifyEmailRequest{
+		UserID: 1,
+		Email:  " JohnDoe@Example.com ",
+	}
+	user := &entity.User{
+		ID:       1,
+		Username: "johndoe",
+	}
+	mailMatcher := mock.MatchedBy(func(msg *mail.Message) bool {
+		return msg.To == email && msg.Subject == "Verify your email address" &&
+			msg.Body == "Hi johndoe,\n\nOpen the link below to verify your email address, it expires in 24 hours.\n\n"+
+				"http://localhost:8500/verify-email?token=dummy\n"
+	})
+
+	tests := []struct {
+		name       string
+		mockFunc   EmailMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error on find by id",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name: "error user not found",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name: "error email already verified",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:              1,
+					Username:        "johndoe",
+					Email:           &email,
+					EmailVerifiedAt: &now,
+				}, nil)
+			},
+			wantErrMsg: "email already verified",
+		},
+		{
+			name: "error on find by email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by email: something error",
+		},
+		{
+			name: "error email used by other user",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("FindByEmail", mock.Anything, email).Return(&entity.User{ID: 2}, nil)
+			},
+			wantErrMsg: "email already exist",
+		},
+		{
+			name: "error on create token",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+				ot.On("Create").Return("", errors.New("something error"))
+			},
+			wantErrMsg: "failed to create verification token: something error",
+		},
+		{
+			name: "error on store token",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+				ot.On("Create").Return("dummy", nil)
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, emailVerifyKey, "1:"+email, 24*time.Hour).Return(setCmd)
+			},
+			wantErrMsg: "failed to store verification token: something error",
+		},
+		{
+			name: "error on send mail",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, emailVerifyKey, "1:"+email, 24*time.Hour).Return(redis.NewStatusCmd(s.ctx))
+				m.On("Send", mock.Anything, mailMatcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to send verification email: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, emailVerifyKey, "1:"+email, 24*time.Hour).Return(redis.NewStatusCmd(s.ctx))
+				m.On("Send", mock.Anything, mailMatcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			m := mocks.NewMailer(s.T())
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewEmailUsecase(s.log, rc, m, ot, ur, "http://localhost:8500")
+			tt.mockFunc(rc, m, ot, ur)
+
+			err := usecase.RequestVerification(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *EmailUsecaseSuite) TestEmailUsecase_ConfirmVerification() {
+	request := &model.ConfirmEmailRequest{
+		Token: "dummy",
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   EmailMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error token not found",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(redis.Nil)
+				rc.On("GetDel", mock.Anything, emailVerifyKey).Return(getCmd)
+			},
+			wantErrMsg: "invalid or expired email verification token",
+		},
+		{
+			name: "error on get token",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetErr(errors.New("something error"))
+				rc.On("GetDel", mock.Anything, emailVerifyKey).Return(getCmd)
+			},
+			wantErrMsg: "failed to get verification token: something error",
+		},
+		{
+			name: "error malformed token value",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("abc:johndoe@example.com")
+				rc.On("GetDel", mock.Anything, emailVerifyKey).Return(getCmd)
+			},
+			wantErrMsg: "invalid or expired email verification token",
+		},
+		{
+			name: "error email already exist",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1:johndoe@example.com")
+				rc.On("GetDel", mock.Anything, emailVerifyKey).Return(getCmd)
+				ur.On("UpdateEmailByID", mock.Anything, uint64(1), "johndoe@example.com", mock.Anything).
+					Return(model.ErrEmailAlreadyExist)
+			},
+			wantErrMsg: "email already exist",
+		},
+		{
+			name: "error on update email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1:johndoe@example.com")
+				rc.On("GetDel", mock.Anything, emailVerifyKey).Return(getCmd)
+				ur.On("UpdateEmailByID", mock.Anything, uint64(1), "johndoe@example.com", mock.Anything).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update user email: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				getCmd := redis.NewStringCmd(s.ctx)
+				getCmd.SetVal("1:johndoe@example.com")
+				rc.On("GetDel", mock.Anything, emailVerifyKey).Return(getCmd)
+				ur.On("UpdateEmailByID", mock.Anything, uint64(1), "johndoe@example.com", mock.Anything).
+					Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			m := mocks.NewMailer(s.T())
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewEmailUsecase(s.log, rc, m, ot, ur, "http://localhost:8500")
+			tt.mockFunc(rc, m, ot, ur)
+
+			err := usecase.ConfirmVerification(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestEmailUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(EmailUsecaseSuite))
+}
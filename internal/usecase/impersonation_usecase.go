@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type impersonationUsecase struct {
+	Log                        *zap.Logger
+	JWTToken                   auth.JWTToken
+	TokenTTL                   time.Duration
+	UserRepository             UserRepository
+	RoleRepository             RoleRepository
+	ImpersonationLogRepository ImpersonationLogRepository
+}
+
+func NewImpersonationUsecase(log *zap.Logger, jwtToken auth.JWTToken, tokenTTL time.Duration, userRepository UserRepository,
+	roleRepository RoleRepository, impersonationLogRepository ImpersonationLogRepository) ImpersonationUsecase {
+	return &impersonationUsecase{
+		Log:                        log,
+		JWTToken:                   jwtToken,
+		TokenTTL:                   tokenTTL,
+		UserRepository:             userRepository,
+		RoleRepository:             roleRepository,
+		ImpersonationLogRepository: impersonationLogRepository,
+	}
+}
+
+// Impersonate issues an access token of the user that names the admin in its act claim, users that may impersonate
+// others themselves can not be impersonated so the token never grants more than the admin already has. The issuance is
+// stored as the first impersonation log of the token and no token is handed out when that fails
+func (c *impersonationUsecase) Impersonate(ctx context.Context, req *model.ImpersonateRequest) (*model.ImpersonateResponse, error) {
+	if req.ActorID == req.UserID {
+		return nil, model.ErrImpersonationNotAllowed
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+	if user.IsSuspended() {
+		return nil, model.ErrUserSuspended
+	}
+
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user permissions: %w", err)
+	}
+
+	if auth.GrantsPermission(subject.Permissions, auth.PermissionUserImpersonate) {
+		return nil, model.ErrImpersonationNotAllowed
+	}
+
+	subject.ActorID = fmt.Sprint(req.ActorID)
+	subject.ExpireDuration = c.TokenTTL
+
+	accessToken, claims, err := c.JWTToken.Create(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+
+	err = c.ImpersonationLogRepository.Create(ctx, &entity.ImpersonationLog{
+		ActorID:   req.ActorID,
+		UserID:    req.UserID,
+		TokenID:   claims.ID,
+		Method:    req.Method,
+		Path:      req.Path,
+		IP:        req.IP,
+		RequestID: req.RequestID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store impersonation log: %w", err)
+	}
+
+	c.Log.Info("impersonation started",
+		zap.Uint64("actor_id", req.ActorID),
+		zap.Uint64("user_id", req.UserID),
+		zap.String("token_id", claims.ID),
+	)
+
+	return &model.ImpersonateResponse{
+		AccessToken: accessToken,
+		ExpiresAt:   claims.ExpiresAt.Time.Format(time.RFC3339),
+	}, nil
+}
+
+// Record stores a request made with an impersonation token, the auth middleware refuses the request when it fails
+func (c *impersonationUsecase) Record(ctx context.Context, req *model.RecordImpersonationRequest) error {
+	if !req.Claims.IsImpersonated() {
+		return model.ErrInvalidAuthToken
+	}
+
+	actorID, err := strconv.ParseUint(req.Claims.Actor.UserID, 10, 64)
+	if err != nil {
+		return model.ErrInvalidAuthToken
+	}
+
+	userID, err := strconv.ParseUint(req.Claims.UserID, 10, 64)
+	if err != nil {
+		return model.ErrInvalidAuthToken
+	}
+
+	log := &entity.ImpersonationLog{
+		ActorID:   actorID,
+		UserID:    userID,
+		TokenID:   req.Claims.ID,
+		Method:    req.Method,
+		Path:      req.Path,
+		IP:        req.IP,
+		RequestID: req.RequestID,
+	}
+
+	err = c.ImpersonationLogRepository.Create(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to store impersonation log: %w", err)
+	}
+
+	return nil
+}
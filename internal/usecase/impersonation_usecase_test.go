@@ -0,0 +1,271 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ImpersonationUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type ImpersonationMockFunc func(
+	jt *mocks.JWTToken,
+	ur *mocks.UserRepository,
+	rr *mocks.RoleRepository,
+	ir *mocks.ImpersonationLogRepository,
+)
+
+func (s *ImpersonationUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *ImpersonationUsecaseSuite) TestImpersonationUsecase_Impersonate() {
+	user := &entity.User{ID: 2, Username: "johndoe", Role: auth.RoleUser, TokenVersion: 4}
+	admin := &entity.User{ID: 3, Username: "janedoe", Role: auth.RoleAdmin}
+	suspendedAt := time.Now()
+	suspended := &entity.User{ID: 4, Username: "jackdoe", Role: auth.RoleUser, SuspendedAt: &suspendedAt}
+	userRole := &entity.Role{Name: auth.RoleUser, Permissions: []string{auth.PermissionTodoRead}}
+	adminRole := &entity.Role{Name: auth.RoleAdmin, Permissions: []string{auth.PermissionAll}}
+	expiresAt := time.Now().Add(10 * time.Minute)
+	claims := &auth.JWTClaims{
+		UserID:           "2",
+		Actor:            &auth.Actor{UserID: "1"},
+		RegisteredClaims: jwt.RegisteredClaims{ID: "token-id", ExpiresAt: jwt.NewNumericDate(expiresAt)},
+	}
+
+	request := &model.ImpersonateRequest{
+		ActorID:   1,
+		UserID:    2,
+		Method:    "POST",
+		Path:      "/api/admin/users/2/impersonate",
+		IP:        "127.0.0.1",
+		RequestID: "request-id",
+	}
+	issued := &entity.ImpersonationLog{
+		ActorID:   1,
+		UserID:    2,
+		TokenID:   "token-id",
+		Method:    "POST",
+		Path:      "/api/admin/users/2/impersonate",
+		IP:        "127.0.0.1",
+		RequestID: "request-id",
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.ImpersonateRequest
+		mockFunc   ImpersonationMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error impersonate self",
+			request: &model.ImpersonateRequest{ActorID: 1, UserID: 1},
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+			},
+			wantErrMsg: "user can not be impersonated",
+		},
+		{
+			name:    "error on find user",
+			request: request,
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:    "error user not found",
+			request: request,
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name:    "error user suspended",
+			request: &model.ImpersonateRequest{ActorID: 1, UserID: 4},
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(4)).Return(suspended, nil)
+			},
+			wantErrMsg: "account suspended",
+		},
+		{
+			name:    "error on find role",
+			request: request,
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to resolve user permissions: something error",
+		},
+		{
+			name:    "error impersonate admin",
+			request: &model.ImpersonateRequest{ActorID: 1, UserID: 3},
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(3)).Return(admin, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleAdmin).Return(adminRole, nil)
+			},
+			wantErrMsg: "user can not be impersonated",
+		},
+		{
+			name:    "error on create access token",
+			request: request,
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(userRole, nil)
+				jt.On("Create", mock.Anything).Return("", nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to create access token: something error",
+		},
+		{
+			name:    "error on store log",
+			request: request,
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(userRole, nil)
+				jt.On("Create", mock.Anything).Return("dummy-token", claims, nil)
+				ir.On("Create", mock.Anything, issued).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store impersonation log: something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(userRole, nil)
+				jt.On("Create", mock.MatchedBy(func(subject *auth.Subject) bool {
+					return subject.UserID == "2" && subject.ActorID == "1" && subject.TokenVersion == 4 &&
+						subject.ExpireDuration == 10*time.Minute && subject.SessionID == ""
+				})).Return("dummy-token", claims, nil)
+				ir.On("Create", mock.Anything, issued).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			jt := mocks.NewJWTToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			ir := mocks.NewImpersonationLogRepository(s.T())
+			usecase := usecase.NewImpersonationUsecase(s.log, jt, 10*time.Minute, ur, rr, ir)
+			tt.mockFunc(jt, ur, rr, ir)
+
+			res, err := usecase.Impersonate(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(&model.ImpersonateResponse{
+					AccessToken: "dummy-token",
+					ExpiresAt:   expiresAt.Format(time.RFC3339),
+				}, res)
+			}
+		})
+	}
+}
+
+func (s *ImpersonationUsecaseSuite) TestImpersonationUsecase_Record() {
+	claims := &auth.JWTClaims{
+		UserID:           "2",
+		Actor:            &auth.Actor{UserID: "1"},
+		RegisteredClaims: jwt.RegisteredClaims{ID: "token-id"},
+	}
+	newRequest := func(claims *auth.JWTClaims) *model.RecordImpersonationRequest {
+		return &model.RecordImpersonationRequest{
+			Claims:    claims,
+			Method:    "GET",
+			Path:      "/api/todos",
+			IP:        "127.0.0.1",
+			RequestID: "request-id",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.RecordImpersonationRequest
+		mockFunc   ImpersonationMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error not impersonated",
+			request: newRequest(&auth.JWTClaims{UserID: "2"}),
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+			},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:    "error invalid actor id",
+			request: newRequest(&auth.JWTClaims{UserID: "2", Actor: &auth.Actor{UserID: "admin"}}),
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+			},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:    "error on store log",
+			request: newRequest(claims),
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ir.On("Create", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store impersonation log: something error",
+		},
+		{
+			name:    "success",
+			request: newRequest(claims),
+			mockFunc: func(jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, ir *mocks.ImpersonationLogRepository) {
+				ir.On("Create", mock.Anything, &entity.ImpersonationLog{
+					ActorID:   1,
+					UserID:    2,
+					TokenID:   "token-id",
+					Method:    "GET",
+					Path:      "/api/todos",
+					IP:        "127.0.0.1",
+					RequestID: "request-id",
+				}).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			jt := mocks.NewJWTToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			ir := mocks.NewImpersonationLogRepository(s.T())
+			usecase := usecase.NewImpersonationUsecase(s.log, jt, 10*time.Minute, ur, rr, ir)
+			tt.mockFunc(jt, ur, rr, ir)
+
+			err := usecase.Record(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestImpersonationUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationUsecaseSuite))
+}
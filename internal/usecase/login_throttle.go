@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/storage"
+	"strings"
+	"time"
+)
+
+// failed logins are counted per username and per ip, each of them is locked on its own once it runs over its limit
+
+const (
+	loginScopeUser  = "user"
+	loginScopeIP    = "ip"
+	loginScopeEmail = "email"
+)
+
+func loginThrottleKey(prefix string, scope string, id string) string {
+	if scope == loginScopeUser {
+		id = strings.ToLower(id)
+	}
+
+	return fmt.Sprintf("%s:%s:%s", prefix, scope, id)
+}
+
+func isLoginLocked(ctx context.Context, redisClient storage.RedisClient, username string, ip string) (bool, error) {
+	keys := []string{loginThrottleKey(auth.PrefixLoginLockKey, loginScopeUser, username)}
+	if ip != "" {
+		keys = append(keys, loginThrottleKey(auth.PrefixLoginLockKey, loginScopeIP, ip))
+	}
+
+	locked, err := redisClient.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return locked > 0, nil
+}
+
+// isIPLoginLocked is used by logins that don't know the username up front, only the lock of the ip applies to them
+func isIPLoginLocked(ctx context.Context, redisClient storage.RedisClient, ip string) (bool, error) {
+	if ip == "" {
+		return false, nil
+	}
+
+	locked, err := redisClient.Exists(ctx, loginThrottleKey(auth.PrefixLoginLockKey, loginScopeIP, ip)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return locked > 0, nil
+}
+
+func recordLoginFailure(ctx context.Context, redisClient storage.RedisClient, username string, ip string) error {
+	err := countLoginFailure(ctx, redisClient, loginScopeUser, username, auth.LoginMaxUserFailures)
+	if err != nil {
+		return err
+	}
+
+	if ip == "" {
+		return nil
+	}
+
+	return countLoginFailure(ctx, redisClient, loginScopeIP, ip, auth.LoginMaxIPFailures)
+}
+
+func recordIPLoginFailure(ctx context.Context, redisClient storage.RedisClient, ip string) error {
+	if ip == "" {
+		return nil
+	}
+
+	return countLoginFailure(ctx, redisClient, loginScopeIP, ip, auth.LoginMaxIPFailures)
+}
+
+func countLoginFailure(ctx context.Context, redisClient storage.RedisClient, scope string, id string, maxFailures int64) error {
+	failureKey := loginThrottleKey(auth.PrefixLoginFailureKey, scope, id)
+	failures, err := redisClient.Incr(ctx, failureKey).Result()
+	if err != nil {
+		return err
+	}
+
+	lock := auth.LoginLockDuration(failures, maxFailures)
+	if lock == 0 {
+		if failures == 1 {
+			return redisClient.Expire(ctx, failureKey, auth.LoginFailureWindow).Err()
+		}
+		return nil
+	}
+
+	// the counter outlives the lock, so the next failure after it locks for twice as long
+	err = redisClient.Expire(ctx, failureKey, lock+auth.LoginFailureWindow).Err()
+	if err != nil {
+		return err
+	}
+
+	lockKey := loginThrottleKey(auth.PrefixLoginLockKey, scope, id)
+	return redisClient.SetEx(ctx, lockKey, "true", lock).Err()
+}
+
+func clearLoginFailures(ctx context.Context, redisClient storage.RedisClient, username string) error {
+	return redisClient.Del(ctx,
+		loginThrottleKey(auth.PrefixLoginFailureKey, loginScopeUser, username),
+		loginThrottleKey(auth.PrefixLoginLockKey, loginScopeUser, username),
+	).Err()
+}
+
+// countMagicLinkRequest counts a magic link request against the email and the ip, true is returned once either of
+// them ran over its limit within the window
+func countMagicLinkRequest(ctx context.Context, redisClient storage.RedisClient, email string, ip string) (bool, error) {
+	limited, err := countRequest(ctx, redisClient, loginThrottleKey(auth.PrefixMagicLinkRequestKey, loginScopeEmail, email),
+		auth.MagicLinkMaxEmailRequests, auth.MagicLinkRequestWindow)
+	if err != nil || limited || ip == "" {
+		return limited, err
+	}
+
+	return countRequest(ctx, redisClient, loginThrottleKey(auth.PrefixMagicLinkRequestKey, loginScopeIP, ip),
+		auth.MagicLinkMaxIPRequests, auth.MagicLinkRequestWindow)
+}
+
+// countRequest counts a request in a fixed window that starts with the first request, true is returned once the
+// count ran over maxRequests
+func countRequest(ctx context.Context, redisClient storage.RedisClient, key string, maxRequests int64,
+	window time.Duration) (bool, error) {
+	requests, err := redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if requests == 1 {
+		err = redisClient.Expire(ctx, key, window).Err()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return requests > maxRequests, nil
+}
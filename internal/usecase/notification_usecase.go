@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+
+	"go.uber.org/zap"
+)
+
+type notificationUsecase struct {
+	Log                    *zap.Logger
+	NotificationRepository NotificationRepository
+}
+
+func NewNotificationUsecase(log *zap.Logger, notificationRepository NotificationRepository) NotificationUsecase {
+	return &notificationUsecase{
+		Log:                    log,
+		NotificationRepository: notificationRepository,
+	}
+}
+
+func (c *notificationUsecase) Create(ctx context.Context, req *model.CreateNotificationRequest) error {
+	notification := &entity.Notification{
+		UserID:   req.UserID,
+		Type:     req.Type,
+		EventKey: req.EventKey,
+		Title:    req.Title,
+		Body:     req.Body,
+	}
+
+	err := c.NotificationRepository.Create(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+func (c *notificationUsecase) List(ctx context.Context, req *model.SearchNotificationRequest) ([]model.NotificationResponse, int, int, error) {
+	notifications, total, err := c.NotificationRepository.List(ctx, req)
+	if err != nil {
+		return []model.NotificationResponse{}, 0, 0, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unread, err := c.NotificationRepository.CountUnreadByUserID(ctx, req.UserID)
+	if err != nil {
+		return []model.NotificationResponse{}, 0, 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	if len(notifications) == 0 {
+		return []model.NotificationResponse{}, total, unread, nil
+	}
+
+	return serializer.ListNotificationToResponse(notifications), total, unread, nil
+}
+
+func (c *notificationUsecase) MarkReadByID(ctx context.Context, req *model.ReadNotificationRequest) error {
+	notification, err := c.NotificationRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find notification by id: %w", err)
+	}
+
+	if notification == nil {
+		return model.ErrNotificationNotFound
+	}
+
+	if notification.UserID != req.UserID {
+		return model.ErrForbidden
+	}
+
+	if notification.IsRead() {
+		return nil
+	}
+
+	err = c.NotificationRepository.MarkReadByID(ctx, notification.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return nil
+}
+
+func (c *notificationUsecase) MarkAllRead(ctx context.Context, req *model.ReadAllNotificationRequest) error {
+	err := c.NotificationRepository.MarkAllReadByUserID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+
+	return nil
+}
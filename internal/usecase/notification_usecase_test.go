@@ -0,0 +1,281 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type NotificationUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+func (s *NotificationUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *NotificationUsecaseSuite) TestNotificationUsecase_Create() {
+	body := "dummy body"
+	request := &model.CreateNotificationRequest{
+		UserID:   1,
+		Type:     entity.NotificationTypeTodoAssigned,
+		EventKey: "1-1",
+		Title:    "dummy title",
+		Body:     &body,
+	}
+	matcher := mock.MatchedBy(func(n *entity.Notification) bool {
+		return n.UserID == 1 && n.Type == entity.NotificationTypeTodoAssigned &&
+			n.EventKey == "1-1" && n.Title == "dummy title" && n.GetBody() == "dummy body"
+	})
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.NotificationRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on create",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("Create", mock.Anything, matcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create notification: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("Create", mock.Anything, matcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nr := mocks.NewNotificationRepository(s.T())
+			tt.mockFunc(nr)
+
+			u := usecase.NewNotificationUsecase(s.log, nr)
+			err := u.Create(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *NotificationUsecaseSuite) TestNotificationUsecase_List() {
+	now := time.Now()
+	request := &model.SearchNotificationRequest{
+		UserID: 1,
+		Limit:  10,
+		Offset: 0,
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.NotificationRepository)
+		wantRes    []model.NotificationResponse
+		wantTotal  int
+		wantUnread int
+		wantErrMsg string
+	}{
+		{
+			name: "error on list",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("List", mock.Anything, request).Return(nil, 0, errors.New("something error"))
+			},
+			wantRes:    []model.NotificationResponse{},
+			wantErrMsg: "failed to get notifications: something error",
+		},
+		{
+			name: "error on count unread",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("List", mock.Anything, request).Return([]entity.Notification{}, 0, nil)
+				r.On("CountUnreadByUserID", mock.Anything, uint64(1)).Return(0, errors.New("something error"))
+			},
+			wantRes:    []model.NotificationResponse{},
+			wantErrMsg: "failed to count unread notifications: something error",
+		},
+		{
+			name: "success with empty result",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("List", mock.Anything, request).Return([]entity.Notification{}, 0, nil)
+				r.On("CountUnreadByUserID", mock.Anything, uint64(1)).Return(0, nil)
+			},
+			wantRes:    []model.NotificationResponse{},
+			wantErrMsg: "",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("List", mock.Anything, request).Return([]entity.Notification{
+					{ID: 2, UserID: 1, Type: entity.NotificationTypeTodoAssigned, Title: "title 2", CreatedAt: now},
+					{ID: 1, UserID: 1, Type: entity.NotificationTypeUserRegistered, Title: "title 1", ReadAt: &now, CreatedAt: now},
+				}, 2, nil)
+				r.On("CountUnreadByUserID", mock.Anything, uint64(1)).Return(1, nil)
+			},
+			wantRes: []model.NotificationResponse{
+				{ID: 2, Type: "todo_assigned", Title: "title 2", CreatedAt: now.Format(time.RFC3339)},
+				{ID: 1, Type: "user_registered", Title: "title 1", ReadAt: func() *string {
+					str := now.Format(time.RFC3339)
+					return &str
+				}(), CreatedAt: now.Format(time.RFC3339)},
+			},
+			wantTotal:  2,
+			wantUnread: 1,
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nr := mocks.NewNotificationRepository(s.T())
+			tt.mockFunc(nr)
+
+			u := usecase.NewNotificationUsecase(s.log, nr)
+			res, total, unread, err := u.List(s.ctx, request)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantUnread, unread)
+			if tt.wantErrMsg != "" {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *NotificationUsecaseSuite) TestNotificationUsecase_MarkReadByID() {
+	now := time.Now()
+	request := &model.ReadNotificationRequest{
+		ID:     1,
+		UserID: 1,
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.NotificationRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find notification by id: something error",
+		},
+		{
+			name: "error not found",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "notification not found",
+		},
+		{
+			name: "error forbidden",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Notification{ID: 1, UserID: 2}, nil)
+			},
+			wantErrMsg: "forbidden",
+		},
+		{
+			name: "success already read",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Notification{ID: 1, UserID: 1, ReadAt: &now}, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on mark read",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Notification{ID: 1, UserID: 1}, nil)
+				r.On("MarkReadByID", mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to mark notification as read: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Notification{ID: 1, UserID: 1}, nil)
+				r.On("MarkReadByID", mock.Anything, uint64(1)).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nr := mocks.NewNotificationRepository(s.T())
+			tt.mockFunc(nr)
+
+			u := usecase.NewNotificationUsecase(s.log, nr)
+			err := u.MarkReadByID(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *NotificationUsecaseSuite) TestNotificationUsecase_MarkAllRead() {
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.NotificationRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on mark all read",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("MarkAllReadByUserID", mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to mark all notifications as read: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.NotificationRepository) {
+				r.On("MarkAllReadByUserID", mock.Anything, uint64(1)).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			nr := mocks.NewNotificationRepository(s.T())
+			tt.mockFunc(nr)
+
+			u := usecase.NewNotificationUsecase(s.log, nr)
+			err := u.MarkAllRead(s.ctx, &model.ReadAllNotificationRequest{UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestNotificationUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(NotificationUsecaseSuite))
+}
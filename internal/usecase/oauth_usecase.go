@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/storage"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type oauthUsecase struct {
+	Log                           *zap.Logger
+	RedisClient                   storage.RedisClient
+	JWTToken                      auth.JWTToken
+	UserRepository                UserRepository
+	RoleRepository                RoleRepository
+	PersonalAccessTokenRepository PersonalAccessTokenRepository
+}
+
+func NewOAuthUsecase(log *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken, userRepository UserRepository,
+	roleRepository RoleRepository, personalAccessTokenRepository PersonalAccessTokenRepository) OAuthUsecase {
+	return &oauthUsecase{
+		Log:                           log,
+		RedisClient:                   redisClient,
+		JWTToken:                      jwtToken,
+		UserRepository:                userRepository,
+		RoleRepository:                roleRepository,
+		PersonalAccessTokenRepository: personalAccessTokenRepository,
+	}
+}
+
+// Introspect tells the token type by its shape: personal access tokens carry their prefix, access tokens are jwts
+// and everything else is looked up as a refresh token
+func (c *oauthUsecase) Introspect(ctx context.Context, req *model.IntrospectTokenRequest) (*model.IntrospectTokenResponse, error) {
+	switch tokenType(req.Token) {
+	case model.TokenTypePersonalAccessToken:
+		return c.introspectPersonalAccessToken(ctx, req.Token)
+	case model.TokenTypeAccessToken:
+		return c.introspectAccessToken(ctx, req.Token)
+	default:
+		return c.introspectRefreshToken(ctx, req.Token)
+	}
+}
+
+// Revoke succeeds for unknown or already invalid tokens as RFC 7009 asks, revoking a refresh token ends its
+// whole session including the access tokens issued for it
+func (c *oauthUsecase) Revoke(ctx context.Context, req *model.RevokeTokenRequest) error {
+	switch tokenType(req.Token) {
+	case model.TokenTypePersonalAccessToken:
+		token, err := c.PersonalAccessTokenRepository.FindByTokenHash(ctx, auth.HashToken(req.Token))
+		if err != nil {
+			return fmt.Errorf("failed to find personal access token: %w", err)
+		}
+		if token == nil {
+			return nil
+		}
+
+		err = c.PersonalAccessTokenRepository.DeleteByID(ctx, token.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete personal access token: %w", err)
+		}
+	case model.TokenTypeAccessToken:
+		claims, err := c.JWTToken.Parse(req.Token)
+		if err != nil {
+			return nil
+		}
+
+		err = revokeAccessToken(ctx, c.RedisClient, claims)
+		if err != nil {
+			return fmt.Errorf("failed to set revoke token: %w", err)
+		}
+	default:
+		refreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, req.Token)
+		value, err := c.RedisClient.Get(ctx, refreshKey).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return nil
+			}
+			return fmt.Errorf("failed to get refresh token: %w", err)
+		}
+
+		userID, sessionID := parseRefreshValue(value)
+		var session *entity.Session
+		if sessionID != "" {
+			session, err = findSession(ctx, c.RedisClient, sessionID)
+			if err != nil {
+				return fmt.Errorf("failed to find session: %w", err)
+			}
+		}
+		if session == nil {
+			deleteRefreshToken(ctx, c.RedisClient, userID, req.Token)
+			return nil
+		}
+
+		err = revokeSession(ctx, c.RedisClient, session)
+		if err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+	}
+
+	c.Log.Info("token revoked", zap.String("client_id", req.ClientID), zap.String("token_type_hint", req.TokenTypeHint))
+
+	return nil
+}
+
+func (c *oauthUsecase) introspectAccessToken(ctx context.Context, token string) (*model.IntrospectTokenResponse, error) {
+	claims, err := c.JWTToken.Parse(token)
+	if err != nil {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	revokeKey := fmt.Sprintf("%s:%s", auth.PrefixRevokeKey, claims.ID)
+	exists, err := c.RedisClient.Exists(ctx, revokeKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+	if exists == 1 {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	version, found, err := findTokenVersion(ctx, c.RedisClient, c.UserRepository, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find token version: %w", err)
+	}
+	if !found || claims.TokenVersion != version {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	res := &model.IntrospectTokenResponse{
+		Active:    true,
+		TokenType: model.TokenTypeAccessToken,
+		Scope:     strings.Join(claims.Permissions, " "),
+		Sub:       claims.UserID,
+		Exp:       claims.ExpiresAt.Unix(),
+		Jti:       claims.ID,
+		Act:       claims.Actor,
+	}
+	if claims.IssuedAt != nil {
+		res.Iat = claims.IssuedAt.Unix()
+	}
+
+	return res, nil
+}
+
+// introspectRefreshToken reports the session id as jti, refresh tokens have no id of their own
+func (c *oauthUsecase) introspectRefreshToken(ctx context.Context, token string) (*model.IntrospectTokenResponse, error) {
+	refreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, token)
+	value, err := c.RedisClient.Get(ctx, refreshKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return &model.IntrospectTokenResponse{Active: false}, nil
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	ttl, err := c.RedisClient.TTL(ctx, refreshKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token ttl: %w", err)
+	}
+	if ttl <= 0 {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	userID, sessionID := parseRefreshValue(value)
+
+	return &model.IntrospectTokenResponse{
+		Active:    true,
+		TokenType: model.TokenTypeRefreshToken,
+		Sub:       userID,
+		Exp:       time.Now().Add(ttl).Unix(),
+		Jti:       sessionID,
+	}, nil
+}
+
+func (c *oauthUsecase) introspectPersonalAccessToken(ctx context.Context, plain string) (*model.IntrospectTokenResponse, error) {
+	token, err := c.PersonalAccessTokenRepository.FindByTokenHash(ctx, auth.HashToken(plain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find personal access token: %w", err)
+	}
+
+	now := time.Now()
+	if token == nil || token.IsExpired(now) {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil || user.IsSuspended() {
+		return &model.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user permissions: %w", err)
+	}
+
+	res := &model.IntrospectTokenResponse{
+		Active:    true,
+		TokenType: model.TokenTypePersonalAccessToken,
+		Scope:     strings.Join(personalAccessTokenPermissions(subject, token.Scopes), " "),
+		Sub:       fmt.Sprint(token.UserID),
+		Iat:       token.CreatedAt.Unix(),
+		Jti:       fmt.Sprint(token.ID),
+	}
+	if token.ExpiresAt != nil {
+		res.Exp = token.ExpiresAt.Unix()
+	}
+
+	return res, nil
+}
+
+func tokenType(token string) string {
+	switch {
+	case strings.HasPrefix(token, auth.PersonalAccessTokenPrefix):
+		return model.TokenTypePersonalAccessToken
+	case strings.Count(token, ".") == 2:
+		return model.TokenTypeAccessToken
+	default:
+		return model.TokenTypeRefreshToken
+	}
+}
@@ -0,0 +1,403 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OAuthUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type OAuthMockFunc func(
+	rc *mocks.RedisClient,
+	jt *mocks.JWTToken,
+	ur *mocks.UserRepository,
+	rr *mocks.RoleRepository,
+	pr *mocks.PersonalAccessTokenRepository,
+)
+
+func (s *OAuthUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+}
+
+func (s *OAuthUsecaseSuite) TestOAuthUsecase_Introspect() {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	claims := &auth.JWTClaims{
+		UserID:       "1",
+		Permissions:  []string{auth.PermissionTodoRead, auth.PermissionTodoWrite},
+		TokenVersion: 2,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        "asd-789",
+		},
+	}
+	token := &entity.PersonalAccessToken{
+		ID:        5,
+		UserID:    1,
+		Scopes:    []string{auth.PermissionTodoRead, auth.PermissionTodoWrite, auth.PermissionUserManage},
+		ExpiresAt: &expiresAt,
+		CreatedAt: now,
+	}
+	// the role no longer grants todos:write and users:manage is never a personal access token scope
+	role := &entity.Role{Name: auth.RoleUser, Permissions: []string{auth.PermissionTodoRead, auth.PermissionUserManage}}
+	existsCmd := func(val int64) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	versionCmd := func(val string) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	inactive := &model.IntrospectTokenResponse{Active: false}
+
+	tests := []struct {
+		name       string
+		token      string
+		mockFunc   OAuthMockFunc
+		wantRes    *model.IntrospectTokenResponse
+		wantErrMsg string
+	}{
+		{
+			name:  "invalid access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(nil, errors.New("token is expired"))
+			},
+			wantRes: inactive,
+		},
+		{
+			name:  "error on check revoked access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(claims, nil)
+				cmd := redis.NewIntCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:asd-789").Return(cmd)
+			},
+			wantErrMsg: "failed to check revoked token: something error",
+		},
+		{
+			name:  "revoked access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(claims, nil)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:asd-789").Return(existsCmd(1))
+			},
+			wantRes: inactive,
+		},
+		{
+			name:  "outdated access token version",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(claims, nil)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:asd-789").Return(existsCmd(0))
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(versionCmd("3"))
+			},
+			wantRes: inactive,
+		},
+		{
+			name:  "active access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(claims, nil)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:asd-789").Return(existsCmd(0))
+				rc.On("Get", mock.Anything, "user-token-version:1").Return(versionCmd("2"))
+			},
+			wantRes: &model.IntrospectTokenResponse{
+				Active:    true,
+				TokenType: model.TokenTypeAccessToken,
+				Scope:     "todos:read todos:write",
+				Sub:       "1",
+				Exp:       expiresAt.Unix(),
+				Iat:       now.Unix(),
+				Jti:       "asd-789",
+			},
+		},
+		{
+			name:  "error on get refresh token",
+			token: "zxc-123",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(cmd)
+			},
+			wantErrMsg: "failed to get refresh token: something error",
+		},
+		{
+			name:  "unknown refresh token",
+			token: "zxc-123",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(cmd)
+			},
+			wantRes: inactive,
+		},
+		{
+			name:  "active refresh token",
+			token: "zxc-123",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(versionCmd("1:qwe-123"))
+				ttlCmd := redis.NewDurationCmd(s.ctx, time.Second)
+				ttlCmd.SetVal(time.Hour)
+				rc.On("TTL", mock.Anything, "refresh-token:zxc-123").Return(ttlCmd)
+			},
+			wantRes: &model.IntrospectTokenResponse{
+				Active:    true,
+				TokenType: model.TokenTypeRefreshToken,
+				Sub:       "1",
+				Exp:       expiresAt.Unix(),
+				Jti:       "qwe-123",
+			},
+		},
+		{
+			name:  "error on find personal access token",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find personal access token: something error",
+		},
+		{
+			name:  "unknown personal access token",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).Return(nil, nil)
+			},
+			wantRes: inactive,
+		},
+		{
+			name:  "error on find personal access token user",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:  "personal access token of suspended user",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, SuspendedAt: &now}, nil)
+			},
+			wantRes: inactive,
+		},
+		{
+			name:  "error on find personal access token role",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Role: auth.RoleUser}, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to resolve user permissions: something error",
+		},
+		{
+			name:  "active personal access token",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Role: auth.RoleUser}, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+			},
+			wantRes: &model.IntrospectTokenResponse{
+				Active:    true,
+				TokenType: model.TokenTypePersonalAccessToken,
+				Scope:     "todos:read",
+				Sub:       "1",
+				Exp:       expiresAt.Unix(),
+				Iat:       now.Unix(),
+				Jti:       "5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jt := mocks.NewJWTToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			tt.mockFunc(rc, jt, ur, rr, pr)
+
+			usecase := usecase.NewOAuthUsecase(s.log, rc, jt, ur, rr, pr)
+			res, err := usecase.Introspect(s.ctx, &model.IntrospectTokenRequest{Token: tt.token})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+				return
+			}
+
+			s.Nil(err)
+			if res.TokenType == model.TokenTypeRefreshToken {
+				// the expiry is derived from the remaining ttl of the key
+				s.InDelta(tt.wantRes.Exp, res.Exp, 2)
+				res.Exp = tt.wantRes.Exp
+			}
+			s.Equal(tt.wantRes, res)
+		})
+	}
+}
+
+func (s *OAuthUsecaseSuite) TestOAuthUsecase_Revoke() {
+	now := time.Now()
+	claims := &auth.JWTClaims{
+		UserID: "1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			ID:        "asd-789",
+		},
+	}
+	stringCmd := func(val string) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		mockFunc   OAuthMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:  "invalid access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(nil, errors.New("token is expired"))
+			},
+		},
+		{
+			name:  "error on revoke access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(claims, nil)
+				cmd := redis.NewStatusCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).Return(cmd)
+			},
+			wantErrMsg: "failed to set revoke token: something error",
+		},
+		{
+			name:  "revoke access token",
+			token: "qwe.asd.zxc",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				jt.On("Parse", "qwe.asd.zxc").Return(claims, nil)
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+			},
+		},
+		{
+			name:  "unknown refresh token",
+			token: "zxc-123",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				cmd := redis.NewStringCmd(s.ctx)
+				cmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(cmd)
+			},
+		},
+		{
+			name:  "revoke refresh token without session",
+			token: "zxc-123",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(stringCmd("1"))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123").Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").Return(redis.NewIntCmd(s.ctx))
+			},
+		},
+		{
+			name:  "revoke refresh token with session",
+			token: "zxc-123",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("Get", mock.Anything, "refresh-token:zxc-123").Return(stringCmd("1:qwe-123"))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(stringCmd(fmt.Sprintf(
+					`{"id":"qwe-123","user_id":"1","refresh_token":"zxc-123","access_tokens":{"asd-789":%q},`+
+						`"created_at":"2025-08-13T10:00:00Z","last_used_at":"2025-08-13T10:00:00Z"}`,
+					now.Add(time.Minute).Format(time.RFC3339))))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123").Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-refresh-token:1", "zxc-123").Return(redis.NewIntCmd(s.ctx))
+				rc.On("Del", mock.Anything, "session:qwe-123").Return(redis.NewIntCmd(s.ctx))
+				rc.On("SRem", mock.Anything, "user-session:1", "qwe-123").Return(redis.NewIntCmd(s.ctx))
+			},
+		},
+		{
+			name:  "unknown personal access token",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).Return(nil, nil)
+			},
+		},
+		{
+			name:  "error on delete personal access token",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).
+					Return(&entity.PersonalAccessToken{ID: 5, UserID: 1}, nil)
+				pr.On("DeleteByID", mock.Anything, uint64(5)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete personal access token: something error",
+		},
+		{
+			name:  "revoke personal access token",
+			token: "pat_dummy-secret",
+			mockFunc: func(rc *mocks.RedisClient, jt *mocks.JWTToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, auth.HashToken("pat_dummy-secret")).
+					Return(&entity.PersonalAccessToken{ID: 5, UserID: 1}, nil)
+				pr.On("DeleteByID", mock.Anything, uint64(5)).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jt := mocks.NewJWTToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			tt.mockFunc(rc, jt, ur, rr, pr)
+
+			usecase := usecase.NewOAuthUsecase(s.log, rc, jt, ur, rr, pr)
+			err := usecase.Revoke(s.ctx, &model.RevokeTokenRequest{Token: tt.token, ClientID: "billing"})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestOAuthUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(OAuthUsecaseSuite))
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/storage"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func storeOIDCState(ctx context.Context, redisClient storage.RedisClient, token string, state *entity.OIDCState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	stateKey := fmt.Sprintf("%s:%s", auth.PrefixOIDCStateKey, token)
+	return redisClient.SetEx(ctx, stateKey, string(value), auth.OIDCStateTTL).Err()
+}
+
+// consumeOIDCState deletes the state while reading it, a callback can only be completed once
+func consumeOIDCState(ctx context.Context, redisClient storage.RedisClient, token string) (*entity.OIDCState, error) {
+	stateKey := fmt.Sprintf("%s:%s", auth.PrefixOIDCStateKey, token)
+	value, err := redisClient.GetDel(ctx, stateKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var state entity.OIDCState
+	err = json.Unmarshal([]byte(value), &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"errors"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/model"
+)
+
+func checkPasswordPolicy(policy *auth.PasswordPolicy, password string) error {
+	err := policy.Validate(password)
+	switch {
+	case errors.Is(err, auth.ErrPasswordTooShort):
+		return model.ErrPasswordTooShort
+	case errors.Is(err, auth.ErrPasswordTooWeak):
+		return model.ErrPasswordTooWeak
+	case errors.Is(err, auth.ErrPasswordTooCommon):
+		return model.ErrPasswordTooCommon
+	}
+
+	return err
+}
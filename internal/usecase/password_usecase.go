@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/db"
+	"go-api-example/internal/mail"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/model"
+	"go-api-example/internal/storage"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type passwordUsecase struct {
+	Log                   *zap.Logger
+	TX                    db.Transactioner
+	RedisClient           storage.RedisClient
+	Mailer                mail.Mailer
+	OpaqueToken           auth.OpaqueToken
+	PasswordHasher        auth.PasswordHasher
+	PasswordPolicy        *auth.PasswordPolicy
+	SecurityEventProducer *messaging.SecurityEventProducer
+	UserRepository        UserRepository
+	AppBaseURL            string
+}
+
+func NewPasswordUsecase(log *zap.Logger, tx db.Transactioner, redisClient storage.RedisClient, mailer mail.Mailer,
+	opaqueToken auth.OpaqueToken, passwordHasher auth.PasswordHasher, passwordPolicy *auth.PasswordPolicy,
+	securityEventProducer *messaging.SecurityEventProducer, userRepository UserRepository,
+	appBaseURL string) PasswordUsecase {
+	return &passwordUsecase{
+		Log:                   log,
+		TX:                    tx,
+		RedisClient:           redisClient,
+		Mailer:                mailer,
+		OpaqueToken:           opaqueToken,
+		PasswordHasher:        passwordHasher,
+		PasswordPolicy:        passwordPolicy,
+		SecurityEventProducer: securityEventProducer,
+		UserRepository:        userRepository,
+		AppBaseURL:            appBaseURL,
+	}
+}
+
+// Forgot answers the same way whether the email is registered or not, failures after the lookup are only logged
+// so the response can't be used to enumerate accounts
+func (c *passwordUsecase) Forgot(ctx context.Context, req *model.ForgotPasswordRequest) error {
+	user, err := c.UserRepository.FindByEmail(ctx, normalizeEmail(req.Email))
+	if err != nil {
+		return fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	if !user.IsEmailVerified() {
+		return nil
+	}
+
+	token, err := c.OpaqueToken.Create()
+	if err != nil {
+		c.Log.Warn("failed to create password reset token", zap.Error(err))
+		return nil
+	}
+
+	resetKey := fmt.Sprintf("%s:%s", auth.PrefixPasswordResetKey, auth.HashToken(token))
+	err = c.RedisClient.SetEx(ctx, resetKey, fmt.Sprint(user.ID), auth.PasswordResetTTL).Err()
+	if err != nil {
+		c.Log.Warn("failed to store password reset token", zap.Error(err))
+		return nil
+	}
+
+	// an untracked token would outlive the reset, so it is never mailed
+	userResetKey := fmt.Sprintf("%s:%d", auth.PrefixUserPasswordResetKey, user.ID)
+	err = c.RedisClient.SAdd(ctx, userResetKey, resetKey).Err()
+	if err == nil {
+		err = c.RedisClient.Expire(ctx, userResetKey, auth.PasswordResetTTL).Err()
+	}
+	if err != nil {
+		c.Log.Warn("failed to track password reset token", zap.Error(err))
+		return nil
+	}
+
+	err = c.Mailer.Send(ctx, &mail.Message{
+		To:      user.GetEmail(),
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to reset your password, it expires in 30 minutes.\n\n%s/reset-password?token=%s\n\n"+
+			"If you did not request a password reset, you can ignore this email.\n",
+			user.Username, c.AppBaseURL, token),
+	})
+	if err != nil {
+		c.Log.Warn("failed to send password reset email", zap.Error(err))
+		return nil
+	}
+
+	return nil
+}
+
+func (c *passwordUsecase) Reset(ctx context.Context, req *model.ResetPasswordRequest) error {
+	// the policy is checked before the token is consumed, so a rejected password can be retried with the same link
+	err := checkPasswordPolicy(c.PasswordPolicy, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	resetKey := fmt.Sprintf("%s:%s", auth.PrefixPasswordResetKey, auth.HashToken(req.Token))
+	value, err := c.RedisClient.GetDel(ctx, resetKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return model.ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	userID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return model.ErrInvalidPasswordResetToken
+	}
+
+	password, err := c.PasswordHasher.Hash(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	var tokenVersion uint64
+	err = c.TX.Do(ctx, func(exec db.Executor) error {
+		var txErr error
+		tokenVersion, txErr = c.UserRepository.ChangePasswordByID(ctx, exec, userID, password)
+		if txErr != nil {
+			// the user was deleted after the token was requested
+			if errors.Is(txErr, sql.ErrNoRows) {
+				return model.ErrInvalidPasswordResetToken
+			}
+			return fmt.Errorf("failed to change password: %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = invalidateTokens(ctx, c.RedisClient, userID, tokenVersion)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate tokens: %w", err)
+	}
+
+	err = c.revokeResetTokens(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke password reset tokens: %w", err)
+	}
+
+	sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+		Type:      model.SecurityEventPasswordChanged,
+		UserID:    userID,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		RequestID: req.RequestID,
+	})
+
+	return nil
+}
+
+// revokeResetTokens drops the other reset links the user requested, an older leaked link can't be used after a reset
+func (c *passwordUsecase) revokeResetTokens(ctx context.Context, userID uint64) error {
+	userResetKey := fmt.Sprintf("%s:%d", auth.PrefixUserPasswordResetKey, userID)
+	resetKeys, err := c.RedisClient.SMembers(ctx, userResetKey).Result()
+	if err != nil {
+		return err
+	}
+
+	return c.RedisClient.Del(ctx, append(resetKeys, userResetKey)...).Err()
+}
@@ -0,0 +1,354 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mail"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+const passwordResetKey = "password-reset-token:b5a2c96250612366ea272ffac6d9744aaf4b45aacd96aa7cfcb931ee3b558259"
+
+type PasswordUsecaseSuite struct {
+	suite.Suite
+	log            *zap.Logger
+	passwordHasher auth.PasswordHasher
+	passwordPolicy *auth.PasswordPolicy
+	ctx            context.Context
+}
+
+func (s *PasswordUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.passwordHasher, _ = auth.NewPasswordHasher(auth.PasswordHashConfig{
+		Algorithm:         auth.PasswordHashArgon2id,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+	s.passwordPolicy, _ = auth.NewPasswordPolicy(auth.PasswordPolicyConfig{MinLength: 8})
+	s.ctx = context.Background()
+}
+
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_Forgot() {
+	email := "johndoe@example.com"
+	now := time.Now()
+	request := &model.ForgotPasswordRequest{
+		Email: "JohnDoe@example.com",
+	}
+	user := &entity.User{
+		ID:              1,
+		Username:        "johndoe",
+		Email:           &email,
+		EmailVerifiedAt: &now,
+	}
+	mailMatcher := mock.MatchedBy(func(msg *mail.Message) bool {
+		return msg.To == email && msg.Subject == "Reset your password"
+	})
+
+	tests := []struct {
+		name       string
+		mockFunc   EmailMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error on find by email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by email: something error",
+		},
+		{
+			name: "unknown email",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on create token is not returned",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("", errors.New("something error"))
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on store token is not returned",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, passwordResetKey, "1", 30*time.Minute).Return(setCmd)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on track token is not returned",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, passwordResetKey, "1", 30*time.Minute).Return(redis.NewStatusCmd(s.ctx))
+				addCmd := redis.NewIntCmd(s.ctx)
+				addCmd.SetErr(errors.New("something error"))
+				rc.On("SAdd", mock.Anything, "user-password-reset-token:1", passwordResetKey).Return(addCmd)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on send mail is not returned",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, passwordResetKey, "1", 30*time.Minute).Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-password-reset-token:1", passwordResetKey).Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-password-reset-token:1", 30*time.Minute).Return(redis.NewBoolCmd(s.ctx))
+				m.On("Send", mock.Anything, mailMatcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository) {
+				ur.On("FindByEmail", mock.Anything, email).Return(user, nil)
+				ot.On("Create").Return("dummy", nil)
+				rc.On("SetEx", mock.Anything, passwordResetKey, "1", 30*time.Minute).Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SAdd", mock.Anything, "user-password-reset-token:1", passwordResetKey).Return(redis.NewIntCmd(s.ctx))
+				rc.On("Expire", mock.Anything, "user-password-reset-token:1", 30*time.Minute).Return(redis.NewBoolCmd(s.ctx))
+				m.On("Send", mock.Anything, mailMatcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			m := mocks.NewMailer(s.T())
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tx := mocks.NewTransactioner(s.T())
+			usecase := usecase.NewPasswordUsecase(s.log, tx, rc, m, ot, s.passwordHasher, s.passwordPolicy, nil, ur,
+				"http://localhost:8500")
+			tt.mockFunc(rc, m, ot, ur)
+
+			err := usecase.Forgot(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_Reset() {
+	request := &model.ResetPasswordRequest{
+		Token:       "dummy",
+		NewPassword: "newpassword",
+		UserAgent:   "curl/8.0",
+		IP:          "192.0.2.1",
+		RequestID:   "req-123",
+	}
+	passwordMatcher := mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$") && s.passwordHasher.Compare(hash, "newpassword") == nil
+	})
+	getCmd := func(val string, err error) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.ResetPasswordRequest
+		mockFunc   func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer)
+		wantErrMsg string
+	}{
+		{
+			name:    "error on password policy",
+			request: &model.ResetPasswordRequest{Token: "dummy", NewPassword: "password1"},
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+			},
+			wantErrMsg: "password is too common or known to be breached",
+		},
+		{
+			name:    "error token not found",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("", redis.Nil))
+			},
+			wantErrMsg: "invalid or expired password reset token",
+		},
+		{
+			name:    "error on get token",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("", errors.New("something error")))
+			},
+			wantErrMsg: "failed to get password reset token: something error",
+		},
+		{
+			name:    "error malformed token value",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("abc", nil))
+			},
+			wantErrMsg: "invalid or expired password reset token",
+		},
+		{
+			name:    "error on update password",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("1", nil))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), passwordMatcher).Return(uint64(0), errors.New("something error"))
+			},
+			wantErrMsg: "failed to change password: something error",
+		},
+		{
+			name:    "error user deleted",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("1", nil))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), passwordMatcher).Return(uint64(0), sql.ErrNoRows)
+			},
+			wantErrMsg: "invalid or expired password reset token",
+		},
+		{
+			name:    "error on cache token version",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("1", nil))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), passwordMatcher).Return(uint64(2), nil)
+				setCmd := redis.NewStatusCmd(s.ctx)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).Return(setCmd)
+			},
+			wantErrMsg: "failed to invalidate tokens: something error",
+		},
+		{
+			name:    "error on revoke sessions",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("1", nil))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), passwordMatcher).Return(uint64(2), nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				membersCmd := redis.NewStringSliceCmd(s.ctx)
+				membersCmd.SetErr(errors.New("something error"))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(membersCmd)
+			},
+			wantErrMsg: "failed to invalidate tokens: something error",
+		},
+		{
+			name:    "error on revoke reset tokens",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("1", nil))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), passwordMatcher).Return(uint64(2), nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(redis.NewStringSliceCmd(s.ctx))
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(redis.NewStringSliceCmd(s.ctx))
+				rc.On("Del", mock.Anything, "user-refresh-token:1", "user-session:1").Return(redis.NewIntCmd(s.ctx))
+				resetCmd := redis.NewStringSliceCmd(s.ctx)
+				resetCmd.SetErr(errors.New("something error"))
+				rc.On("SMembers", mock.Anything, "user-password-reset-token:1").Return(resetCmd)
+			},
+			wantErrMsg: "failed to revoke password reset tokens: something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, m *mocks.Mailer, ot *mocks.OpaqueToken, ur *mocks.UserRepository, tx *mocks.Transactioner, k *mocks.KafkaProducer) {
+				rc.On("GetDel", mock.Anything, passwordResetKey).Return(getCmd("1", nil))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), passwordMatcher).Return(uint64(2), nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				membersCmd := redis.NewStringSliceCmd(s.ctx)
+				membersCmd.SetVal([]string{"zxc-123"})
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(membersCmd)
+				sessionsCmd := redis.NewStringSliceCmd(s.ctx)
+				sessionsCmd.SetVal([]string{"qwe-123"})
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(sessionsCmd)
+				sessionCmd := redis.NewStringCmd(s.ctx)
+				sessionCmd.SetVal(fmt.Sprintf(`{"id":"qwe-123","user_id":"1","refresh_token":"zxc-123","access_tokens":{"asd-789":%q}}`,
+					time.Now().Add(time.Minute).Format(time.RFC3339)))
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd)
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "session:qwe-123", "user-refresh-token:1", "user-session:1").
+					Return(redis.NewIntCmd(s.ctx))
+				resetCmd := redis.NewStringSliceCmd(s.ctx)
+				resetCmd.SetVal([]string{passwordResetKey, "password-reset-token:older"})
+				rc.On("SMembers", mock.Anything, "user-password-reset-token:1").Return(resetCmd)
+				rc.On("Del", mock.Anything, passwordResetKey, "password-reset-token:older", "user-password-reset-token:1").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					event := new(model.SecurityEvent)
+					_ = json.Unmarshal(msg.Value, event)
+					return *msg.TopicPartition.Topic == "security-event" && event.Type == model.SecurityEventPasswordChanged &&
+						event.UserID == 1 && event.IP == "192.0.2.1" && event.UserAgent == "curl/8.0" && event.RequestID == "req-123"
+				}), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			m := mocks.NewMailer(s.T())
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tx := mocks.NewTransactioner(s.T())
+			k := mocks.NewKafkaProducer(s.T())
+			securityEventProducer := messaging.NewSecurityEventProducer(s.log, k, "security-event")
+			usecase := usecase.NewPasswordUsecase(s.log, tx, rc, m, ot, s.passwordHasher, s.passwordPolicy,
+				securityEventProducer, ur, "http://localhost:8500")
+			tt.mockFunc(rc, m, ot, ur, tx, k)
+
+			err := usecase.Reset(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestPasswordUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordUsecaseSuite))
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+type personalAccessTokenUsecase struct {
+	Log                           *zap.Logger
+	OpaqueToken                   auth.OpaqueToken
+	UserRepository                UserRepository
+	RoleRepository                RoleRepository
+	PersonalAccessTokenRepository PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenUsecase(log *zap.Logger, opaqueToken auth.OpaqueToken, userRepository UserRepository,
+	roleRepository RoleRepository, personalAccessTokenRepository PersonalAccessTokenRepository) PersonalAccessTokenUsecase {
+	return &personalAccessTokenUsecase{
+		Log:                           log,
+		OpaqueToken:                   opaqueToken,
+		UserRepository:                userRepository,
+		RoleRepository:                roleRepository,
+		PersonalAccessTokenRepository: personalAccessTokenRepository,
+	}
+}
+
+// Create only hands out scopes the user role grants, the plain token is returned once and only its hash is stored
+func (c *personalAccessTokenUsecase) Create(ctx context.Context, req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, error) {
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user permissions: %w", err)
+	}
+
+	for _, scope := range req.Scopes {
+		if !slices.Contains(auth.PersonalAccessTokenScopes, scope) || !auth.GrantsPermission(subject.Permissions, scope) {
+			return nil, model.ErrScopeNotGranted
+		}
+	}
+
+	secret, err := c.OpaqueToken.Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+	plain := auth.PersonalAccessTokenPrefix + secret
+
+	token := &entity.PersonalAccessToken{
+		UserID:    user.ID,
+		Name:      req.Name,
+		TokenHash: auth.HashToken(plain),
+		Scopes:    req.Scopes,
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	err = c.PersonalAccessTokenRepository.Create(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store personal access token: %w", err)
+	}
+
+	return &model.CreatePersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: *serializer.PersonalAccessTokenToResponse(token),
+		Token:                       plain,
+	}, nil
+}
+
+func (c *personalAccessTokenUsecase) List(ctx context.Context, req *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error) {
+	tokens, err := c.PersonalAccessTokenRepository.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+
+	return serializer.ListPersonalAccessTokenToResponse(tokens), nil
+}
+
+func (c *personalAccessTokenUsecase) Revoke(ctx context.Context, req *model.RevokePersonalAccessTokenRequest) error {
+	token, err := c.PersonalAccessTokenRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find personal access token by id: %w", err)
+	}
+
+	if token == nil {
+		return model.ErrAccessTokenNotFound
+	}
+
+	if token.UserID != req.UserID {
+		return model.ErrForbidden
+	}
+
+	err = c.PersonalAccessTokenRepository.DeleteByID(ctx, token.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access token: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a personal access token into claims, the permissions are the token scopes the user role still grants
+func (c *personalAccessTokenUsecase) Authenticate(ctx context.Context, plain string) (*auth.JWTClaims, error) {
+	if !strings.HasPrefix(plain, auth.PersonalAccessTokenPrefix) {
+		return nil, model.ErrInvalidAuthToken
+	}
+
+	token, err := c.PersonalAccessTokenRepository.FindByTokenHash(ctx, auth.HashToken(plain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find personal access token: %w", err)
+	}
+
+	now := time.Now()
+	if token == nil || token.IsExpired(now) {
+		return nil, model.ErrInvalidAuthToken
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrInvalidAuthToken
+	}
+	if user.IsSuspended() {
+		return nil, model.ErrUserSuspended
+	}
+
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user permissions: %w", err)
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= auth.PersonalAccessTokenLastUsedInterval {
+		err = c.PersonalAccessTokenRepository.UpdateLastUsedAtByID(ctx, token.ID, now)
+		if err != nil {
+			c.Log.Warn("failed to update personal access token last used at", zap.Error(err))
+		}
+	}
+
+	claims := &auth.JWTClaims{
+		UserID:                subject.UserID,
+		Role:                  subject.Role,
+		Permissions:           personalAccessTokenPermissions(subject, token.Scopes),
+		PersonalAccessTokenID: token.ID,
+	}
+	if token.ExpiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*token.ExpiresAt)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,464 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type PersonalAccessTokenMockFunc func(
+	ot *mocks.OpaqueToken,
+	ur *mocks.UserRepository,
+	rr *mocks.RoleRepository,
+	pr *mocks.PersonalAccessTokenRepository,
+)
+
+func (s *PersonalAccessTokenUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_Create() {
+	user := &entity.User{ID: 1, Username: "johndoe", Role: auth.RoleUser}
+	role := &entity.Role{Name: auth.RoleUser, Permissions: []string{auth.PermissionTodoRead, auth.PermissionTodoWrite}}
+	expiresInDays := 30
+
+	tests := []struct {
+		name       string
+		request    *model.CreatePersonalAccessTokenRequest
+		mockFunc   PersonalAccessTokenMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find user",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:    "error user not found",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name:    "error on find role",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to resolve user permissions: something error",
+		},
+		{
+			name:    "error scope not granted",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead, auth.PermissionUserRead}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+			},
+			wantErrMsg: "scope not granted to user role",
+		},
+		{
+			name:    "error scope not allowed for personal access token",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionUserManage}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(&entity.Role{Name: auth.RoleUser, Permissions: []string{auth.PermissionAll}}, nil)
+			},
+			wantErrMsg: "scope not granted to user role",
+		},
+		{
+			name:    "error on create token",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+				ot.On("Create").Return("", errors.New("something error"))
+			},
+			wantErrMsg: "failed to create personal access token: something error",
+		},
+		{
+			name:    "error on store token",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+				ot.On("Create").Return("dummy-secret", nil)
+				pr.On("Create", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store personal access token: something error",
+		},
+		{
+			name:    "success",
+			request: &model.CreatePersonalAccessTokenRequest{UserID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}, ExpiresInDays: &expiresInDays},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+				ot.On("Create").Return("dummy-secret", nil)
+				pr.On("Create", mock.Anything, mock.MatchedBy(func(t *entity.PersonalAccessToken) bool {
+					return t.UserID == 1 && t.Name == "ci" && t.TokenHash == auth.HashToken("pat_dummy-secret") &&
+						t.ExpiresAt != nil && t.ExpiresAt.After(time.Now().AddDate(0, 0, 29))
+				})).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, ot, ur, rr, pr)
+			tt.mockFunc(ot, ur, rr, pr)
+
+			res, err := usecase.Create(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("pat_dummy-secret", res.Token)
+				s.Equal("ci", res.Name)
+				s.Equal([]string{auth.PermissionTodoRead}, res.Scopes)
+				s.NotNil(res.ExpiresAt)
+			}
+		})
+	}
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_List() {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		mockFunc   PersonalAccessTokenMockFunc
+		wantRes    []model.PersonalAccessTokenResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on list",
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("ListByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to list personal access tokens: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("ListByUserID", mock.Anything, uint64(1)).Return([]entity.PersonalAccessToken{
+					{ID: 1, UserID: 1, Name: "ci", TokenHash: "dummy-hash", Scopes: []string{auth.PermissionTodoRead}, CreatedAt: now},
+				}, nil)
+			},
+			wantRes: []model.PersonalAccessTokenResponse{
+				{ID: 1, Name: "ci", Scopes: []string{auth.PermissionTodoRead}, CreatedAt: now.Format(time.RFC3339)},
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, ot, ur, rr, pr)
+			tt.mockFunc(ot, ur, rr, pr)
+
+			res, err := usecase.List(s.ctx, &model.ListPersonalAccessTokenRequest{UserID: 1})
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_Revoke() {
+	token := &entity.PersonalAccessToken{ID: 1, UserID: 1, Name: "ci"}
+
+	tests := []struct {
+		name       string
+		request    *model.RevokePersonalAccessTokenRequest
+		mockFunc   PersonalAccessTokenMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find token",
+			request: &model.RevokePersonalAccessTokenRequest{ID: 1, UserID: 1},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find personal access token by id: something error",
+		},
+		{
+			name:    "error token not found",
+			request: &model.RevokePersonalAccessTokenRequest{ID: 1, UserID: 1},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "personal access token not found",
+		},
+		{
+			name:    "error token of another user",
+			request: &model.RevokePersonalAccessTokenRequest{ID: 1, UserID: 2},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByID", mock.Anything, uint64(1)).Return(token, nil)
+			},
+			wantErrMsg: "forbidden",
+		},
+		{
+			name:    "error on delete token",
+			request: &model.RevokePersonalAccessTokenRequest{ID: 1, UserID: 1},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByID", mock.Anything, uint64(1)).Return(token, nil)
+				pr.On("DeleteByID", mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete personal access token: something error",
+		},
+		{
+			name:    "success",
+			request: &model.RevokePersonalAccessTokenRequest{ID: 1, UserID: 1},
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByID", mock.Anything, uint64(1)).Return(token, nil)
+				pr.On("DeleteByID", mock.Anything, uint64(1)).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, ot, ur, rr, pr)
+			tt.mockFunc(ot, ur, rr, pr)
+
+			err := usecase.Revoke(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_Authenticate() {
+	now := time.Now()
+	recent := now.Add(-10 * time.Second)
+	past := now.Add(-1 * time.Hour)
+	future := now.Add(1 * time.Hour)
+	plain := "pat_dummy-secret"
+	hash := auth.HashToken(plain)
+	user := &entity.User{ID: 1, Username: "johndoe", Role: auth.RoleUser}
+	role := &entity.Role{Name: auth.RoleUser, Permissions: []string{auth.PermissionTodoRead, auth.PermissionNotificationRead}}
+	token := &entity.PersonalAccessToken{
+		ID:         5,
+		UserID:     1,
+		Name:       "ci",
+		TokenHash:  hash,
+		Scopes:     []string{auth.PermissionTodoRead, auth.PermissionTodoWrite},
+		LastUsedAt: &recent,
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		mockFunc    PersonalAccessTokenMockFunc
+		wantClaims  *auth.JWTClaims
+		wantErrMsg  string
+		wantExpires bool
+	}{
+		{
+			name:  "error without prefix",
+			token: "dummy-secret",
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:  "error on find token",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find personal access token: something error",
+		},
+		{
+			name:  "error token not found",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(nil, nil)
+			},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:  "error token expired",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(&entity.PersonalAccessToken{ID: 5, UserID: 1, ExpiresAt: &past}, nil)
+			},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:  "error on find user",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:  "error user not found",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "invalid auth token",
+		},
+		{
+			name:  "error user suspended",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).
+					Return(&entity.User{ID: 1, Username: "johndoe", Role: auth.RoleUser, SuspendedAt: &past}, nil)
+			},
+			wantErrMsg: "account suspended",
+		},
+		{
+			name:  "error on find role",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to resolve user permissions: something error",
+		},
+		{
+			name:  "success drops scopes the role no longer grants",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(token, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+			},
+			wantClaims: &auth.JWTClaims{
+				UserID:                "1",
+				Role:                  auth.RoleUser,
+				Permissions:           []string{auth.PermissionTodoRead},
+				PersonalAccessTokenID: 5,
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:  "success updates last used at",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(&entity.PersonalAccessToken{
+					ID:        5,
+					UserID:    1,
+					Scopes:    []string{auth.PermissionTodoRead},
+					ExpiresAt: &future,
+				}, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+				pr.On("UpdateLastUsedAtByID", mock.Anything, uint64(5), mock.Anything).Return(nil)
+			},
+			wantClaims: &auth.JWTClaims{
+				UserID:                "1",
+				Role:                  auth.RoleUser,
+				Permissions:           []string{auth.PermissionTodoRead},
+				PersonalAccessTokenID: 5,
+			},
+			wantErrMsg:  "",
+			wantExpires: true,
+		},
+		{
+			name:  "success ignores error on update last used at",
+			token: plain,
+			mockFunc: func(ot *mocks.OpaqueToken, ur *mocks.UserRepository, rr *mocks.RoleRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, hash).Return(&entity.PersonalAccessToken{
+					ID:         5,
+					UserID:     1,
+					Scopes:     []string{auth.PermissionTodoRead},
+					LastUsedAt: &past,
+				}, nil)
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, auth.RoleUser).Return(role, nil)
+				pr.On("UpdateLastUsedAtByID", mock.Anything, uint64(5), mock.Anything).Return(errors.New("something error"))
+			},
+			wantClaims: &auth.JWTClaims{
+				UserID:                "1",
+				Role:                  auth.RoleUser,
+				Permissions:           []string{auth.PermissionTodoRead},
+				PersonalAccessTokenID: 5,
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ot := mocks.NewOpaqueToken(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			rr := mocks.NewRoleRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, ot, ur, rr, pr)
+			tt.mockFunc(ot, ur, rr, pr)
+
+			res, err := usecase.Authenticate(s.ctx, tt.token)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+				return
+			}
+
+			s.Nil(err)
+			s.Equal(tt.wantExpires, res.ExpiresAt != nil)
+			res.ExpiresAt = nil
+			s.Equal(tt.wantClaims, res)
+			s.True(res.IsPersonalAccessToken())
+		})
+	}
+}
+
+func TestPersonalAccessTokenUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenUsecaseSuite))
+}
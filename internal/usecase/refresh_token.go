@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/storage"
+	"strings"
+	"time"
+)
+
+// every refresh token is also indexed in a per user set, so all sessions of a user can be revoked at once
+
+func storeRefreshToken(ctx context.Context, redisClient storage.RedisClient, userID string, sessionID string, refreshToken string) error {
+	refreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, refreshToken)
+	err := redisClient.SetEx(ctx, refreshKey, fmt.Sprintf("%s:%s", userID, sessionID), auth.RefreshTTL).Err()
+	if err != nil {
+		return err
+	}
+
+	userRefreshKey := fmt.Sprintf("%s:%s", auth.PrefixUserRefreshKey, userID)
+	err = redisClient.SAdd(ctx, userRefreshKey, refreshToken).Err()
+	if err != nil {
+		return err
+	}
+
+	return redisClient.Expire(ctx, userRefreshKey, auth.RefreshTTL).Err()
+}
+
+// parseRefreshValue splits the stored "<user id>:<session id>" value, tokens issued before sessions existed
+// only hold the user id
+func parseRefreshValue(value string) (string, string) {
+	userID, sessionID, _ := strings.Cut(value, ":")
+	return userID, sessionID
+}
+
+func deleteRefreshToken(ctx context.Context, redisClient storage.RedisClient, userID string, refreshToken string) {
+	refreshKey := fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, refreshToken)
+	userRefreshKey := fmt.Sprintf("%s:%s", auth.PrefixUserRefreshKey, userID)
+
+	_ = redisClient.Del(ctx, refreshKey)
+	_ = redisClient.SRem(ctx, userRefreshKey, refreshToken)
+}
+
+// claimRefreshToken sets the rotated marker before the refresh token is used, of concurrent uses of the same token
+// only one wins the claim and the others are caught as a reuse
+func claimRefreshToken(ctx context.Context, redisClient storage.RedisClient, refreshToken string, value string) (bool, error) {
+	rotatedKey := fmt.Sprintf("%s:%s", auth.PrefixRotatedRefreshKey, refreshToken)
+	return redisClient.SetNX(ctx, rotatedKey, value, auth.RefreshTTL).Result()
+}
+
+// rotateRefreshToken replaces the refresh token by a marker pointing to its session, so a later reuse of the
+// rotated token can be traced back to the session
+func rotateRefreshToken(ctx context.Context, redisClient storage.RedisClient, userID string, sessionID string, refreshToken string) error {
+	rotatedKey := fmt.Sprintf("%s:%s", auth.PrefixRotatedRefreshKey, refreshToken)
+	err := redisClient.SetEx(ctx, rotatedKey, fmt.Sprintf("%s:%s", userID, sessionID), auth.RefreshTTL).Err()
+	if err != nil {
+		return err
+	}
+
+	deleteRefreshToken(ctx, redisClient, userID, refreshToken)
+
+	return nil
+}
+
+func revokeAllSessions(ctx context.Context, redisClient storage.RedisClient, userID string) error {
+	userRefreshKey := fmt.Sprintf("%s:%s", auth.PrefixUserRefreshKey, userID)
+	refreshTokens, err := redisClient.SMembers(ctx, userRefreshKey).Result()
+	if err != nil {
+		return err
+	}
+
+	userSessionKey := fmt.Sprintf("%s:%s", auth.PrefixUserSessionKey, userID)
+	sessionIDs, err := redisClient.SMembers(ctx, userSessionKey).Result()
+	if err != nil {
+		return err
+	}
+
+	// the access tokens still alive on other devices are revoked before their sessions are forgotten
+	for _, sessionID := range sessionIDs {
+		session, err := findSession(ctx, redisClient, sessionID)
+		if err != nil {
+			return err
+		}
+
+		if session == nil {
+			continue
+		}
+
+		err = revokeSessionAccessTokens(ctx, redisClient, session)
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(refreshTokens)+len(sessionIDs)+2)
+	for _, refreshToken := range refreshTokens {
+		keys = append(keys, fmt.Sprintf("%s:%s", auth.PrefixRefreshKey, refreshToken))
+	}
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, fmt.Sprintf("%s:%s", auth.PrefixSessionKey, sessionID))
+	}
+	keys = append(keys, userRefreshKey, userSessionKey)
+
+	return redisClient.Del(ctx, keys...).Err()
+}
+
+func revokeAccessToken(ctx context.Context, redisClient storage.RedisClient, claims *auth.JWTClaims) error {
+	revokeKey := fmt.Sprintf("%s:%s", auth.PrefixRevokeKey, claims.ID)
+	revokeTTL := time.Until(claims.ExpiresAt.Time)
+
+	return redisClient.SetEx(ctx, revokeKey, "true", revokeTTL).Err()
+}
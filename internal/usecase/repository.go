@@ -5,6 +5,7 @@ import (
 	"go-api-example/internal/db"
 	"go-api-example/internal/entity"
 	"go-api-example/internal/model"
+	"time"
 )
 
 //go:generate mockery --name=UserRepository --structname UserRepository --outpkg=mocks --output=./../mocks
@@ -12,15 +13,93 @@ type UserRepository interface {
 	Create(ctx context.Context, exec db.Executor, user *entity.User) error
 	List(ctx context.Context, req *model.SearchUserRequest) ([]entity.User, int, error)
 	FindByID(ctx context.Context, id uint64) (*entity.User, error)
+	FindDiscoverableByID(ctx context.Context, viewerID uint64, id uint64) (*entity.User, error)
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	UpdateByID(ctx context.Context, req *model.UpdateUserRequest) error
-	CountByUsername(ctx context.Context, username string) (int, error)
+	ChangePasswordByID(ctx context.Context, exec db.Executor, id uint64, password string) (uint64, error)
+	UpdateProfile(ctx context.Context, exec db.Executor, user *entity.User) error
+	UpdateAvatarByID(ctx context.Context, exec db.Executor, id uint64, avatarKey *string) error
+	UpdateEmailByID(ctx context.Context, id uint64, email string, verifiedAt time.Time) error
+	SuspendByID(ctx context.Context, exec db.Executor, id uint64, suspendedAt time.Time) (uint64, error)
+	UnsuspendByID(ctx context.Context, exec db.Executor, id uint64) error
+	RequirePasswordResetByID(ctx context.Context, exec db.Executor, id uint64, requiredAt time.Time) (uint64, error)
+	DeleteByID(ctx context.Context, exec db.Executor, id uint64) error
+}
+
+//go:generate mockery --name=RoleRepository --structname RoleRepository --outpkg=mocks --output=./../mocks
+type RoleRepository interface {
+	FindByName(ctx context.Context, name string) (*entity.Role, error)
+}
+
+//go:generate mockery --name=TOTPRepository --structname TOTPRepository --outpkg=mocks --output=./../mocks
+type TOTPRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*entity.TOTP, error)
+	Upsert(ctx context.Context, totp *entity.TOTP) error
+	ConfirmByUserID(ctx context.Context, exec db.Executor, userID uint64, confirmedAt time.Time) error
+	ReplaceRecoveryCodes(ctx context.Context, exec db.Executor, userID uint64, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error)
+	DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error
+}
+
+//go:generate mockery --name=PersonalAccessTokenRepository --structname PersonalAccessTokenRepository --outpkg=mocks --output=./../mocks
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *entity.PersonalAccessToken) error
+	ListByUserID(ctx context.Context, userID uint64) ([]entity.PersonalAccessToken, error)
+	FindByID(ctx context.Context, id uint64) (*entity.PersonalAccessToken, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error)
+	UpdateLastUsedAtByID(ctx context.Context, id uint64, lastUsedAt time.Time) error
+	DeleteByID(ctx context.Context, id uint64) error
+	DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error
+}
+
+//go:generate mockery --name=UserIdentityRepository --structname UserIdentityRepository --outpkg=mocks --output=./../mocks
+type UserIdentityRepository interface {
+	FindByProviderSubject(ctx context.Context, provider string, subject string) (*entity.UserIdentity, error)
+	ListByUserID(ctx context.Context, userID uint64) ([]entity.UserIdentity, error)
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+	DeleteByUserIDProvider(ctx context.Context, userID uint64, provider string) (bool, error)
+	DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error
+}
+
+//go:generate mockery --name=ImpersonationLogRepository --structname ImpersonationLogRepository --outpkg=mocks --output=./../mocks
+type ImpersonationLogRepository interface {
+	Create(ctx context.Context, log *entity.ImpersonationLog) error
+}
+
+//go:generate mockery --name=AdminAuditLogRepository --structname AdminAuditLogRepository --outpkg=mocks --output=./../mocks
+type AdminAuditLogRepository interface {
+	Create(ctx context.Context, exec db.Executor, log *entity.AdminAuditLog) error
+}
+
+//go:generate mockery --name=SecurityEventRepository --structname SecurityEventRepository --outpkg=mocks --output=./../mocks
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event *entity.SecurityEvent) error
+	List(ctx context.Context, req *model.SearchSecurityEventRequest) ([]entity.SecurityEvent, int, error)
+	DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error
 }
 
 //go:generate mockery --name=TodoRepository --structname TodoRepository --outpkg=mocks --output=./../mocks
 type TodoRepository interface {
 	Create(ctx context.Context, user *entity.Todo) error
 	List(ctx context.Context, req *model.SearchTodoRequest) ([]entity.Todo, int, error)
+	Board(ctx context.Context, req *model.BoardTodoRequest) ([]entity.Todo, map[entity.TodoStatus]int, error)
 	FindByID(ctx context.Context, id uint64) (*entity.Todo, error)
 	UpdateByID(ctx context.Context, req *model.UpdateTodoRequest) error
+	UpdateStatusByID(ctx context.Context, id uint64, status entity.TodoStatus) error
+	UpdateAssigneeByID(ctx context.Context, todo *entity.Todo) error
+	DeleteByID(ctx context.Context, id uint64) error
+	DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error
+	UnassignByAssigneeID(ctx context.Context, exec db.Executor, assigneeID uint64) error
+}
+
+//go:generate mockery --name=NotificationRepository --structname NotificationRepository --outpkg=mocks --output=./../mocks
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entity.Notification) error
+	List(ctx context.Context, req *model.SearchNotificationRequest) ([]entity.Notification, int, error)
+	CountUnreadByUserID(ctx context.Context, userID uint64) (int, error)
+	FindByID(ctx context.Context, id uint64) (*entity.Notification, error)
+	MarkReadByID(ctx context.Context, id uint64) error
+	MarkAllReadByUserID(ctx context.Context, userID uint64) error
+	DeleteByUserID(ctx context.Context, exec db.Executor, userID uint64) error
 }
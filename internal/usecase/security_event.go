@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"fmt"
+	"go-api-example/internal/messaging"
+	"go-api-example/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// sendSecurityEvent publishes an event for the security log of the user and for siem ingestion. A failure is only
+// logged, an unavailable broker must not fail the login or logout that caused the event
+func sendSecurityEvent(log *zap.Logger, producer *messaging.SecurityEventProducer, event *model.SecurityEvent) {
+	event.ID = uuid.NewString()
+	event.OccurredAt = time.Now().Format(time.RFC3339)
+
+	err := producer.Send(event)
+	if err != nil {
+		log.Warn(fmt.Sprintf("failed to send security event: %+v", err),
+			zap.String("type", event.Type),
+			zap.Uint64("user_id", event.UserID),
+		)
+	}
+}
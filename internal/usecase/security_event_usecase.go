@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/model/serializer"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// securityEventUserAgentMaxLength is the size of the user_agent column, longer user agents are cut instead of
+// failing the insert
+const securityEventUserAgentMaxLength = 512
+
+type securityEventUsecase struct {
+	Log                     *zap.Logger
+	SecurityEventRepository SecurityEventRepository
+}
+
+func NewSecurityEventUsecase(log *zap.Logger, securityEventRepository SecurityEventRepository) SecurityEventUsecase {
+	return &securityEventUsecase{
+		Log:                     log,
+		SecurityEventRepository: securityEventRepository,
+	}
+}
+
+func (c *securityEventUsecase) Create(ctx context.Context, event *model.SecurityEvent) error {
+	occurredAt, err := time.Parse(time.RFC3339, event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to parse occurred at: %w", err)
+	}
+
+	userAgent := []rune(event.UserAgent)
+	if len(userAgent) > securityEventUserAgentMaxLength {
+		userAgent = userAgent[:securityEventUserAgentMaxLength]
+	}
+
+	err = c.SecurityEventRepository.Create(ctx, &entity.SecurityEvent{
+		EventID:    event.ID,
+		UserID:     event.UserID,
+		Type:       event.Type,
+		SessionID:  event.SessionID,
+		IP:         event.IP,
+		UserAgent:  string(userAgent),
+		RequestID:  event.RequestID,
+		OccurredAt: occurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+
+	return nil
+}
+
+func (c *securityEventUsecase) List(ctx context.Context, req *model.SearchSecurityEventRequest) ([]model.SecurityEventResponse, int, error) {
+	events, total, err := c.SecurityEventRepository.List(ctx, req)
+	if err != nil {
+		return []model.SecurityEventResponse{}, 0, fmt.Errorf("failed to get security events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return []model.SecurityEventResponse{}, total, nil
+	}
+
+	return serializer.ListSecurityEventToResponse(events), total, nil
+}
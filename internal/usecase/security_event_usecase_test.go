@@ -0,0 +1,179 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SecurityEventUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+func (s *SecurityEventUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *SecurityEventUsecaseSuite) TestSecurityEventUsecase_Create() {
+	occurredAt := time.Date(2026, 10, 18, 9, 20, 0, 0, time.UTC)
+	request := &model.SecurityEvent{
+		ID:         "event-123",
+		Type:       model.SecurityEventLogin,
+		UserID:     1,
+		SessionID:  "qwe-123",
+		IP:         "127.0.0.1",
+		UserAgent:  "curl/8.0",
+		RequestID:  "req-123",
+		OccurredAt: occurredAt.Format(time.RFC3339),
+	}
+	matcher := mock.MatchedBy(func(e *entity.SecurityEvent) bool {
+		return e.EventID == "event-123" && e.UserID == 1 && e.Type == "login" && e.SessionID == "qwe-123" &&
+			e.IP == "127.0.0.1" && e.UserAgent == "curl/8.0" && e.RequestID == "req-123" && e.OccurredAt.Equal(occurredAt)
+	})
+
+	tests := []struct {
+		name       string
+		request    *model.SecurityEvent
+		mockFunc   func(r *mocks.SecurityEventRepository)
+		wantErrMsg string
+	}{
+		{
+			name:       "error on parse occurred at",
+			request:    &model.SecurityEvent{ID: "event-123", Type: model.SecurityEventLogin, UserID: 1, OccurredAt: "yesterday"},
+			mockFunc:   func(r *mocks.SecurityEventRepository) {},
+			wantErrMsg: `failed to parse occurred at: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			name:    "error on create",
+			request: request,
+			mockFunc: func(r *mocks.SecurityEventRepository) {
+				r.On("Create", mock.Anything, matcher).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create security event: something error",
+		},
+		{
+			name: "success with long user agent",
+			request: &model.SecurityEvent{
+				ID:         "event-123",
+				Type:       model.SecurityEventLogin,
+				UserID:     1,
+				UserAgent:  strings.Repeat("a", 600),
+				OccurredAt: occurredAt.Format(time.RFC3339),
+			},
+			mockFunc: func(r *mocks.SecurityEventRepository) {
+				r.On("Create", mock.Anything, mock.MatchedBy(func(e *entity.SecurityEvent) bool {
+					return e.UserAgent == strings.Repeat("a", 512)
+				})).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(r *mocks.SecurityEventRepository) {
+				r.On("Create", mock.Anything, matcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ser := mocks.NewSecurityEventRepository(s.T())
+			tt.mockFunc(ser)
+
+			u := usecase.NewSecurityEventUsecase(s.log, ser)
+			err := u.Create(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *SecurityEventUsecaseSuite) TestSecurityEventUsecase_List() {
+	now := time.Now()
+	request := &model.SearchSecurityEventRequest{
+		UserID: 1,
+		Limit:  10,
+		Offset: 0,
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.SecurityEventRepository)
+		wantRes    []model.SecurityEventResponse
+		wantTotal  int
+		wantErrMsg string
+	}{
+		{
+			name: "error on list",
+			mockFunc: func(r *mocks.SecurityEventRepository) {
+				r.On("List", mock.Anything, request).Return(nil, 0, errors.New("something error"))
+			},
+			wantRes:    []model.SecurityEventResponse{},
+			wantErrMsg: "failed to get security events: something error",
+		},
+		{
+			name: "success with empty result",
+			mockFunc: func(r *mocks.SecurityEventRepository) {
+				r.On("List", mock.Anything, request).Return([]entity.SecurityEvent{}, 0, nil)
+			},
+			wantRes:    []model.SecurityEventResponse{},
+			wantErrMsg: "",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.SecurityEventRepository) {
+				r.On("List", mock.Anything, request).Return([]entity.SecurityEvent{
+					{ID: 2, UserID: 1, Type: "logout", SessionID: "qwe-123", IP: "127.0.0.1", UserAgent: "curl/8.0", OccurredAt: now},
+					{ID: 1, UserID: 1, Type: "login_failed", IP: "127.0.0.1", UserAgent: "curl/8.0", RequestID: "req-123", OccurredAt: now},
+				}, 2, nil)
+			},
+			wantRes: []model.SecurityEventResponse{
+				{ID: 2, Type: "logout", SessionID: "qwe-123", IP: "127.0.0.1", UserAgent: "curl/8.0", OccurredAt: now.Format(time.RFC3339)},
+				{ID: 1, Type: "login_failed", IP: "127.0.0.1", UserAgent: "curl/8.0", RequestID: "req-123", OccurredAt: now.Format(time.RFC3339)},
+			},
+			wantTotal:  2,
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ser := mocks.NewSecurityEventRepository(s.T())
+			tt.mockFunc(ser)
+
+			u := usecase.NewSecurityEventUsecase(s.log, ser)
+			res, total, err := u.List(s.ctx, request)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantTotal, total)
+			if tt.wantErrMsg != "" {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestSecurityEventUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventUsecaseSuite))
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/storage"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func storeSession(ctx context.Context, redisClient storage.RedisClient, session *entity.Session) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	sessionKey := fmt.Sprintf("%s:%s", auth.PrefixSessionKey, session.ID)
+	err = redisClient.SetEx(ctx, sessionKey, string(value), auth.RefreshTTL).Err()
+	if err != nil {
+		return err
+	}
+
+	userSessionKey := fmt.Sprintf("%s:%s", auth.PrefixUserSessionKey, session.UserID)
+	err = redisClient.SAdd(ctx, userSessionKey, session.ID).Err()
+	if err != nil {
+		return err
+	}
+
+	return redisClient.Expire(ctx, userSessionKey, auth.RefreshTTL).Err()
+}
+
+func findSession(ctx context.Context, redisClient storage.RedisClient, sessionID string) (*entity.Session, error) {
+	sessionKey := fmt.Sprintf("%s:%s", auth.PrefixSessionKey, sessionID)
+	value, err := redisClient.Get(ctx, sessionKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var session entity.Session
+	err = json.Unmarshal([]byte(value), &session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// listSessions returns the sessions ordered by last use, expired sessions are dropped from the index on the way
+func listSessions(ctx context.Context, redisClient storage.RedisClient, userID string) ([]entity.Session, error) {
+	userSessionKey := fmt.Sprintf("%s:%s", auth.PrefixUserSessionKey, userID)
+	sessionIDs, err := redisClient.SMembers(ctx, userSessionKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]entity.Session, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		session, err := findSession(ctx, redisClient, sessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if session == nil {
+			_ = redisClient.SRem(ctx, userSessionKey, sessionID)
+			continue
+		}
+		sessions = append(sessions, *session)
+	}
+
+	slices.SortFunc(sessions, func(a, b entity.Session) int {
+		return cmp.Or(b.LastUsedAt.Compare(a.LastUsedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return sessions, nil
+}
+
+func deleteSession(ctx context.Context, redisClient storage.RedisClient, userID string, sessionID string) {
+	sessionKey := fmt.Sprintf("%s:%s", auth.PrefixSessionKey, sessionID)
+	userSessionKey := fmt.Sprintf("%s:%s", auth.PrefixUserSessionKey, userID)
+
+	_ = redisClient.Del(ctx, sessionKey)
+	_ = redisClient.SRem(ctx, userSessionKey, sessionID)
+}
+
+// revokeSession ends the session together with its refresh token and the access tokens it issued that are still alive
+func revokeSession(ctx context.Context, redisClient storage.RedisClient, session *entity.Session) error {
+	err := revokeSessionAccessTokens(ctx, redisClient, session)
+	if err != nil {
+		return err
+	}
+
+	deleteRefreshToken(ctx, redisClient, session.UserID, session.RefreshToken)
+	deleteSession(ctx, redisClient, session.UserID, session.ID)
+
+	return nil
+}
+
+func revokeSessionAccessTokens(ctx context.Context, redisClient storage.RedisClient, session *entity.Session) error {
+	for tokenID, expiresAt := range session.AccessTokens {
+		revokeTTL := time.Until(expiresAt)
+		if revokeTTL <= 0 {
+			continue
+		}
+
+		revokeKey := fmt.Sprintf("%s:%s", auth.PrefixRevokeKey, tokenID)
+		err := redisClient.SetEx(ctx, revokeKey, "true", revokeTTL).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
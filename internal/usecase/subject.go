@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"slices"
+)
+
+// newSubject resolves the permissions of the user role, they are carried in the access token until it expires
+func newSubject(ctx context.Context, roleRepository RoleRepository, user *entity.User) (*auth.Subject, error) {
+	role, err := roleRepository.FindByName(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == nil {
+		return nil, fmt.Errorf("role %s not found", user.Role)
+	}
+
+	return &auth.Subject{
+		UserID:       fmt.Sprint(user.ID),
+		Role:         role.Name,
+		Permissions:  role.Permissions,
+		TokenVersion: user.TokenVersion,
+	}, nil
+}
+
+// personalAccessTokenPermissions keeps the scopes a personal access token may carry that the user role still grants,
+// a scope the role lost after the token was created is dropped
+func personalAccessTokenPermissions(subject *auth.Subject, scopes []string) []string {
+	permissions := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if slices.Contains(auth.PersonalAccessTokenScopes, scope) && auth.GrantsPermission(subject.Permissions, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	return permissions
+}
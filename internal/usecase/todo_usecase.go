@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"go-api-example/internal/entity"
+	"go-api-example/internal/messaging"
 	"go-api-example/internal/model"
 	"go-api-example/internal/model/serializer"
 
@@ -12,19 +13,32 @@ import (
 
 type todoUsecase struct {
 	Log            *zap.Logger
+	TodoProducer   *messaging.TodoProducer
 	TodoRepository TodoRepository
+	UserRepository UserRepository
 }
 
-func NewTodoUsecase(log *zap.Logger, todoRepository TodoRepository) TodoUsecase {
+func NewTodoUsecase(log *zap.Logger, todoProducer *messaging.TodoProducer, todoRepository TodoRepository,
+	userRepository UserRepository) TodoUsecase {
 	return &todoUsecase{
 		Log:            log,
+		TodoProducer:   todoProducer,
 		TodoRepository: todoRepository,
+		UserRepository: userRepository,
 	}
 }
 
 func (c *todoUsecase) Create(ctx context.Context, req *model.CreateTodoRequest) (*model.TodoResponse, error) {
+	if req.AssigneeID != nil {
+		err := c.validateAssignee(ctx, req.UserID, *req.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	todo := &entity.Todo{
 		UserID:      req.UserID,
+		AssigneeID:  req.AssigneeID,
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      entity.TodoStatusPending,
@@ -35,6 +49,10 @@ func (c *todoUsecase) Create(ctx context.Context, req *model.CreateTodoRequest)
 		return nil, fmt.Errorf("failed to create todo: %w", err)
 	}
 
+	if todo.AssigneeID != nil {
+		c.sendAssignedEvent(todo)
+	}
+
 	return serializer.TodoToResponse(todo), nil
 }
 
@@ -51,13 +69,44 @@ func (c *todoUsecase) List(ctx context.Context, req *model.SearchTodoRequest) ([
 	return serializer.ListTodoToResponse(todos), total, nil
 }
 
+func (c *todoUsecase) Board(ctx context.Context, req *model.BoardTodoRequest) (*model.BoardTodoResponse, error) {
+	todos, totals, err := c.TodoRepository.Board(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todo board: %w", err)
+	}
+
+	todosByStatus := make(map[entity.TodoStatus][]entity.Todo)
+	for _, t := range todos {
+		todosByStatus[t.Status] = append(todosByStatus[t.Status], t)
+	}
+
+	res := &model.BoardTodoResponse{
+		Columns: make([]model.BoardTodoColumnResponse, len(req.Columns)),
+	}
+	for i, column := range req.Columns {
+		res.Columns[i] = model.BoardTodoColumnResponse{
+			Status: column.Status.String(),
+			Todos:  serializer.ListTodoToResponse(todosByStatus[column.Status]),
+			Limit:  column.Limit,
+			Offset: column.Offset,
+			Total:  totals[column.Status],
+		}
+	}
+
+	return res, nil
+}
+
 func (c *todoUsecase) FindByID(ctx context.Context, req *model.GetTodoRequest) (*model.TodoResponse, error) {
 	todo, err := c.TodoRepository.FindByID(ctx, req.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find todo by id: %w", err)
 	}
 
-	if req.UserID != todo.UserID {
+	if todo == nil {
+		return nil, model.ErrTodoNotFound
+	}
+
+	if !todo.IsOwner(req.UserID) && !todo.IsAssignee(req.UserID) {
 		return nil, model.ErrForbidden
 	}
 
@@ -70,14 +119,116 @@ func (c *todoUsecase) UpdateByID(ctx context.Context, req *model.UpdateTodoReque
 		return fmt.Errorf("failed to find todo by id: %w", err)
 	}
 
-	if req.UserID != todo.UserID {
+	if todo == nil {
+		return model.ErrTodoNotFound
+	}
+
+	if todo.IsOwner(req.UserID) {
+		err = c.TodoRepository.UpdateByID(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to update todo by id: %w", err)
+		}
+
+		return nil
+	}
+
+	if !todo.IsAssignee(req.UserID) {
+		return model.ErrForbidden
+	}
+
+	// assignee is only allowed to move the todo between statuses
+	if req.Title != todo.Title || req.Description != todo.GetDescription() {
+		return model.ErrAssigneeFieldUpdate
+	}
+
+	err = c.TodoRepository.UpdateStatusByID(ctx, todo.ID, req.IntStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update todo status by id: %w", err)
+	}
+
+	return nil
+}
+
+func (c *todoUsecase) AssignByID(ctx context.Context, req *model.AssignTodoRequest) (*model.TodoResponse, error) {
+	todo, err := c.TodoRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find todo by id: %w", err)
+	}
+
+	if todo == nil {
+		return nil, model.ErrTodoNotFound
+	}
+
+	if !todo.IsOwner(req.UserID) {
+		return nil, model.ErrForbidden
+	}
+
+	if req.AssigneeID != nil {
+		err = c.validateAssignee(ctx, req.UserID, *req.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	previousAssigneeID := todo.AssigneeID
+	todo.AssigneeID = req.AssigneeID
+
+	err = c.TodoRepository.UpdateAssigneeByID(ctx, todo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update todo assignee by id: %w", err)
+	}
+
+	if todo.AssigneeID != nil && (previousAssigneeID == nil || *previousAssigneeID != *todo.AssigneeID) {
+		c.sendAssignedEvent(todo)
+	}
+
+	return serializer.TodoToResponse(todo), nil
+}
+
+func (c *todoUsecase) DeleteByID(ctx context.Context, req *model.DeleteTodoRequest) error {
+	todo, err := c.TodoRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find todo by id: %w", err)
+	}
+
+	if todo == nil {
+		return model.ErrTodoNotFound
+	}
+
+	if !todo.IsOwner(req.UserID) {
 		return model.ErrForbidden
 	}
 
-	err = c.TodoRepository.UpdateByID(ctx, req)
+	err = c.TodoRepository.DeleteByID(ctx, todo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete todo by id: %w", err)
+	}
+
+	return nil
+}
+
+// validateAssignee only accepts users the owner may find in the user search, a hidden assignee is reported as not
+// found so the assignment can't be used to probe for accounts
+func (c *todoUsecase) validateAssignee(ctx context.Context, ownerID uint64, assigneeID uint64) error {
+	user, err := c.UserRepository.FindDiscoverableByID(ctx, ownerID, assigneeID)
 	if err != nil {
-		return fmt.Errorf("failed to update todo by id: %w", err)
+		return fmt.Errorf("failed to find assignee by id: %w", err)
+	}
+
+	if user == nil {
+		return model.ErrAssigneeNotFound
 	}
 
 	return nil
 }
+
+func (c *todoUsecase) sendAssignedEvent(todo *entity.Todo) {
+	// the todo is already stored, a failed event should not fail the request
+	event := serializer.TodoToAssignedEvent(todo)
+	err := c.TodoProducer.Send(event)
+	if err != nil {
+		c.Log.Warn(fmt.Sprintf("failed to send todo assigned event: %+v", err),
+			zap.Uint64("todo_id", todo.ID),
+		)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"go-api-example/internal/entity"
+	"go-api-example/internal/messaging"
 	"go-api-example/internal/mocks"
 	"go-api-example/internal/model"
 	"go-api-example/internal/usecase"
@@ -17,26 +18,61 @@ import (
 
 type TodoUsecaseSuite struct {
 	suite.Suite
-	log *zap.Logger
-	ctx context.Context
+	log          *zap.Logger
+	todoProducer *messaging.TodoProducer
+	ctx          context.Context
 }
 
 func (s *TodoUsecaseSuite) SetupTest() {
 	s.log, _ = zap.NewDevelopment()
+	producer := mocks.NewKafkaProducer(s.T())
+	s.todoProducer = messaging.NewTodoProducer(
+		s.log, producer, "todo-assigned",
+	)
 	s.ctx = context.Background()
 }
 
 func (s *TodoUsecaseSuite) TestTodoUsecase_Create() {
 	description := "description"
+	assigneeID := uint64(2)
 	now := time.Now()
 
 	tests := []struct {
 		name       string
 		request    *model.CreateTodoRequest
-		mockFunc   func(r *mocks.TodoRepository)
+		mockFunc   func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer)
 		wantTodo   *model.TodoResponse
 		wantErrMsg string
 	}{
+		{
+			name: "error on find assignee",
+			request: &model.CreateTodoRequest{
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "title",
+				Description: &description,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).
+					Return(nil, errors.New("something error"))
+			},
+			wantTodo:   nil,
+			wantErrMsg: "failed to find assignee by id: something error",
+		},
+		{
+			name: "error assignee not found",
+			request: &model.CreateTodoRequest{
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "title",
+				Description: &description,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).Return(nil, nil)
+			},
+			wantTodo:   nil,
+			wantErrMsg: "assignee not found",
+		},
 		{
 			name: "error on create",
 			request: &model.CreateTodoRequest{
@@ -44,7 +80,7 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_Create() {
 				Title:       "title",
 				Description: &description,
 			},
-			mockFunc: func(r *mocks.TodoRepository) {
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
 				r.On("Create", mock.Anything, mock.Anything).
 					Return(errors.New("something error"))
 			},
@@ -58,7 +94,39 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_Create() {
 				Title:       "title",
 				Description: &description,
 			},
-			mockFunc: func(r *mocks.TodoRepository) {
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("Create", mock.Anything, mock.Anything).Return(nil).
+					Run(func(args mock.Arguments) {
+						t := args.Get(1).(*entity.Todo)
+						t.ID = 1
+						t.CreatedAt = now
+						t.UpdatedAt = now
+					})
+			},
+			wantTodo: &model.TodoResponse{
+				ID:          1,
+				UserID:      1,
+				Title:       "title",
+				Description: description,
+				Status:      entity.TodoStatusPending.String(),
+				CreatedAt:   now.Format(time.RFC3339),
+				UpdatedAt:   now.Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success with assignee",
+			request: &model.CreateTodoRequest{
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "title",
+				Description: &description,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{
+					ID:       2,
+					Username: "chyntia",
+				}, nil)
 				r.On("Create", mock.Anything, mock.Anything).Return(nil).
 					Run(func(args mock.Arguments) {
 						t := args.Get(1).(*entity.Todo)
@@ -66,10 +134,47 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_Create() {
 						t.CreatedAt = now
 						t.UpdatedAt = now
 					})
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
 			},
 			wantTodo: &model.TodoResponse{
 				ID:          1,
 				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "title",
+				Description: description,
+				Status:      entity.TodoStatusPending.String(),
+				CreatedAt:   now.Format(time.RFC3339),
+				UpdatedAt:   now.Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success with assignee when failed to send event",
+			request: &model.CreateTodoRequest{
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "title",
+				Description: &description,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{
+					ID:       2,
+					Username: "chyntia",
+				}, nil)
+				r.On("Create", mock.Anything, mock.Anything).Return(nil).
+					Run(func(args mock.Arguments) {
+						t := args.Get(1).(*entity.Todo)
+						t.ID = 1
+						t.CreatedAt = now
+						t.UpdatedAt = now
+					})
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			wantTodo: &model.TodoResponse{
+				ID:          1,
+				UserID:      1,
+				AssigneeID:  &assigneeID,
 				Title:       "title",
 				Description: description,
 				Status:      entity.TodoStatusPending.String(),
@@ -82,9 +187,12 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_Create() {
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
+			kafka := mocks.NewKafkaProducer(s.T())
+			todoProducer := messaging.NewTodoProducer(s.log, kafka, "todo-assigned")
 			todoRepository := mocks.NewTodoRepository(s.T())
-			usecase := usecase.NewTodoUsecase(s.log, todoRepository)
-			tt.mockFunc(todoRepository)
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, todoProducer, todoRepository, userRepository)
+			tt.mockFunc(todoRepository, userRepository, kafka)
 
 			res, err := usecase.Create(s.ctx, tt.request)
 
@@ -163,7 +271,8 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_List() {
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			todoRepository := mocks.NewTodoRepository(s.T())
-			usecase := usecase.NewTodoUsecase(s.log, todoRepository)
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, s.todoProducer, todoRepository, userRepository)
 			tt.mockFunc(todoRepository)
 
 			res, total, err := usecase.List(s.ctx, tt.request)
@@ -181,8 +290,100 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_List() {
 	}
 }
 
+func (s *TodoUsecaseSuite) TestTodoUsecase_Board() {
+	now := time.Now()
+	request := &model.BoardTodoRequest{
+		UserID: 1,
+		Columns: []model.BoardTodoColumnRequest{
+			{Status: entity.TodoStatusPending, Limit: 10, Offset: 0},
+			{Status: entity.TodoStatusInProgress, Limit: 5, Offset: 5},
+			{Status: entity.TodoStatusCompleted, Limit: 10, Offset: 0},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.TodoRepository)
+		wantRes    *model.BoardTodoResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on board",
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("Board", mock.Anything, request).
+					Return(nil, nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to get todo board: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("Board", mock.Anything, request).Return([]entity.Todo{
+					{ID: 1, UserID: 1, Title: "title 1", Status: entity.TodoStatusPending, CreatedAt: now, UpdatedAt: now},
+					{ID: 3, UserID: 1, Title: "title 3", Status: entity.TodoStatusPending, CreatedAt: now, UpdatedAt: now},
+					{ID: 8, UserID: 1, Title: "title 8", Status: entity.TodoStatusInProgress, CreatedAt: now, UpdatedAt: now},
+				}, map[entity.TodoStatus]int{
+					entity.TodoStatusPending:    2,
+					entity.TodoStatusInProgress: 6,
+				}, nil)
+			},
+			wantRes: &model.BoardTodoResponse{
+				Columns: []model.BoardTodoColumnResponse{
+					{
+						Status: "pending",
+						Todos: []model.TodoResponse{
+							{ID: 1, UserID: 1, Title: "title 1", Status: "pending", CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339)},
+							{ID: 3, UserID: 1, Title: "title 3", Status: "pending", CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339)},
+						},
+						Limit:  10,
+						Offset: 0,
+						Total:  2,
+					},
+					{
+						Status: "in_progress",
+						Todos: []model.TodoResponse{
+							{ID: 8, UserID: 1, Title: "title 8", Status: "in_progress", CreatedAt: now.Format(time.RFC3339), UpdatedAt: now.Format(time.RFC3339)},
+						},
+						Limit:  5,
+						Offset: 5,
+						Total:  6,
+					},
+					{
+						Status: "completed",
+						Todos:  []model.TodoResponse{},
+						Limit:  10,
+						Offset: 0,
+						Total:  0,
+					},
+				},
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			todoRepository := mocks.NewTodoRepository(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, s.todoProducer, todoRepository, userRepository)
+			tt.mockFunc(todoRepository)
+
+			res, err := usecase.Board(s.ctx, request)
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func (s *TodoUsecaseSuite) TestTodoUsecase_FindByID() {
 	description := "description"
+	assigneeID := uint64(2)
 	now := time.Now()
 
 	tests := []struct {
@@ -205,6 +406,18 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_FindByID() {
 			wantTodo:   nil,
 			wantErrMsg: "failed to find todo by id: something error",
 		},
+		{
+			name: "error not found",
+			request: &model.GetTodoRequest{
+				ID:     1,
+				UserID: 1,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantTodo:   nil,
+			wantErrMsg: "todo not found",
+		},
 		{
 			name: "error forbidden",
 			request: &model.GetTodoRequest{
@@ -253,12 +466,43 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_FindByID() {
 			},
 			wantErrMsg: "",
 		},
+		{
+			name: "success as assignee",
+			request: &model.GetTodoRequest{
+				ID:     1,
+				UserID: 2,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:          1,
+					UserID:      1,
+					AssigneeID:  &assigneeID,
+					Title:       "title",
+					Description: &description,
+					Status:      entity.TodoStatusPending,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}, nil)
+			},
+			wantTodo: &model.TodoResponse{
+				ID:          1,
+				UserID:      1,
+				AssigneeID:  &assigneeID,
+				Title:       "title",
+				Description: description,
+				Status:      entity.TodoStatusPending.String(),
+				CreatedAt:   now.Format(time.RFC3339),
+				UpdatedAt:   now.Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			todoRepository := mocks.NewTodoRepository(s.T())
-			usecase := usecase.NewTodoUsecase(s.log, todoRepository)
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, s.todoProducer, todoRepository, userRepository)
 			tt.mockFunc(todoRepository)
 
 			res, err := usecase.FindByID(s.ctx, tt.request)
@@ -276,6 +520,7 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_FindByID() {
 
 func (s *TodoUsecaseSuite) TestTodoUsecase_UpdateByID() {
 	description := "description"
+	assigneeID := uint64(2)
 	now := time.Now()
 
 	tests := []struct {
@@ -372,12 +617,104 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_UpdateByID() {
 			},
 			wantErrMsg: "",
 		},
+		{
+			name: "error not found",
+			request: &model.UpdateTodoRequest{
+				ID:          1,
+				UserID:      1,
+				Title:       "new title",
+				Description: "new description",
+				Status:      "in_progress",
+				IntStatus:   entity.TodoStatusInProgress,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "todo not found",
+		},
+		{
+			name: "error assignee update other fields",
+			request: &model.UpdateTodoRequest{
+				ID:          1,
+				UserID:      2,
+				Title:       "new title",
+				Description: "new description",
+				Status:      "in_progress",
+				IntStatus:   entity.TodoStatusInProgress,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:          1,
+					UserID:      1,
+					AssigneeID:  &assigneeID,
+					Title:       "title",
+					Description: &description,
+					Status:      entity.TodoStatusPending,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}, nil)
+			},
+			wantErrMsg: "assignee can only update todo status",
+		},
+		{
+			name: "error on assignee update status",
+			request: &model.UpdateTodoRequest{
+				ID:          1,
+				UserID:      2,
+				Title:       "title",
+				Description: description,
+				Status:      "in_progress",
+				IntStatus:   entity.TodoStatusInProgress,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:          1,
+					UserID:      1,
+					AssigneeID:  &assigneeID,
+					Title:       "title",
+					Description: &description,
+					Status:      entity.TodoStatusPending,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}, nil)
+				r.On("UpdateStatusByID", mock.Anything, uint64(1), entity.TodoStatusInProgress).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update todo status by id: something error",
+		},
+		{
+			name: "success as assignee",
+			request: &model.UpdateTodoRequest{
+				ID:          1,
+				UserID:      2,
+				Title:       "title",
+				Description: description,
+				Status:      "in_progress",
+				IntStatus:   entity.TodoStatusInProgress,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:          1,
+					UserID:      1,
+					AssigneeID:  &assigneeID,
+					Title:       "title",
+					Description: &description,
+					Status:      entity.TodoStatusPending,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}, nil)
+				r.On("UpdateStatusByID", mock.Anything, uint64(1), entity.TodoStatusInProgress).
+					Return(nil)
+			},
+			wantErrMsg: "",
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			todoRepository := mocks.NewTodoRepository(s.T())
-			usecase := usecase.NewTodoUsecase(s.log, todoRepository)
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, s.todoProducer, todoRepository, userRepository)
 			tt.mockFunc(todoRepository)
 
 			err := usecase.UpdateByID(s.ctx, tt.request)
@@ -391,6 +728,291 @@ func (s *TodoUsecaseSuite) TestTodoUsecase_UpdateByID() {
 	}
 }
 
+func (s *TodoUsecaseSuite) TestTodoUsecase_AssignByID() {
+	assigneeID := uint64(2)
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		request    *model.AssignTodoRequest
+		mockFunc   func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer)
+		wantTodo   *model.TodoResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantTodo:   nil,
+			wantErrMsg: "failed to find todo by id: something error",
+		},
+		{
+			name: "error not found",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantTodo:   nil,
+			wantErrMsg: "todo not found",
+		},
+		{
+			name: "error forbidden",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     2,
+				AssigneeID: &assigneeID,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:         1,
+					UserID:     1,
+					AssigneeID: &assigneeID,
+					Title:      "title",
+					Status:     entity.TodoStatusPending,
+				}, nil)
+			},
+			wantTodo:   nil,
+			wantErrMsg: "forbidden",
+		},
+		{
+			name: "error assignee not found",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:     1,
+					UserID: 1,
+					Title:  "title",
+					Status: entity.TodoStatusPending,
+				}, nil)
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).Return(nil, nil)
+			},
+			wantTodo:   nil,
+			wantErrMsg: "assignee not found",
+		},
+		{
+			name: "error on update assignee",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:     1,
+					UserID: 1,
+					Title:  "title",
+					Status: entity.TodoStatusPending,
+				}, nil)
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{
+					ID:       2,
+					Username: "chyntia",
+				}, nil)
+				r.On("UpdateAssigneeByID", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			wantTodo:   nil,
+			wantErrMsg: "failed to update todo assignee by id: something error",
+		},
+		{
+			name: "success assign",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:        1,
+					UserID:    1,
+					Title:     "title",
+					Status:    entity.TodoStatusPending,
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				ur.On("FindDiscoverableByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{
+					ID:       2,
+					Username: "chyntia",
+				}, nil)
+				r.On("UpdateAssigneeByID", mock.Anything, mock.Anything).Return(nil)
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantTodo: &model.TodoResponse{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: &assigneeID,
+				Title:      "title",
+				Status:     entity.TodoStatusPending.String(),
+				CreatedAt:  now.Format(time.RFC3339),
+				UpdatedAt:  now.Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success unassign",
+			request: &model.AssignTodoRequest{
+				ID:         1,
+				UserID:     1,
+				AssigneeID: nil,
+			},
+			mockFunc: func(r *mocks.TodoRepository, ur *mocks.UserRepository, k *mocks.KafkaProducer) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:         1,
+					UserID:     1,
+					AssigneeID: &assigneeID,
+					Title:      "title",
+					Status:     entity.TodoStatusPending,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				}, nil)
+				r.On("UpdateAssigneeByID", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantTodo: &model.TodoResponse{
+				ID:        1,
+				UserID:    1,
+				Title:     "title",
+				Status:    entity.TodoStatusPending.String(),
+				CreatedAt: now.Format(time.RFC3339),
+				UpdatedAt: now.Format(time.RFC3339),
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			kafka := mocks.NewKafkaProducer(s.T())
+			todoProducer := messaging.NewTodoProducer(s.log, kafka, "todo-assigned")
+			todoRepository := mocks.NewTodoRepository(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, todoProducer, todoRepository, userRepository)
+			tt.mockFunc(todoRepository, userRepository, kafka)
+
+			res, err := usecase.AssignByID(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantTodo, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *TodoUsecaseSuite) TestTodoUsecase_DeleteByID() {
+	assigneeID := uint64(2)
+
+	tests := []struct {
+		name       string
+		request    *model.DeleteTodoRequest
+		mockFunc   func(r *mocks.TodoRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			request: &model.DeleteTodoRequest{
+				ID:     1,
+				UserID: 1,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find todo by id: something error",
+		},
+		{
+			name: "error not found",
+			request: &model.DeleteTodoRequest{
+				ID:     1,
+				UserID: 1,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "todo not found",
+		},
+		{
+			name: "error forbidden for assignee",
+			request: &model.DeleteTodoRequest{
+				ID:     1,
+				UserID: 2,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:         1,
+					UserID:     1,
+					AssigneeID: &assigneeID,
+				}, nil)
+			},
+			wantErrMsg: "forbidden",
+		},
+		{
+			name: "error on delete",
+			request: &model.DeleteTodoRequest{
+				ID:     1,
+				UserID: 1,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:     1,
+					UserID: 1,
+				}, nil)
+				r.On("DeleteByID", mock.Anything, uint64(1)).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete todo by id: something error",
+		},
+		{
+			name: "success",
+			request: &model.DeleteTodoRequest{
+				ID:     1,
+				UserID: 1,
+			},
+			mockFunc: func(r *mocks.TodoRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.Todo{
+					ID:     1,
+					UserID: 1,
+				}, nil)
+				r.On("DeleteByID", mock.Anything, uint64(1)).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			todoRepository := mocks.NewTodoRepository(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewTodoUsecase(s.log, s.todoProducer, todoRepository, userRepository)
+			tt.mockFunc(todoRepository)
+
+			err := usecase.DeleteByID(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func TestTodoUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(TodoUsecaseSuite))
 }
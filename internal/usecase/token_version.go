@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/storage"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// findTokenVersion reads the token version of the user from the cache and falls back to the database, false is
+// returned when the user does not exist
+func findTokenVersion(ctx context.Context, redisClient storage.RedisClient, userRepository UserRepository,
+	userID uint64) (uint64, bool, error) {
+	versionKey := fmt.Sprintf("%s:%d", auth.PrefixTokenVersionKey, userID)
+	value, err := redisClient.Get(ctx, versionKey).Result()
+	if err == nil {
+		version, err := strconv.ParseUint(value, 10, 64)
+		if err == nil {
+			return version, true, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		return 0, false, err
+	}
+
+	user, err := userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if user == nil {
+		return 0, false, nil
+	}
+
+	// the fill never replaces a cached version, a bump that lands after the read above has already written a newer one
+	ok, err := redisClient.SetNX(ctx, versionKey, strconv.FormatUint(user.TokenVersion, 10), auth.TokenVersionCacheTTL).Result()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !ok {
+		value, err = redisClient.Get(ctx, versionKey).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return 0, false, err
+		}
+
+		version, err := strconv.ParseUint(value, 10, 64)
+		if err == nil {
+			return version, true, nil
+		}
+	}
+
+	return user.TokenVersion, true, nil
+}
+
+// invalidateTokens runs after the token version of the user was bumped, it caches the bumped version so the old
+// access tokens are rejected and revokes every refresh token of the user. Writing the version instead of dropping it
+// keeps a concurrent cache fill from putting the old version back
+func invalidateTokens(ctx context.Context, redisClient storage.RedisClient, userID uint64, version uint64) error {
+	versionKey := fmt.Sprintf("%s:%d", auth.PrefixTokenVersionKey, userID)
+	err := redisClient.SetEx(ctx, versionKey, strconv.FormatUint(version, 10), auth.TokenVersionCacheTTL).Err()
+	if err != nil {
+		return err
+	}
+
+	return revokeAllSessions(ctx, redisClient, fmt.Sprint(userID))
+}
+
+// dropTokenVersion runs after the user was deleted, the next check misses the cache and finds no user
+func dropTokenVersion(ctx context.Context, redisClient storage.RedisClient, userID uint64) error {
+	versionKey := fmt.Sprintf("%s:%d", auth.PrefixTokenVersionKey, userID)
+	return redisClient.Del(ctx, versionKey).Err()
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/storage"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// verifyTOTPCode accepts a code once, replaying it within its validity window fails like a wrong code
+func verifyTOTPCode(ctx context.Context, redisClient storage.RedisClient, totp *entity.TOTP, code string) (bool, error) {
+	step, ok := auth.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	usedKey := fmt.Sprintf("%s:%d:%d", auth.PrefixTOTPUsedKey, totp.UserID, step)
+	used, err := redisClient.Incr(ctx, usedKey).Result()
+	if err != nil {
+		return false, err
+	}
+	if used > 1 {
+		return false, nil
+	}
+
+	err = redisClient.Expire(ctx, usedKey, auth.TOTPUsedTTL).Err()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// generateRecoveryCodes returns the codes to show to the user once and the hashes to store
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, auth.RecoveryCodeCount)
+	hashes := make([]string, 0, auth.RecoveryCodeCount)
+	for range auth.RecoveryCodeCount {
+		code, err := auth.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+func storeMFAChallenge(ctx context.Context, redisClient storage.RedisClient, token string, challenge *entity.MFAChallenge) error {
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	challengeKey := fmt.Sprintf("%s:%s", auth.PrefixMFAChallengeKey, token)
+	return redisClient.SetEx(ctx, challengeKey, string(value), auth.MFAChallengeTTL).Err()
+}
+
+func findMFAChallenge(ctx context.Context, redisClient storage.RedisClient, token string) (*entity.MFAChallenge, error) {
+	challengeKey := fmt.Sprintf("%s:%s", auth.PrefixMFAChallengeKey, token)
+	value, err := redisClient.Get(ctx, challengeKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var challenge entity.MFAChallenge
+	err = json.Unmarshal([]byte(value), &challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+func deleteMFAChallenge(ctx context.Context, redisClient storage.RedisClient, token string) error {
+	challengeKey := fmt.Sprintf("%s:%s", auth.PrefixMFAChallengeKey, token)
+	return redisClient.Del(ctx, challengeKey).Err()
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/model"
+	"go-api-example/internal/storage"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type twoFactorUsecase struct {
+	Log            *zap.Logger
+	TX             db.Transactioner
+	RedisClient    storage.RedisClient
+	PasswordHasher auth.PasswordHasher
+	UserRepository UserRepository
+	TOTPRepository TOTPRepository
+	Issuer         string
+}
+
+func NewTwoFactorUsecase(log *zap.Logger, tx db.Transactioner, redisClient storage.RedisClient,
+	passwordHasher auth.PasswordHasher, userRepository UserRepository, totpRepository TOTPRepository, issuer string) TwoFactorUsecase {
+	return &twoFactorUsecase{
+		Log:            log,
+		TX:             tx,
+		RedisClient:    redisClient,
+		PasswordHasher: passwordHasher,
+		UserRepository: userRepository,
+		TOTPRepository: totpRepository,
+		Issuer:         issuer,
+	}
+}
+
+// EnrollTOTP stores a new pending secret, it is not asked for on login until ConfirmTOTP proves the app has it
+func (c *twoFactorUsecase) EnrollTOTP(ctx context.Context, req *model.EnrollTOTPRequest) (*model.EnrollTOTPResponse, error) {
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	totp, err := c.TOTPRepository.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp: %w", err)
+	}
+	if totp.IsConfirmed() {
+		return nil, model.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	err = c.TOTPRepository.Upsert(ctx, &entity.TOTP{
+		UserID: user.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store totp: %w", err)
+	}
+
+	return &model.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthURI: auth.TOTPURI(c.Issuer, user.Username, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns the second factor on and returns the recovery codes, they are never shown again
+func (c *twoFactorUsecase) ConfirmTOTP(ctx context.Context, req *model.ConfirmTOTPRequest) (*model.RecoveryCodesResponse, error) {
+	totp, err := c.TOTPRepository.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp: %w", err)
+	}
+	if totp == nil {
+		return nil, model.ErrTOTPNotEnrolled
+	}
+	if totp.IsConfirmed() {
+		return nil, model.ErrTOTPAlreadyEnabled
+	}
+
+	valid, err := verifyTOTPCode(ctx, c.RedisClient, totp, req.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify totp code: %w", err)
+	}
+	if !valid {
+		return nil, model.ErrInvalidTOTPCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	err = c.TX.Do(ctx, func(exec db.Executor) error {
+		txErr := c.TOTPRepository.ConfirmByUserID(ctx, exec, req.UserID, time.Now())
+		if txErr != nil {
+			return fmt.Errorf("failed to confirm totp: %w", txErr)
+		}
+
+		txErr = c.TOTPRepository.ReplaceRecoveryCodes(ctx, exec, req.UserID, hashes)
+		if txErr != nil {
+			return fmt.Errorf("failed to store recovery codes: %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (c *twoFactorUsecase) DisableTOTP(ctx context.Context, req *model.DisableTOTPRequest) error {
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	err = c.PasswordHasher.Compare(user.Password, req.Password)
+	if err != nil {
+		return model.ErrInvalidPassword
+	}
+
+	totp, err := c.TOTPRepository.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find totp: %w", err)
+	}
+	if totp == nil {
+		return model.ErrTOTPNotEnrolled
+	}
+
+	err = c.TX.Do(ctx, func(exec db.Executor) error {
+		txErr := c.TOTPRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete totp: %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,372 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/db"
+	"go-api-example/internal/entity"
+	"go-api-example/internal/mocks"
+	"go-api-example/internal/model"
+	"go-api-example/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type TwoFactorUsecaseSuite struct {
+	suite.Suite
+	log            *zap.Logger
+	passwordHasher auth.PasswordHasher
+	ctx            context.Context
+}
+
+type TwoFactorMockFunc func(
+	tx *mocks.Transactioner,
+	rc *mocks.RedisClient,
+	ur *mocks.UserRepository,
+	tr *mocks.TOTPRepository,
+)
+
+func (s *TwoFactorUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.passwordHasher, _ = auth.NewPasswordHasher(auth.PasswordHashConfig{
+		Algorithm:  auth.PasswordHashBcrypt,
+		BcryptCost: bcrypt.DefaultCost,
+	})
+	s.ctx = context.Background()
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_EnrollTOTP() {
+	user := &entity.User{ID: 1, Username: "johndoe"}
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		mockFunc   TwoFactorMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error on find user",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name: "error user not found",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name: "error on find totp",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find totp: something error",
+		},
+		{
+			name: "error already enabled",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.TOTP{UserID: 1, ConfirmedAt: &now}, nil)
+			},
+			wantErrMsg: "two-factor authentication already enabled",
+		},
+		{
+			name: "error on upsert",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				tr.On("Upsert", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store totp: something error",
+		},
+		{
+			name: "success replacing pending secret",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.TOTP{UserID: 1, Secret: "old"}, nil)
+				tr.On("Upsert", mock.Anything, mock.MatchedBy(func(t *entity.TOTP) bool {
+					return t.UserID == 1 && len(t.Secret) == 32
+				})).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, tx, rc, s.passwordHasher, ur, tr, "api-example")
+			tt.mockFunc(tx, rc, ur, tr)
+
+			res, err := usecase.EnrollTOTP(s.ctx, &model.EnrollTOTPRequest{UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Len(res.Secret, 32)
+				s.Equal(auth.TOTPURI("api-example", "johndoe", res.Secret), res.OtpauthURI)
+			}
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_ConfirmTOTP() {
+	now := time.Now()
+	pending := &entity.TOTP{UserID: 1, Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"}
+	code, _ := auth.TOTPCode(pending.Secret, auth.TOTPStep(now))
+	usedMatcher := mock.MatchedBy(func(v string) bool {
+		return strings.HasPrefix(v, "totp-used:1:")
+	})
+	usedCmd := func(val int64) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		return cmd
+	}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	hashesMatcher := mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == auth.RecoveryCodeCount
+	})
+
+	tests := []struct {
+		name       string
+		code       string
+		mockFunc   TwoFactorMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error on find totp",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find totp: something error",
+		},
+		{
+			name: "error not enrolled",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "two-factor authentication not enrolled",
+		},
+		{
+			name: "error already enabled",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.TOTP{UserID: 1, ConfirmedAt: &now}, nil)
+			},
+			wantErrMsg: "two-factor authentication already enabled",
+		},
+		{
+			name: "error invalid code",
+			code: "000000",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(pending, nil)
+			},
+			wantErrMsg: "invalid two-factor code",
+		},
+		{
+			name: "error on mark code used",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(pending, nil)
+				cmd := redis.NewIntCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Incr", mock.Anything, usedMatcher).Return(cmd)
+			},
+			wantErrMsg: "failed to verify totp code: something error",
+		},
+		{
+			name: "error replayed code",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(pending, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(usedCmd(2))
+			},
+			wantErrMsg: "invalid two-factor code",
+		},
+		{
+			name: "error on confirm",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(pending, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(usedCmd(1))
+				rc.On("Expire", mock.Anything, usedMatcher, auth.TOTPUsedTTL).Return(redis.NewBoolCmd(s.ctx))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("ConfirmByUserID", mock.Anything, mock.Anything, uint64(1), mock.Anything).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to confirm totp: something error",
+		},
+		{
+			name: "error on store recovery codes",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(pending, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(usedCmd(1))
+				rc.On("Expire", mock.Anything, usedMatcher, auth.TOTPUsedTTL).Return(redis.NewBoolCmd(s.ctx))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("ConfirmByUserID", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				tr.On("ReplaceRecoveryCodes", mock.Anything, mock.Anything, uint64(1), hashesMatcher).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store recovery codes: something error",
+		},
+		{
+			name: "success",
+			code: code,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(pending, nil)
+				rc.On("Incr", mock.Anything, usedMatcher).Return(usedCmd(1))
+				rc.On("Expire", mock.Anything, usedMatcher, auth.TOTPUsedTTL).Return(redis.NewBoolCmd(s.ctx))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("ConfirmByUserID", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				tr.On("ReplaceRecoveryCodes", mock.Anything, mock.Anything, uint64(1), hashesMatcher).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, tx, rc, s.passwordHasher, ur, tr, "api-example")
+			tt.mockFunc(tx, rc, ur, tr)
+
+			res, err := usecase.ConfirmTOTP(s.ctx, &model.ConfirmTOTPRequest{UserID: 1, Code: tt.code})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Len(res.RecoveryCodes, auth.RecoveryCodeCount)
+			}
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_DisableTOTP() {
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	user := &entity.User{ID: 1, Username: "johndoe", Password: string(passwordHash)}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+
+	tests := []struct {
+		name       string
+		password   string
+		mockFunc   TwoFactorMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:     "error on find user",
+			password: "password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:     "error user not found",
+			password: "password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name:     "error invalid password",
+			password: "wrong-password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+			},
+			wantErrMsg: "invalid password",
+		},
+		{
+			name:     "error on find totp",
+			password: "password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find totp: something error",
+		},
+		{
+			name:     "error not enrolled",
+			password: "password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "two-factor authentication not enrolled",
+		},
+		{
+			name:     "error on delete",
+			password: "password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.TOTP{UserID: 1}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete totp: something error",
+		},
+		{
+			name:     "success",
+			password: "password",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, tr *mocks.TOTPRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.TOTP{UserID: 1}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			tr := mocks.NewTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, tx, rc, s.passwordHasher, ur, tr, "api-example")
+			tt.mockFunc(tx, rc, ur, tr)
+
+			err := usecase.DisableTOTP(s.ctx, &model.DisableTOTPRequest{UserID: 1, Password: tt.password})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestTwoFactorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorUsecaseSuite))
+}
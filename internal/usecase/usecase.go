@@ -2,14 +2,28 @@ package usecase
 
 import (
 	"context"
+	"go-api-example/internal/auth"
 	"go-api-example/internal/model"
 )
 
 //go:generate mockery --name=AuthUsecase --structname AuthUsecase --outpkg=mocks --output=./../mocks
 type AuthUsecase interface {
 	Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error)
+	VerifyMFA(ctx context.Context, req *model.VerifyMFARequest) (*model.LoginResponse, error)
+	RequestMagicLink(ctx context.Context, req *model.MagicLinkRequest) error
+	LoginMagicLink(ctx context.Context, req *model.MagicLinkLoginRequest) (*model.LoginResponse, error)
+	AuthorizeOIDC(ctx context.Context, req *model.AuthorizeOIDCRequest) (*model.AuthorizeOIDCResponse, error)
+	LoginOIDC(ctx context.Context, req *model.OIDCCallbackRequest) (*model.LoginResponse, error)
 	Logout(ctx context.Context, req *model.LogoutRequest) error
 	Refresh(ctx context.Context, req *model.RefreshRequest) (*model.RefreshResponse, error)
+	ListSessions(ctx context.Context, req *model.ListSessionRequest) ([]model.SessionResponse, error)
+	RevokeSession(ctx context.Context, req *model.RevokeSessionRequest) error
+	RevokeAllSessions(ctx context.Context, req *model.RevokeAllSessionRequest) error
+	UnlockLogin(ctx context.Context, req *model.UnlockLoginRequest) error
+	ListIdentities(ctx context.Context, req *model.ListIdentityRequest) ([]model.IdentityResponse, error)
+	UnlinkIdentity(ctx context.Context, req *model.UnlinkIdentityRequest) error
+	VerifyTokenVersion(ctx context.Context, claims *auth.JWTClaims) error
+	JWKS(ctx context.Context) *auth.JSONWebKeySet
 }
 
 //go:generate mockery --name=UserUsecase --structname UserUsecase --outpkg=mocks --output=./../mocks
@@ -18,12 +32,77 @@ type UserUsecase interface {
 	List(ctx context.Context, req *model.SearchUserRequest) ([]model.UserResponse, int, error)
 	FindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error)
 	UpdateByID(ctx context.Context, req *model.UpdateUserRequest) error
+	UpdateAvatar(ctx context.Context, req *model.UpdateAvatarRequest) (*model.UserResponse, error)
+	DeleteAvatar(ctx context.Context, req *model.DeleteAvatarRequest) error
+	DeleteByID(ctx context.Context, req *model.DeleteUserRequest) error
+	AdminList(ctx context.Context, req *model.SearchUserRequest) ([]model.UserResponse, int, error)
+	AdminFindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error)
+	Suspend(ctx context.Context, req *model.AdminUserActionRequest) error
+	Unsuspend(ctx context.Context, req *model.AdminUserActionRequest) error
+	ForcePasswordReset(ctx context.Context, req *model.AdminUserActionRequest) error
+	AdminDeleteByID(ctx context.Context, req *model.AdminUserActionRequest) error
+}
+
+//go:generate mockery --name=EmailUsecase --structname EmailUsecase --outpkg=mocks --output=./../mocks
+type EmailUsecase interface {
+	RequestVerification(ctx context.Context, req *model.VerifyEmailRequest) error
+	ConfirmVerification(ctx context.Context, req *model.ConfirmEmailRequest) error
+}
+
+//go:generate mockery --name=PasswordUsecase --structname PasswordUsecase --outpkg=mocks --output=./../mocks
+type PasswordUsecase interface {
+	Forgot(ctx context.Context, req *model.ForgotPasswordRequest) error
+	Reset(ctx context.Context, req *model.ResetPasswordRequest) error
+}
+
+//go:generate mockery --name=TwoFactorUsecase --structname TwoFactorUsecase --outpkg=mocks --output=./../mocks
+type TwoFactorUsecase interface {
+	EnrollTOTP(ctx context.Context, req *model.EnrollTOTPRequest) (*model.EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, req *model.ConfirmTOTPRequest) (*model.RecoveryCodesResponse, error)
+	DisableTOTP(ctx context.Context, req *model.DisableTOTPRequest) error
+}
+
+//go:generate mockery --name=PersonalAccessTokenUsecase --structname PersonalAccessTokenUsecase --outpkg=mocks --output=./../mocks
+type PersonalAccessTokenUsecase interface {
+	Create(ctx context.Context, req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, error)
+	List(ctx context.Context, req *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error)
+	Revoke(ctx context.Context, req *model.RevokePersonalAccessTokenRequest) error
+	Authenticate(ctx context.Context, token string) (*auth.JWTClaims, error)
+}
+
+//go:generate mockery --name=OAuthUsecase --structname OAuthUsecase --outpkg=mocks --output=./../mocks
+type OAuthUsecase interface {
+	Introspect(ctx context.Context, req *model.IntrospectTokenRequest) (*model.IntrospectTokenResponse, error)
+	Revoke(ctx context.Context, req *model.RevokeTokenRequest) error
+}
+
+//go:generate mockery --name=ImpersonationUsecase --structname ImpersonationUsecase --outpkg=mocks --output=./../mocks
+type ImpersonationUsecase interface {
+	Impersonate(ctx context.Context, req *model.ImpersonateRequest) (*model.ImpersonateResponse, error)
+	Record(ctx context.Context, req *model.RecordImpersonationRequest) error
 }
 
 //go:generate mockery --name=TodoUsecase --structname TodoUsecase --outpkg=mocks --output=./../mocks
 type TodoUsecase interface {
 	Create(ctx context.Context, req *model.CreateTodoRequest) (*model.TodoResponse, error)
 	List(ctx context.Context, req *model.SearchTodoRequest) ([]model.TodoResponse, int, error)
+	Board(ctx context.Context, req *model.BoardTodoRequest) (*model.BoardTodoResponse, error)
 	FindByID(ctx context.Context, req *model.GetTodoRequest) (*model.TodoResponse, error)
 	UpdateByID(ctx context.Context, req *model.UpdateTodoRequest) error
+	AssignByID(ctx context.Context, req *model.AssignTodoRequest) (*model.TodoResponse, error)
+	DeleteByID(ctx context.Context, req *model.DeleteTodoRequest) error
+}
+
+//go:generate mockery --name=NotificationUsecase --structname NotificationUsecase --outpkg=mocks --output=./../mocks
+type NotificationUsecase interface {
+	Create(ctx context.Context, req *model.CreateNotificationRequest) error
+	List(ctx context.Context, req *model.SearchNotificationRequest) ([]model.NotificationResponse, int, int, error)
+	MarkReadByID(ctx context.Context, req *model.ReadNotificationRequest) error
+	MarkAllRead(ctx context.Context, req *model.ReadAllNotificationRequest) error
+}
+
+//go:generate mockery --name=SecurityEventUsecase --structname SecurityEventUsecase --outpkg=mocks --output=./../mocks
+type SecurityEventUsecase interface {
+	Create(ctx context.Context, event *model.SecurityEvent) error
+	List(ctx context.Context, req *model.SearchSecurityEventRequest) ([]model.SecurityEventResponse, int, error)
 }
@@ -2,63 +2,111 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/avatar"
 	"go-api-example/internal/db"
 	"go-api-example/internal/entity"
 	"go-api-example/internal/messaging"
 	"go-api-example/internal/model"
 	"go-api-example/internal/model/serializer"
+	"go-api-example/internal/storage"
+	"time"
+	"unicode/utf8"
 
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
+// userSearchMinQueryLength keeps prefix searches from listing the whole directory one letter at a time
+const userSearchMinQueryLength = 2
+
 type userUsecase struct {
-	Log            *zap.Logger
-	TX             db.Transactioner
-	UserProducer   *messaging.UserProducer
-	UserRepository UserRepository
+	Log                           *zap.Logger
+	TX                            db.Transactioner
+	RedisClient                   storage.RedisClient
+	PasswordHasher                auth.PasswordHasher
+	PasswordPolicy                *auth.PasswordPolicy
+	UserProducer                  *messaging.UserProducer
+	UserDeletedProducer           *messaging.UserDeletedProducer
+	UserUpdatedProducer           *messaging.UserUpdatedProducer
+	SecurityEventProducer         *messaging.SecurityEventProducer
+	FileStorage                   storage.FileStorage
+	UserRepository                UserRepository
+	TodoRepository                TodoRepository
+	NotificationRepository        NotificationRepository
+	TOTPRepository                TOTPRepository
+	PersonalAccessTokenRepository PersonalAccessTokenRepository
+	UserIdentityRepository        UserIdentityRepository
+	RoleRepository                RoleRepository
+	AdminAuditLogRepository       AdminAuditLogRepository
+	SecurityEventRepository       SecurityEventRepository
 }
 
-func NewUserUsecase(log *zap.Logger, tx db.Transactioner, userProducer *messaging.UserProducer, userRepository UserRepository) UserUsecase {
+func NewUserUsecase(log *zap.Logger, tx db.Transactioner, redisClient storage.RedisClient,
+	passwordHasher auth.PasswordHasher, passwordPolicy *auth.PasswordPolicy, userProducer *messaging.UserProducer,
+	userDeletedProducer *messaging.UserDeletedProducer, userUpdatedProducer *messaging.UserUpdatedProducer,
+	securityEventProducer *messaging.SecurityEventProducer, fileStorage storage.FileStorage, userRepository UserRepository,
+	todoRepository TodoRepository, notificationRepository NotificationRepository, totpRepository TOTPRepository,
+	personalAccessTokenRepository PersonalAccessTokenRepository, userIdentityRepository UserIdentityRepository,
+	roleRepository RoleRepository, adminAuditLogRepository AdminAuditLogRepository,
+	securityEventRepository SecurityEventRepository) UserUsecase {
 	return &userUsecase{
-		Log:            log,
-		TX:             tx,
-		UserProducer:   userProducer,
-		UserRepository: userRepository,
+		Log:                           log,
+		TX:                            tx,
+		RedisClient:                   redisClient,
+		PasswordHasher:                passwordHasher,
+		PasswordPolicy:                passwordPolicy,
+		UserProducer:                  userProducer,
+		UserDeletedProducer:           userDeletedProducer,
+		UserUpdatedProducer:           userUpdatedProducer,
+		SecurityEventProducer:         securityEventProducer,
+		FileStorage:                   fileStorage,
+		UserRepository:                userRepository,
+		TodoRepository:                todoRepository,
+		NotificationRepository:        notificationRepository,
+		TOTPRepository:                totpRepository,
+		PersonalAccessTokenRepository: personalAccessTokenRepository,
+		UserIdentityRepository:        userIdentityRepository,
+		RoleRepository:                roleRepository,
+		AdminAuditLogRepository:       adminAuditLogRepository,
+		SecurityEventRepository:       securityEventRepository,
 	}
 }
 
 func (c *userUsecase) Create(ctx context.Context, req *model.CreateUserRequest) (*model.UserResponse, error) {
-	total, err := c.UserRepository.CountByUsername(ctx, req.Username)
+	username, err := normalizeUsername(req.Username)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count by username: %w", err)
+		return nil, err
 	}
 
-	if total > 0 {
-		return nil, model.ErrUsernameAlreadyExist
+	err = checkPasswordPolicy(c.PasswordPolicy, req.Password)
+	if err != nil {
+		return nil, err
 	}
 
-	password, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	password, err := c.PasswordHasher.Hash(req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate password: %w", err)
 	}
 
 	user := &entity.User{
-		Username: req.Username,
-		Password: string(password),
+		Username: username,
+		Password: password,
+		Role:     auth.RoleUser,
 	}
 
+	// the unique index decides between concurrent registrations of the same username
 	err = c.TX.Do(ctx, func(exec db.Executor) error {
 		txErr := c.UserRepository.Create(ctx, exec, user)
 		if txErr != nil {
-			return fmt.Errorf("failed to create user: %w", err)
+			return fmt.Errorf("failed to create user: %w", txErr)
 		}
 
 		event := serializer.UserToEvent(user)
 		txErr = c.UserProducer.Send(event)
 		if txErr != nil {
-			return fmt.Errorf("failed to send user event: %w", err)
+			return fmt.Errorf("failed to send user event: %w", txErr)
 		}
 
 		return nil
@@ -71,6 +119,30 @@ func (c *userUsecase) Create(ctx context.Context, req *model.CreateUserRequest)
 }
 
 func (c *userUsecase) List(ctx context.Context, req *model.SearchUserRequest) ([]model.UserResponse, int, error) {
+	if req.Username != nil {
+		username := auth.NormalizeUsername(*req.Username)
+		req.Username = &username
+	}
+
+	if req.Query != nil {
+		query := auth.NormalizeUsername(*req.Query)
+		if utf8.RuneCountInString(query) < userSearchMinQueryLength {
+			return []model.UserResponse{}, 0, model.ErrSearchQueryTooShort
+		}
+		req.Query = &query
+	}
+
+	if !req.Unrestricted {
+		searchKey := fmt.Sprintf("%s:%d", auth.PrefixUserSearchKey, req.ViewerID)
+		limited, err := countRequest(ctx, c.RedisClient, searchKey, auth.UserSearchMaxRequests, auth.UserSearchWindow)
+		if err != nil {
+			return []model.UserResponse{}, 0, fmt.Errorf("failed to count search request: %w", err)
+		}
+		if limited {
+			return []model.UserResponse{}, 0, model.ErrTooManySearchRequests
+		}
+	}
+
 	users, total, err := c.UserRepository.List(ctx, req)
 	if err != nil {
 		return []model.UserResponse{}, 0, fmt.Errorf("failed to get users: %w", err)
@@ -89,30 +161,581 @@ func (c *userUsecase) FindByID(ctx context.Context, req *model.GetUserRequest) (
 		return nil, fmt.Errorf("failed to find user by id: %w", err)
 	}
 
-	return serializer.UserToResponse(user), nil
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	return serializer.UserToProfileResponse(user), nil
 }
 
 func (c *userUsecase) UpdateByID(ctx context.Context, req *model.UpdateUserRequest) error {
+	if req.Username != nil {
+		username, err := normalizeUsername(*req.Username)
+		if err != nil {
+			return err
+		}
+		req.Username = &username
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	var newPassword string
+	if req.NewPassword != "" {
+		err = c.PasswordHasher.Compare(user.Password, req.OldPassword)
+		if err != nil {
+			return model.ErrInvalidOldPassword
+		}
+
+		err = checkPasswordPolicy(c.PasswordPolicy, req.NewPassword)
+		if err != nil {
+			return err
+		}
+
+		newPassword, err = c.PasswordHasher.Hash(req.NewPassword)
+		if err != nil {
+			return fmt.Errorf("failed to generate password: %w", err)
+		}
+	}
+
+	previousUsername := user.Username
+	changedFields := applyProfileChanges(user, req)
+
+	// a rename that still races is refused by the unique index
+	if user.Username != previousUsername {
+		owner, err := c.UserRepository.FindByUsername(ctx, user.Username)
+		if err != nil {
+			return fmt.Errorf("failed to find user by username: %w", err)
+		}
+
+		if owner != nil && owner.ID != user.ID {
+			return model.ErrUsernameAlreadyExist
+		}
+	}
+
+	if newPassword == "" && len(changedFields) == 0 {
+		return nil
+	}
+
+	if user.Username == previousUsername {
+		previousUsername = ""
+	}
+
+	// the password and the profile are written together, a refused profile change leaves the password untouched
+	var tokenVersion uint64
+	err = c.TX.Do(ctx, func(exec db.Executor) error {
+		if newPassword != "" {
+			var txErr error
+			tokenVersion, txErr = c.UserRepository.ChangePasswordByID(ctx, exec, user.ID, newPassword)
+			if txErr != nil {
+				return fmt.Errorf("failed to change password: %w", txErr)
+			}
+		}
+
+		if len(changedFields) == 0 {
+			return nil
+		}
+
+		txErr := c.UserRepository.UpdateProfile(ctx, exec, user)
+		if txErr != nil {
+			return fmt.Errorf("failed to update user profile: %w", txErr)
+		}
+
+		event := serializer.UserToUpdatedEvent(user, previousUsername, changedFields)
+		txErr = c.UserUpdatedProducer.Send(event)
+		if txErr != nil {
+			return fmt.Errorf("failed to send user updated event: %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if newPassword != "" {
+		err = invalidateTokens(ctx, c.RedisClient, user.ID, tokenVersion)
+		if err != nil {
+			return fmt.Errorf("failed to invalidate tokens: %w", err)
+		}
+
+		sendSecurityEvent(c.Log, c.SecurityEventProducer, &model.SecurityEvent{
+			Type:      model.SecurityEventPasswordChanged,
+			UserID:    user.ID,
+			IP:        req.IP,
+			UserAgent: req.UserAgent,
+			RequestID: req.RequestID,
+		})
+	}
+
+	return nil
+}
+
+// applyProfileChanges sets the requested profile fields on the user and returns the names of the fields that changed
+func applyProfileChanges(user *entity.User, req *model.UpdateUserRequest) []string {
+	changedFields := []string{}
+
+	if req.Username != nil && *req.Username != user.Username {
+		user.Username = *req.Username
+		changedFields = append(changedFields, "username")
+	}
+
+	if req.DisplayName != nil && *req.DisplayName != deref(user.DisplayName) {
+		user.DisplayName = emptyToNil(*req.DisplayName)
+		changedFields = append(changedFields, "display_name")
+	}
+
+	if req.Bio != nil && *req.Bio != deref(user.Bio) {
+		user.Bio = emptyToNil(*req.Bio)
+		changedFields = append(changedFields, "bio")
+	}
+
+	if req.Timezone != nil && *req.Timezone != user.Timezone {
+		user.Timezone = *req.Timezone
+		changedFields = append(changedFields, "timezone")
+	}
+
+	if req.Locale != nil && *req.Locale != user.Locale {
+		user.Locale = *req.Locale
+		changedFields = append(changedFields, "locale")
+	}
+
+	if req.Discoverability != nil && *req.Discoverability != user.Discoverability {
+		user.Discoverability = *req.Discoverability
+		changedFields = append(changedFields, "discoverability")
+	}
+
+	return changedFields
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func emptyToNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// UpdateAvatar stores the image in every avatar size under a new key and removes the images of the previous avatar
+func (c *userUsecase) UpdateAvatar(ctx context.Context, req *model.UpdateAvatarRequest) (*model.UserResponse, error) {
+	if len(req.Image) > avatar.MaxUploadSize {
+		return nil, model.ErrAvatarTooLarge
+	}
+
+	img, err := avatar.Decode(req.Image)
+	if errors.Is(err, avatar.ErrImageTooLarge) {
+		return nil, model.ErrAvatarTooLarge
+	}
+	if err != nil {
+		return nil, model.ErrInvalidAvatar
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	key, err := avatar.NewKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate avatar key: %w", err)
+	}
+
+	files := make([]string, 0, len(avatar.Sizes))
+	for _, size := range avatar.Sizes {
+		data, err := avatar.Encode(avatar.Resize(img, size))
+		if err != nil {
+			c.deleteAvatarFiles(ctx, files)
+			return nil, fmt.Errorf("failed to encode avatar: %w", err)
+		}
+
+		name := avatar.FileName(key, size)
+		err = c.FileStorage.Put(ctx, name, data)
+		if err != nil {
+			c.deleteAvatarFiles(ctx, files)
+			return nil, fmt.Errorf("failed to store avatar: %w", err)
+		}
+		files = append(files, name)
+	}
+
+	previousKey := user.AvatarKey
+	user.AvatarKey = &key
+	err = c.saveAvatar(ctx, user)
+	if err != nil {
+		c.deleteAvatarFiles(ctx, files)
+		return nil, err
+	}
+
+	c.deleteAvatarFiles(ctx, avatarFiles(previousKey))
+
+	return serializer.UserToProfileResponse(user), nil
+}
+
+func (c *userUsecase) DeleteAvatar(ctx context.Context, req *model.DeleteAvatarRequest) error {
+	user, err := c.UserRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	if user.AvatarKey == nil {
+		return nil
+	}
+
+	previousKey := user.AvatarKey
+	user.AvatarKey = nil
+	err = c.saveAvatar(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	c.deleteAvatarFiles(ctx, avatarFiles(previousKey))
+
+	return nil
+}
+
+func (c *userUsecase) saveAvatar(ctx context.Context, user *entity.User) error {
+	return c.TX.Do(ctx, func(exec db.Executor) error {
+		txErr := c.UserRepository.UpdateAvatarByID(ctx, exec, user.ID, user.AvatarKey)
+		if txErr != nil {
+			return fmt.Errorf("failed to update user avatar: %w", txErr)
+		}
+
+		event := serializer.UserToUpdatedEvent(user, "", []string{"avatar"})
+		txErr = c.UserUpdatedProducer.Send(event)
+		if txErr != nil {
+			return fmt.Errorf("failed to send user updated event: %w", txErr)
+		}
+
+		return nil
+	})
+}
+
+// deleteAvatarFiles is best effort, a leftover file is only wasted space
+func (c *userUsecase) deleteAvatarFiles(ctx context.Context, files []string) {
+	if len(files) == 0 {
+		return
+	}
+
+	err := c.FileStorage.Delete(ctx, files...)
+	if err != nil {
+		c.Log.Warn("failed to delete avatar files", zap.Error(err))
+	}
+}
+
+func avatarFiles(key *string) []string {
+	if key == nil {
+		return nil
+	}
+
+	files := make([]string, 0, len(avatar.Sizes))
+	for _, size := range avatar.Sizes {
+		files = append(files, avatar.FileName(*key, size))
+	}
+	return files
+}
+
+func (c *userUsecase) DeleteByID(ctx context.Context, req *model.DeleteUserRequest) error {
 	user, err := c.UserRepository.FindByID(ctx, req.ID)
 	if err != nil {
 		return fmt.Errorf("failed to find user by id: %w", err)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword))
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	err = c.PasswordHasher.Compare(user.Password, req.Password)
+	if err != nil {
+		return model.ErrInvalidPassword
+	}
+
+	return c.deleteUser(ctx, user, req.Claims, nil)
+}
+
+// deleteUser removes the user with everything it owns, the audit log of an admin deletion is written in the same
+// transaction
+func (c *userUsecase) deleteUser(ctx context.Context, user *entity.User, claims *auth.JWTClaims,
+	auditLog *entity.AdminAuditLog) error {
+	err := c.TX.Do(ctx, func(exec db.Executor) error {
+		txErr := c.TodoRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user todos: %w", txErr)
+		}
+
+		txErr = c.TodoRepository.UnassignByAssigneeID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to unassign user todos: %w", txErr)
+		}
+
+		txErr = c.NotificationRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user notifications: %w", txErr)
+		}
+
+		txErr = c.TOTPRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user totp: %w", txErr)
+		}
+
+		txErr = c.PersonalAccessTokenRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user personal access tokens: %w", txErr)
+		}
+
+		txErr = c.UserIdentityRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user identities: %w", txErr)
+		}
+
+		txErr = c.SecurityEventRepository.DeleteByUserID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user security events: %w", txErr)
+		}
+
+		txErr = c.UserRepository.DeleteByID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to delete user: %w", txErr)
+		}
+
+		// sessions are revoked before commit, a failure here keeps the account instead of leaving live tokens behind
+		txErr = revokeAllSessions(ctx, c.RedisClient, fmt.Sprint(user.ID))
+		if txErr != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", txErr)
+		}
+
+		if claims != nil {
+			txErr = revokeAccessToken(ctx, c.RedisClient, claims)
+			if txErr != nil {
+				return fmt.Errorf("failed to revoke access token: %w", txErr)
+			}
+		}
+
+		if auditLog != nil {
+			txErr = c.AdminAuditLogRepository.Create(ctx, exec, auditLog)
+			if txErr != nil {
+				return fmt.Errorf("failed to store admin audit log: %w", txErr)
+			}
+		}
+
+		event := serializer.UserToDeletedEvent(user, time.Now())
+		txErr = c.UserDeletedProducer.Send(event)
+		if txErr != nil {
+			return fmt.Errorf("failed to send user deleted event: %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// access tokens that no session tracks are refused once the cached version is gone
+	err = dropTokenVersion(ctx, c.RedisClient, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to drop token version: %w", err)
+	}
+
+	c.deleteAvatarFiles(ctx, avatarFiles(user.AvatarKey))
+
+	return nil
+}
+
+// AdminList finds every user whatever their discoverability, without the rate limit of the user search
+func (c *userUsecase) AdminList(ctx context.Context, req *model.SearchUserRequest) ([]model.UserResponse, int, error) {
+	if req.Query != nil {
+		query := auth.NormalizeUsername(*req.Query)
+		req.Query = &query
+	}
+	if req.Email != nil {
+		email := normalizeEmail(*req.Email)
+		req.Email = &email
+	}
+	req.Unrestricted = true
+
+	users, total, err := c.UserRepository.List(ctx, req)
+	if err != nil {
+		return []model.UserResponse{}, 0, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return []model.UserResponse{}, 0, nil
+	}
+
+	return serializer.ListUserToAdminResponse(users), total, nil
+}
+
+func (c *userUsecase) AdminFindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error) {
+	user, err := c.UserRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	return serializer.UserToAdminResponse(user), nil
+}
+
+// Suspend blocks every login of the user, the token version is bumped and the sessions are revoked so the tokens
+// the user already holds stop working on their next request
+func (c *userUsecase) Suspend(ctx context.Context, req *model.AdminUserActionRequest) error {
+	user, err := c.findManagedUser(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if user.IsSuspended() {
+		return model.ErrUserAlreadySuspended
+	}
+
+	var tokenVersion uint64
+	err = c.TX.Do(ctx, func(exec db.Executor) error {
+		var txErr error
+		tokenVersion, txErr = c.UserRepository.SuspendByID(ctx, exec, user.ID, time.Now())
+		if txErr != nil {
+			return fmt.Errorf("failed to suspend user: %w", txErr)
+		}
+
+		return c.createAuditLog(ctx, exec, req, entity.AdminActionSuspend)
+	})
+	if err != nil {
+		return err
+	}
+
+	// cached after commit, a rolled back bump must never reach the cache
+	err = invalidateTokens(ctx, c.RedisClient, user.ID, tokenVersion)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (c *userUsecase) Unsuspend(ctx context.Context, req *model.AdminUserActionRequest) error {
+	user, err := c.findManagedUser(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsSuspended() {
+		return model.ErrUserNotSuspended
+	}
+
+	return c.TX.Do(ctx, func(exec db.Executor) error {
+		txErr := c.UserRepository.UnsuspendByID(ctx, exec, user.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to unsuspend user: %w", txErr)
+		}
+
+		return c.createAuditLog(ctx, exec, req, entity.AdminActionUnsuspend)
+	})
+}
+
+// ForcePasswordReset logs the user out everywhere, the password no longer logs in until it is reset with the forgot
+// password flow
+func (c *userUsecase) ForcePasswordReset(ctx context.Context, req *model.AdminUserActionRequest) error {
+	user, err := c.findManagedUser(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var tokenVersion uint64
+	err = c.TX.Do(ctx, func(exec db.Executor) error {
+		var txErr error
+		tokenVersion, txErr = c.UserRepository.RequirePasswordResetByID(ctx, exec, user.ID, time.Now())
+		if txErr != nil {
+			return fmt.Errorf("failed to require password reset: %w", txErr)
+		}
+
+		return c.createAuditLog(ctx, exec, req, entity.AdminActionForcePasswordReset)
+	})
 	if err != nil {
-		return model.ErrInvalidOldPassword
+		return err
 	}
 
-	newPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	err = invalidateTokens(ctx, c.RedisClient, user.ID, tokenVersion)
 	if err != nil {
-		return fmt.Errorf("failed to generate password: %w", err)
+		return fmt.Errorf("failed to invalidate tokens: %w", err)
 	}
 
-	req.NewPassword = string(newPassword)
-	err = c.UserRepository.UpdateByID(ctx, req)
+	return nil
+}
+
+func (c *userUsecase) AdminDeleteByID(ctx context.Context, req *model.AdminUserActionRequest) error {
+	user, err := c.findManagedUser(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to update user by id: %w", err)
+		return err
+	}
+
+	return c.deleteUser(ctx, user, nil, newAdminAuditLog(req, entity.AdminActionDelete))
+}
+
+// findManagedUser finds the user an admin acts on. Admins can't act on themselves or on other users that may manage
+// users, so one admin can't lock the others out
+func (c *userUsecase) findManagedUser(ctx context.Context, req *model.AdminUserActionRequest) (*entity.User, error) {
+	if req.ActorID == req.ID {
+		return nil, model.ErrAdminActionNotAllowed
+	}
+
+	user, err := c.UserRepository.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id: %w", err)
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	subject, err := newSubject(ctx, c.RoleRepository, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user permissions: %w", err)
+	}
+
+	if auth.GrantsPermission(subject.Permissions, auth.PermissionUserManage) {
+		return nil, model.ErrAdminActionNotAllowed
+	}
+
+	return user, nil
+}
+
+func (c *userUsecase) createAuditLog(ctx context.Context, exec db.Executor, req *model.AdminUserActionRequest,
+	action string) error {
+	err := c.AdminAuditLogRepository.Create(ctx, exec, newAdminAuditLog(req, action))
+	if err != nil {
+		return fmt.Errorf("failed to store admin audit log: %w", err)
 	}
 
 	return nil
 }
+
+func newAdminAuditLog(req *model.AdminUserActionRequest, action string) *entity.AdminAuditLog {
+	log := &entity.AdminAuditLog{
+		ActorID: req.ActorID,
+		UserID:  req.ID,
+		Action:  action,
+	}
+	if req.Reason != "" {
+		log.Reason = &req.Reason
+	}
+
+	return log
+}
@@ -1,16 +1,29 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/avatar"
+	"go-api-example/internal/db"
 	"go-api-example/internal/entity"
 	"go-api-example/internal/messaging"
 	"go-api-example/internal/mocks"
 	"go-api-example/internal/model"
 	"go-api-example/internal/usecase"
+	"image"
+	"image/png"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -19,9 +32,11 @@ import (
 
 type UserUsecaseSuite struct {
 	suite.Suite
-	log          *zap.Logger
-	userProducer *messaging.UserProducer
-	ctx          context.Context
+	log            *zap.Logger
+	userProducer   *messaging.UserProducer
+	passwordHasher auth.PasswordHasher
+	passwordPolicy *auth.PasswordPolicy
+	ctx            context.Context
 }
 
 func (s *UserUsecaseSuite) SetupTest() {
@@ -30,11 +45,19 @@ func (s *UserUsecaseSuite) SetupTest() {
 	s.userProducer = messaging.NewUserProducer(
 		s.log, producer, "user-registered",
 	)
+	s.passwordHasher, _ = auth.NewPasswordHasher(auth.PasswordHashConfig{
+		Algorithm:  auth.PasswordHashBcrypt,
+		BcryptCost: bcrypt.DefaultCost,
+	})
+	s.passwordPolicy, _ = auth.NewPasswordPolicy(auth.PasswordPolicyConfig{MinLength: 8})
 	s.ctx = context.Background()
 }
 
 func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 	now := time.Now()
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
 
 	tests := []struct {
 		name       string
@@ -44,40 +67,71 @@ func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 		wantErrMsg string
 	}{
 		{
-			name: "error on count",
+			name: "error on invalid username",
 			request: &model.CreateUserRequest{
-				Username: "johndoe",
-				Password: "password",
+				Username: "john doe",
+				Password: "secret-password",
 			},
-			mockFunc: func(tx *mocks.Transactioner, r *mocks.UserRepository) {
-				r.On("CountByUsername", mock.Anything, "johndoe").
-					Return(0, errors.New("something error"))
+			mockFunc:   func(tx *mocks.Transactioner, r *mocks.UserRepository) {},
+			wantUser:   nil,
+			wantErrMsg: "username may only contain letters, digits, dots, dashes and underscores",
+		},
+		{
+			name: "error on reserved username",
+			request: &model.CreateUserRequest{
+				Username: "Admin",
+				Password: "secret-password",
+			},
+			mockFunc:   func(tx *mocks.Transactioner, r *mocks.UserRepository) {},
+			wantUser:   nil,
+			wantErrMsg: "username is reserved",
+		},
+		{
+			name: "error on password policy",
+			request: &model.CreateUserRequest{
+				Username: "johndoe",
+				Password: "secret",
 			},
+			mockFunc:   func(tx *mocks.Transactioner, r *mocks.UserRepository) {},
 			wantUser:   nil,
-			wantErrMsg: "failed to count by username: something error",
+			wantErrMsg: "password is too short",
 		},
 		{
 			name: "error on duplicate username",
 			request: &model.CreateUserRequest{
 				Username: "johndoe",
-				Password: "password",
+				Password: "secret-password",
 			},
 			mockFunc: func(tx *mocks.Transactioner, r *mocks.UserRepository) {
-				r.On("CountByUsername", mock.Anything, "johndoe").
-					Return(1, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("Create", mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ErrUsernameAlreadyExist)
 			},
 			wantUser:   nil,
-			wantErrMsg: "username already exist",
+			wantErrMsg: "failed to create user: username already exist",
+		},
+		{
+			name: "error on create with normalized username",
+			request: &model.CreateUserRequest{
+				Username: " ＪｏｈｎＤｏｅ ",
+				Password: "secret-password",
+			},
+			mockFunc: func(tx *mocks.Transactioner, r *mocks.UserRepository) {
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Username == "johndoe"
+				})).Return(errors.New("something error"))
+			},
+			wantUser:   nil,
+			wantErrMsg: "failed to create user: something error",
 		},
 		{
 			name: "error on create",
 			request: &model.CreateUserRequest{
 				Username: "johndoe",
-				Password: "password",
+				Password: "secret-password",
 			},
 			mockFunc: func(tx *mocks.Transactioner, r *mocks.UserRepository) {
-				r.On("CountByUsername", mock.Anything, "johndoe").
-					Return(0, nil)
 				tx.On("Do", mock.Anything, mock.Anything).
 					Return(errors.New("something error"))
 			},
@@ -88,11 +142,9 @@ func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 			name: "success",
 			request: &model.CreateUserRequest{
 				Username: "johndoe",
-				Password: "password",
+				Password: "secret-password",
 			},
 			mockFunc: func(tx *mocks.Transactioner, r *mocks.UserRepository) {
-				r.On("CountByUsername", mock.Anything, "johndoe").
-					Return(0, nil)
 				tx.On("Do", mock.Anything, mock.Anything).
 					Return(nil)
 			},
@@ -110,7 +162,11 @@ func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 		s.Run(tt.name, func() {
 			tx := mocks.NewTransactioner(s.T())
 			userRepository := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewUserUsecase(s.log, tx, s.userProducer, userRepository)
+			usecase := usecase.NewUserUsecase(s.log, tx, mocks.NewRedisClient(s.T()), s.passwordHasher, s.passwordPolicy,
+				s.userProducer, nil, nil, nil, mocks.NewFileStorage(s.T()), userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
 			tt.mockFunc(tx, userRepository)
 
 			_, err := usecase.Create(s.ctx, tt.request)
@@ -126,22 +182,69 @@ func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 
 func (s *UserUsecaseSuite) TestUserUsecase_List() {
 	now := time.Now()
+	shortQuery := "J"
+	query := "ＪｏＤ"
+	intCmd := func(val int64, err error) *redis.IntCmd {
+		cmd := redis.NewIntCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
 
 	tests := []struct {
 		name       string
 		request    *model.SearchUserRequest
-		mockFunc   func(r *mocks.UserRepository)
+		mockFunc   func(rc *mocks.RedisClient, r *mocks.UserRepository)
 		wantUsers  []model.UserResponse
 		wantTotal  int
 		wantErrMsg string
 	}{
+		{
+			name: "error on short query",
+			request: &model.SearchUserRequest{
+				Query:    &shortQuery,
+				Limit:    10,
+				ViewerID: 2,
+			},
+			mockFunc:   func(rc *mocks.RedisClient, r *mocks.UserRepository) {},
+			wantUsers:  []model.UserResponse{},
+			wantTotal:  0,
+			wantErrMsg: "search query is too short",
+		},
+		{
+			name: "error on count search request",
+			request: &model.SearchUserRequest{
+				Limit:    10,
+				ViewerID: 2,
+			},
+			mockFunc: func(rc *mocks.RedisClient, r *mocks.UserRepository) {
+				rc.On("Incr", mock.Anything, "user-search:2").Return(intCmd(0, errors.New("something error")))
+			},
+			wantUsers:  []model.UserResponse{},
+			wantTotal:  0,
+			wantErrMsg: "failed to count search request: something error",
+		},
+		{
+			name: "error too many search requests",
+			request: &model.SearchUserRequest{
+				Limit:    10,
+				ViewerID: 2,
+			},
+			mockFunc: func(rc *mocks.RedisClient, r *mocks.UserRepository) {
+				rc.On("Incr", mock.Anything, "user-search:2").Return(intCmd(61, nil))
+			},
+			wantUsers:  []model.UserResponse{},
+			wantTotal:  0,
+			wantErrMsg: "too many search requests, try again later",
+		},
 		{
 			name: "error on find",
 			request: &model.SearchUserRequest{
-				Limit:  10,
-				Offset: 0,
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
 			},
-			mockFunc: func(r *mocks.UserRepository) {
+			mockFunc: func(rc *mocks.RedisClient, r *mocks.UserRepository) {
 				r.On("List", mock.Anything, mock.Anything).
 					Return(nil, 0, errors.New("something error"))
 			},
@@ -150,12 +253,46 @@ func (s *UserUsecaseSuite) TestUserUsecase_List() {
 			wantErrMsg: "failed to get users: something error",
 		},
 		{
-			name: "success",
+			name: "success with normalized query",
 			request: &model.SearchUserRequest{
-				Limit:  10,
-				Offset: 0,
+				Query:    &query,
+				Limit:    10,
+				ViewerID: 2,
 			},
-			mockFunc: func(r *mocks.UserRepository) {
+			mockFunc: func(rc *mocks.RedisClient, r *mocks.UserRepository) {
+				rc.On("Incr", mock.Anything, "user-search:2").Return(intCmd(1, nil))
+				rc.On("Expire", mock.Anything, "user-search:2", auth.UserSearchWindow).Return(redis.NewBoolCmd(s.ctx))
+				r.On("List", mock.Anything, mock.MatchedBy(func(req *model.SearchUserRequest) bool {
+					return *req.Query == "jod" && req.ViewerID == 2 && !req.Unrestricted
+				})).Return([]entity.User{
+					{
+						ID:        1,
+						Username:  "jodie",
+						Password:  "password",
+						CreatedAt: now,
+						UpdatedAt: now,
+					},
+				}, 1, nil)
+			},
+			wantUsers: []model.UserResponse{
+				{
+					ID:        1,
+					Username:  "jodie",
+					CreatedAt: now.Format(time.RFC3339),
+					UpdatedAt: now.Format(time.RFC3339),
+				},
+			},
+			wantTotal:  1,
+			wantErrMsg: "",
+		},
+		{
+			name: "success unrestricted",
+			request: &model.SearchUserRequest{
+				Limit:        10,
+				Offset:       0,
+				Unrestricted: true,
+			},
+			mockFunc: func(rc *mocks.RedisClient, r *mocks.UserRepository) {
 				r.On("List", mock.Anything, mock.Anything).Return([]entity.User{
 					{
 						ID:        1,
@@ -182,9 +319,14 @@ func (s *UserUsecaseSuite) TestUserUsecase_List() {
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
 			userRepository := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewUserUsecase(s.log, tx, s.userProducer, userRepository)
-			tt.mockFunc(userRepository)
+			usecase := usecase.NewUserUsecase(s.log, tx, rc, s.passwordHasher, s.passwordPolicy, s.userProducer, nil, nil, nil,
+				mocks.NewFileStorage(s.T()), userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(rc, userRepository)
 
 			res, total, err := usecase.List(s.ctx, tt.request)
 
@@ -223,6 +365,17 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 			wantUser:   nil,
 			wantErrMsg: "failed to find user by id: something error",
 		},
+		{
+			name: "error not found",
+			request: &model.GetUserRequest{
+				ID: 1,
+			},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantUser:   nil,
+			wantErrMsg: "username not found",
+		},
 		{
 			name: "success",
 			request: &model.GetUserRequest{
@@ -251,7 +404,11 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 		s.Run(tt.name, func() {
 			tx := mocks.NewTransactioner(s.T())
 			userRepository := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewUserUsecase(s.log, tx, s.userProducer, userRepository)
+			usecase := usecase.NewUserUsecase(s.log, tx, mocks.NewRedisClient(s.T()), s.passwordHasher, s.passwordPolicy,
+				s.userProducer, nil, nil, nil, mocks.NewFileStorage(s.T()), userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
 			tt.mockFunc(userRepository)
 
 			res, err := usecase.FindByID(s.ctx, tt.request)
@@ -271,13 +428,47 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 	oldPasswordHash, _ := bcrypt.GenerateFromPassword([]byte("old_password"), bcrypt.DefaultCost)
 
 	now := time.Now()
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	username := "janedoe"
+	legacyUsername := "JohnDoe"
+	displayName := "Jane Doe"
+	empty := ""
+	timezone := "Asia/Jakarta"
+	bio := "hello"
 
 	tests := []struct {
 		name       string
 		request    *model.UpdateUserRequest
-		mockFunc   func(r *mocks.UserRepository)
+		mockFunc   func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository)
 		wantErrMsg string
 	}{
+		{
+			name:    "error on invalid username",
+			request: &model.UpdateUserRequest{ID: 1, Username: &empty},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+			},
+			wantErrMsg: "username may only contain letters, digits, dots, dashes and underscores",
+		},
+		{
+			name:    "rename to own username in another case",
+			request: &model.UpdateUserRequest{ID: 1, Username: &legacyUsername},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "JohnDoe",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				r.On("FindByUsername", mock.Anything, "johndoe").Return(&entity.User{ID: 1, Username: "JohnDoe"}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateProfile", mock.Anything, mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Username == "johndoe"
+				})).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update user profile: something error",
+		},
 		{
 			name: "error on find",
 			request: &model.UpdateUserRequest{
@@ -285,12 +476,20 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 				OldPassword: "old_password",
 				NewPassword: "new_password",
 			},
-			mockFunc: func(r *mocks.UserRepository) {
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
 				r.On("FindByID", mock.Anything, uint64(1)).
 					Return(nil, errors.New("something error"))
 			},
 			wantErrMsg: "failed to find user by id: something error",
 		},
+		{
+			name:    "error not found",
+			request: &model.UpdateUserRequest{ID: 1, DisplayName: &displayName},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
 		{
 			name: "error on compare hash and password",
 			request: &model.UpdateUserRequest{
@@ -298,7 +497,7 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 				OldPassword: "old_password",
 				NewPassword: "new_password",
 			},
-			mockFunc: func(r *mocks.UserRepository) {
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
 				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
 					ID:        1,
 					Username:  "johndoe",
@@ -309,6 +508,55 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 			},
 			wantErrMsg: "invalid old password",
 		},
+		{
+			name: "error on password policy",
+			request: &model.UpdateUserRequest{
+				ID:          1,
+				OldPassword: "old_password",
+				NewPassword: "qwerty123",
+			},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+			},
+			wantErrMsg: "password is too common or known to be breached",
+		},
+		{
+			name:    "error on count username",
+			request: &model.UpdateUserRequest{ID: 1, Username: &username},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				r.On("FindByUsername", mock.Anything, "janedoe").
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by username: something error",
+		},
+		{
+			name:    "error on duplicate username",
+			request: &model.UpdateUserRequest{ID: 1, Username: &username},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				r.On("FindByUsername", mock.Anything, "janedoe").Return(&entity.User{ID: 2, Username: "janedoe"}, nil)
+			},
+			wantErrMsg: "username already exist",
+		},
 		{
 			name: "error on update",
 			request: &model.UpdateUserRequest{
@@ -316,7 +564,7 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 				OldPassword: "old_password",
 				NewPassword: "new_password",
 			},
-			mockFunc: func(r *mocks.UserRepository) {
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
 				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
 					ID:        1,
 					Username:  "johndoe",
@@ -324,10 +572,94 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
-				r.On("UpdateByID", mock.Anything, mock.Anything).
-					Return(errors.New("something error"))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), mock.Anything).
+					Return(uint64(0), errors.New("something error"))
+			},
+			wantErrMsg: "failed to change password: something error",
+		},
+		{
+			name: "error on invalidate tokens",
+			request: &model.UpdateUserRequest{
+				ID:          1,
+				OldPassword: "old_password",
+				NewPassword: "new_password",
+			},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(uint64(2), nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				cmd := redis.NewStringSliceCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(cmd)
+			},
+			wantErrMsg: "failed to invalidate tokens: something error",
+		},
+		{
+			name:    "error on update profile",
+			request: &model.UpdateUserRequest{ID: 1, DisplayName: &displayName},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateProfile", mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ErrUsernameAlreadyExist)
+			},
+			wantErrMsg: "failed to update user profile: username already exist",
+		},
+		{
+			name: "error on update profile after password change",
+			request: &model.UpdateUserRequest{
+				ID:          1,
+				OldPassword: "old_password",
+				NewPassword: "new_password",
+				Username:    &username,
+			},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				r.On("FindByUsername", mock.Anything, "janedoe").Return(nil, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(uint64(2), nil)
+				r.On("UpdateProfile", mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ErrUsernameAlreadyExist)
+			},
+			wantErrMsg: "failed to update user profile: username already exist",
+		},
+		{
+			name:    "error on send event",
+			request: &model.UpdateUserRequest{ID: 1, DisplayName: &displayName},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateProfile", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				k.On("Produce", mock.Anything, mock.Anything).Return(errors.New("something error"))
 			},
-			wantErrMsg: "failed to update user by id: something error",
+			wantErrMsg: "failed to send user updated event: failed to produce message for user-updated: something error",
 		},
 		{
 			name: "success",
@@ -336,15 +668,75 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 				OldPassword: "old_password",
 				NewPassword: "new_password",
 			},
-			mockFunc: func(r *mocks.UserRepository) {
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					Password:  string(oldPasswordHash),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("ChangePasswordByID", mock.Anything, mock.Anything, uint64(1), mock.MatchedBy(func(hash string) bool {
+					return s.passwordHasher.Compare(hash, "new_password") == nil
+				})).Return(uint64(2), nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:1", "2", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(redis.NewStringSliceCmd(s.ctx))
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(redis.NewStringSliceCmd(s.ctx))
+				rc.On("Del", mock.Anything, "user-refresh-token:1", "user-session:1").Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					return *msg.TopicPartition.Topic == "security-event" &&
+						strings.Contains(string(msg.Value), `"type":"password_changed","user_id":1`)
+				}), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success with profile",
+			request: &model.UpdateUserRequest{
+				ID:          1,
+				Username:    &username,
+				DisplayName: &displayName,
+				Bio:         &empty,
+				Timezone:    &timezone,
+			},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
 				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
 					ID:        1,
 					Username:  "johndoe",
 					Password:  string(oldPasswordHash),
+					Bio:       &bio,
+					Timezone:  "UTC",
+					Locale:    "en",
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				r.On("FindByUsername", mock.Anything, "janedoe").Return(nil, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateProfile", mock.Anything, mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Username == "janedoe" && *u.DisplayName == "Jane Doe" && u.Bio == nil &&
+						u.Timezone == "Asia/Jakarta" && u.Locale == "en"
+				})).Return(nil)
+				k.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+					event := new(model.UserUpdatedEvent)
+					_ = json.Unmarshal(msg.Value, event)
+					return event.PreviousUsername == "johndoe" &&
+						slices.Equal(event.ChangedFields, []string{"username", "display_name", "bio", "timezone"})
+				}), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:    "success without changes",
+			request: &model.UpdateUserRequest{ID: 1, Username: &username},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "janedoe",
 					CreatedAt: now,
 					UpdatedAt: now,
 				}, nil)
-				r.On("UpdateByID", mock.Anything, mock.Anything).Return(nil)
 			},
 			wantErrMsg: "",
 		},
@@ -353,9 +745,17 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			kafka := mocks.NewKafkaProducer(s.T())
+			userUpdatedProducer := messaging.NewUserUpdatedProducer(s.log, kafka, "user-updated")
+			securityEventProducer := messaging.NewSecurityEventProducer(s.log, kafka, "security-event")
 			userRepository := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewUserUsecase(s.log, tx, s.userProducer, userRepository)
-			tt.mockFunc(userRepository)
+			usecase := usecase.NewUserUsecase(s.log, tx, rc, s.passwordHasher, s.passwordPolicy, s.userProducer, nil,
+				userUpdatedProducer, securityEventProducer, mocks.NewFileStorage(s.T()), userRepository,
+				mocks.NewTodoRepository(s.T()), mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(tx, rc, kafka, userRepository)
 
 			err := usecase.UpdateByID(s.ctx, tt.request)
 
@@ -368,6 +768,1162 @@ func (s *UserUsecaseSuite) TestUserUsecase_UpdateByID() {
 	}
 }
 
+func (s *UserUsecaseSuite) TestUserUsecase_UpdateAvatar() {
+	now := time.Now()
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 30)))
+	validImage := buf.Bytes()
+	previousKey := "qwe"
+	newFile := mock.MatchedBy(func(name string) bool {
+		return strings.HasSuffix(name, ".png") && !strings.HasPrefix(name, "qwe_")
+	})
+
+	tests := []struct {
+		name       string
+		image      []byte
+		mockFunc   func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage)
+		wantErrMsg string
+	}{
+		{
+			name:  "error invalid image",
+			image: []byte("not an image"),
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+			},
+			wantErrMsg: "avatar must be a png, jpeg or gif image",
+		},
+		{
+			name:  "error image too large",
+			image: make([]byte, avatar.MaxUploadSize+1),
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+			},
+			wantErrMsg: "avatar is too large",
+		},
+		{
+			name:  "error not found",
+			image: validImage,
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name:  "error on store",
+			image: validImage,
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Username: "johndoe"}, nil)
+				fs.On("Put", mock.Anything, newFile, mock.Anything).Return(nil).Twice()
+				fs.On("Put", mock.Anything, newFile, mock.Anything).Return(errors.New("something error")).Once()
+				fs.On("Delete", mock.Anything, newFile, newFile).Return(nil)
+			},
+			wantErrMsg: "failed to store avatar: something error",
+		},
+		{
+			name:  "error on update",
+			image: validImage,
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Username: "johndoe"}, nil)
+				fs.On("Put", mock.Anything, newFile, mock.Anything).Return(nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateAvatarByID", mock.Anything, mock.Anything, uint64(1), mock.Anything).
+					Return(errors.New("something error"))
+				fs.On("Delete", mock.Anything, newFile, newFile, newFile, newFile).Return(nil)
+			},
+			wantErrMsg: "failed to update user avatar: something error",
+		},
+		{
+			name:  "success",
+			image: validImage,
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Username:  "johndoe",
+					AvatarKey: &previousKey,
+					CreatedAt: now,
+					UpdatedAt: now,
+				}, nil)
+				fs.On("Put", mock.Anything, newFile, mock.MatchedBy(func(data []byte) bool {
+					img, err := png.Decode(bytes.NewReader(data))
+					return err == nil && img.Bounds().Dx() == img.Bounds().Dy()
+				})).Return(nil).Times(len(avatar.Sizes))
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateAvatarByID", mock.Anything, mock.Anything, uint64(1), mock.MatchedBy(func(key *string) bool {
+					return key != nil && *key != previousKey
+				})).Return(nil)
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+				fs.On("Delete", mock.Anything, "qwe_32.png", "qwe_64.png", "qwe_128.png", "qwe_256.png").
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			kafka := mocks.NewKafkaProducer(s.T())
+			userUpdatedProducer := messaging.NewUserUpdatedProducer(s.log, kafka, "user-updated")
+			userRepository := mocks.NewUserRepository(s.T())
+			fileStorage := mocks.NewFileStorage(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, mocks.NewRedisClient(s.T()), s.passwordHasher, s.passwordPolicy,
+				s.userProducer, nil, userUpdatedProducer, nil, fileStorage, userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(tx, kafka, userRepository, fileStorage)
+
+			res, err := usecase.UpdateAvatar(s.ctx, &model.UpdateAvatarRequest{UserID: 1, Image: tt.image})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Len(res.AvatarURLs, len(avatar.Sizes))
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_DeleteAvatar() {
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	avatarKey := "qwe"
+
+	tests := []struct {
+		name       string
+		mockFunc   func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage)
+		wantErrMsg string
+	}{
+		{
+			name: "error not found",
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name: "error on update",
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, AvatarKey: &avatarKey}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateAvatarByID", mock.Anything, mock.Anything, uint64(1), (*string)(nil)).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update user avatar: something error",
+		},
+		{
+			name: "success without avatar",
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1}, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success",
+			mockFunc: func(tx *mocks.Transactioner, k *mocks.KafkaProducer, r *mocks.UserRepository, fs *mocks.FileStorage) {
+				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{ID: 1, AvatarKey: &avatarKey}, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				r.On("UpdateAvatarByID", mock.Anything, mock.Anything, uint64(1), (*string)(nil)).Return(nil)
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+				fs.On("Delete", mock.Anything, "qwe_32.png", "qwe_64.png", "qwe_128.png", "qwe_256.png").Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			kafka := mocks.NewKafkaProducer(s.T())
+			userUpdatedProducer := messaging.NewUserUpdatedProducer(s.log, kafka, "user-updated")
+			userRepository := mocks.NewUserRepository(s.T())
+			fileStorage := mocks.NewFileStorage(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, mocks.NewRedisClient(s.T()), s.passwordHasher, s.passwordPolicy,
+				s.userProducer, nil, userUpdatedProducer, nil, fileStorage, userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(tx, kafka, userRepository, fileStorage)
+
+			err := usecase.DeleteAvatar(s.ctx, &model.DeleteAvatarRequest{UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_DeleteByID() {
+	now := time.Now()
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	user := &entity.User{
+		ID:        1,
+		Username:  "johndoe",
+		Password:  string(passwordHash),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	avatarKey := "qwe"
+	userWithAvatar := &entity.User{
+		ID:        1,
+		Username:  "johndoe",
+		AvatarKey: &avatarKey,
+		Password:  string(passwordHash),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	claims := &auth.JWTClaims{
+		UserID: "1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			ID:        "asd-789",
+		},
+	}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	tokensCmd := func() *redis.StringSliceCmd {
+		cmd := redis.NewStringSliceCmd(s.ctx)
+		cmd.SetVal([]string{"zxc-123", "zxc-456"})
+		return cmd
+	}
+	sessionsCmd := func() *redis.StringSliceCmd {
+		cmd := redis.NewStringSliceCmd(s.ctx)
+		cmd.SetVal([]string{"qwe-123"})
+		return cmd
+	}
+	sessionCmd := func() *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(fmt.Sprintf(`{"id":"qwe-123","user_id":"1","refresh_token":"zxc-456","access_tokens":{"asd-456":%q}}`,
+			now.Add(time.Minute).Format(time.RFC3339)))
+		return cmd
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.DeleteUserRequest
+		mockFunc   func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository)
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:    "error not found",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name:    "error invalid password",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "wrong-password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+			},
+			wantErrMsg: "invalid password",
+		},
+		{
+			name:    "error on delete todos",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user todos: something error",
+		},
+		{
+			name:    "error on unassign todos",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to unassign user todos: something error",
+		},
+		{
+			name:    "error on delete notifications",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user notifications: something error",
+		},
+		{
+			name:    "error on delete totp",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user totp: something error",
+		},
+		{
+			name:    "error on delete personal access tokens",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user personal access tokens: something error",
+		},
+		{
+			name:    "error on delete identities",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user identities: something error",
+		},
+		{
+			name:    "error on delete security events",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user security events: something error",
+		},
+		{
+			name:    "error on delete user",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete user: something error",
+		},
+		{
+			name:    "error on revoke sessions",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				cmd := redis.NewStringSliceCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(cmd)
+			},
+			wantErrMsg: "failed to revoke sessions: something error",
+		},
+		{
+			name:    "error on revoke access token",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(tokensCmd())
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(sessionsCmd())
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-456", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "refresh-token:zxc-456", "session:qwe-123",
+					"user-refresh-token:1", "user-session:1").
+					Return(redis.NewIntCmd(s.ctx))
+				cmd := redis.NewStatusCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).Return(cmd)
+			},
+			wantErrMsg: "failed to revoke access token: something error",
+		},
+		{
+			name:    "error on send event",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(tokensCmd())
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(sessionsCmd())
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-456", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "refresh-token:zxc-456", "session:qwe-123",
+					"user-refresh-token:1", "user-session:1").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to send user deleted event: failed to produce message for user-deleted: something error",
+		},
+		{
+			name:    "success",
+			request: &model.DeleteUserRequest{ID: 1, Claims: claims, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(user, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(tokensCmd())
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(sessionsCmd())
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-456", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "refresh-token:zxc-456", "session:qwe-123",
+					"user-refresh-token:1", "user-session:1").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-789", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Del", mock.Anything, "user-token-version:1").Return(redis.NewIntCmd(s.ctx))
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:    "success with avatar",
+			request: &model.DeleteUserRequest{ID: 1, Password: "password"},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, fs *mocks.FileStorage, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1)).Return(userWithAvatar, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(1)).Return(nil)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:1").Return(tokensCmd())
+				rc.On("SMembers", mock.Anything, "user-session:1").Return(sessionsCmd())
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-456", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "refresh-token:zxc-123", "refresh-token:zxc-456", "session:qwe-123",
+					"user-refresh-token:1", "user-session:1").
+					Return(redis.NewIntCmd(s.ctx))
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Del", mock.Anything, "user-token-version:1").Return(redis.NewIntCmd(s.ctx))
+				fs.On("Delete", mock.Anything, "qwe_32.png", "qwe_64.png", "qwe_128.png", "qwe_256.png").Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			kafka := mocks.NewKafkaProducer(s.T())
+			userDeletedProducer := messaging.NewUserDeletedProducer(s.log, kafka, "user-deleted")
+			userRepository := mocks.NewUserRepository(s.T())
+			todoRepository := mocks.NewTodoRepository(s.T())
+			notificationRepository := mocks.NewNotificationRepository(s.T())
+			totpRepository := mocks.NewTOTPRepository(s.T())
+			personalAccessTokenRepository := mocks.NewPersonalAccessTokenRepository(s.T())
+			userIdentityRepository := mocks.NewUserIdentityRepository(s.T())
+			fileStorage := mocks.NewFileStorage(s.T())
+			securityEventRepository := mocks.NewSecurityEventRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, rc, s.passwordHasher, s.passwordPolicy, s.userProducer,
+				userDeletedProducer, nil, nil, fileStorage, userRepository, todoRepository, notificationRepository,
+				totpRepository, personalAccessTokenRepository, userIdentityRepository, mocks.NewRoleRepository(s.T()),
+				mocks.NewAdminAuditLogRepository(s.T()), securityEventRepository)
+			tt.mockFunc(tx, rc, kafka, userRepository, todoRepository, notificationRepository, totpRepository, personalAccessTokenRepository,
+				userIdentityRepository, fileStorage, securityEventRepository)
+
+			err := usecase.DeleteByID(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_AdminList() {
+	now := time.Now()
+	query := "ＪＯ"
+	email := " JohnDoe@Example.com "
+	status := model.UserStatusSuspended
+
+	tests := []struct {
+		name       string
+		request    *model.SearchUserRequest
+		mockFunc   func(r *mocks.UserRepository)
+		wantUsers  []model.UserResponse
+		wantTotal  int
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find",
+			request: &model.SearchUserRequest{Limit: 10},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("List", mock.Anything, mock.Anything).Return(nil, 0, errors.New("something error"))
+			},
+			wantUsers:  []model.UserResponse{},
+			wantTotal:  0,
+			wantErrMsg: "failed to get users: something error",
+		},
+		{
+			name:    "success not found",
+			request: &model.SearchUserRequest{Limit: 10},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("List", mock.Anything, mock.Anything).Return(nil, 0, nil)
+			},
+			wantUsers:  []model.UserResponse{},
+			wantTotal:  0,
+			wantErrMsg: "",
+		},
+		{
+			name: "success with normalized filters",
+			request: &model.SearchUserRequest{
+				Query:  &query,
+				Email:  &email,
+				Status: &status,
+				Limit:  10,
+			},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("List", mock.Anything, mock.MatchedBy(func(req *model.SearchUserRequest) bool {
+					return *req.Query == "jo" && *req.Email == "johndoe@example.com" && req.Unrestricted
+				})).Return([]entity.User{
+					{
+						ID:          1,
+						Username:    "johndoe",
+						Email:       &email,
+						Role:        "user",
+						SuspendedAt: &now,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				}, 1, nil)
+			},
+			wantUsers: []model.UserResponse{
+				{
+					ID:          1,
+					Username:    "johndoe",
+					Email:       &email,
+					Role:        "user",
+					SuspendedAt: func() *string { v := now.Format(time.RFC3339); return &v }(),
+					CreatedAt:   now.Format(time.RFC3339),
+					UpdatedAt:   now.Format(time.RFC3339),
+				},
+			},
+			wantTotal:  1,
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, mocks.NewTransactioner(s.T()), mocks.NewRedisClient(s.T()), s.passwordHasher,
+				s.passwordPolicy, s.userProducer, nil, nil, nil, mocks.NewFileStorage(s.T()), userRepository,
+				mocks.NewTodoRepository(s.T()), mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(userRepository)
+
+			res, total, err := usecase.AdminList(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+			s.Equal(tt.wantUsers, res)
+			s.Equal(tt.wantTotal, total)
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_AdminFindByID() {
+	now := time.Now()
+	formatted := now.Format(time.RFC3339)
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.UserRepository)
+		wantRes    *model.UserResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(2)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name: "error not found",
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, uint64(2)).Return(&entity.User{
+					ID:                      2,
+					Username:                "johndoe",
+					Role:                    "user",
+					PasswordResetRequiredAt: &now,
+					CreatedAt:               now,
+					UpdatedAt:               now,
+				}, nil)
+			},
+			wantRes: &model.UserResponse{
+				ID:                      2,
+				Username:                "johndoe",
+				Role:                    "user",
+				PasswordResetRequiredAt: &formatted,
+				CreatedAt:               formatted,
+				UpdatedAt:               formatted,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, mocks.NewTransactioner(s.T()), mocks.NewRedisClient(s.T()), s.passwordHasher,
+				s.passwordPolicy, s.userProducer, nil, nil, nil, mocks.NewFileStorage(s.T()), userRepository,
+				mocks.NewTodoRepository(s.T()), mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()),
+				mocks.NewRoleRepository(s.T()), mocks.NewAdminAuditLogRepository(s.T()), mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(userRepository)
+
+			res, err := usecase.AdminFindByID(s.ctx, &model.GetUserRequest{ID: 2})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantRes, res)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_Suspend() {
+	now := time.Now()
+	user := &entity.User{ID: 2, Username: "johndoe", Role: "user"}
+	suspendedUser := &entity.User{ID: 2, Username: "johndoe", Role: "user", SuspendedAt: &now}
+	userRole := &entity.Role{Name: "user", Permissions: []string{auth.PermissionTodoRead}}
+	request := &model.AdminUserActionRequest{ID: 2, ActorID: 1, Reason: "spam"}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	emptyCmd := func() *redis.StringSliceCmd {
+		return redis.NewStringSliceCmd(s.ctx)
+	}
+	isAuditLog := func(action string) any {
+		return mock.MatchedBy(func(log *entity.AdminAuditLog) bool {
+			return log.ActorID == 1 && log.UserID == 2 && log.Action == action && *log.Reason == "spam"
+		})
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.AdminUserActionRequest
+		mockFunc   func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository)
+		wantErrMsg string
+	}{
+		{
+			name:    "error suspend self",
+			request: &model.AdminUserActionRequest{ID: 1, ActorID: 1},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+			},
+			wantErrMsg: "action not allowed on this user",
+		},
+		{
+			name:    "error on find",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id: something error",
+		},
+		{
+			name:    "error not found",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "username not found",
+		},
+		{
+			name:    "error on find role",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to resolve user permissions: something error",
+		},
+		{
+			name:    "error suspend user manager",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(&entity.User{ID: 2, Role: "admin"}, nil)
+				rr.On("FindByName", mock.Anything, "admin").Return(&entity.Role{Name: "admin", Permissions: []string{auth.PermissionAll}}, nil)
+			},
+			wantErrMsg: "action not allowed on this user",
+		},
+		{
+			name:    "error already suspended",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(suspendedUser, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+			},
+			wantErrMsg: "user already suspended",
+		},
+		{
+			name:    "error on suspend",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("SuspendByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(0), errors.New("something error"))
+			},
+			wantErrMsg: "failed to suspend user: something error",
+		},
+		{
+			name:    "error on store audit log",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("SuspendByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(3), nil)
+				ar.On("Create", mock.Anything, mock.Anything, isAuditLog(entity.AdminActionSuspend)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store admin audit log: something error",
+		},
+		{
+			name:    "error on invalidate tokens",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("SuspendByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(3), nil)
+				ar.On("Create", mock.Anything, mock.Anything, isAuditLog(entity.AdminActionSuspend)).Return(nil)
+				cmd := redis.NewStatusCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, "user-token-version:2", "3", auth.TokenVersionCacheTTL).Return(cmd)
+			},
+			wantErrMsg: "failed to invalidate tokens: something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("SuspendByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(3), nil)
+				ar.On("Create", mock.Anything, mock.Anything, isAuditLog(entity.AdminActionSuspend)).Return(nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:2", "3", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:2").Return(emptyCmd())
+				rc.On("SMembers", mock.Anything, "user-session:2").Return(emptyCmd())
+				rc.On("Del", mock.Anything, "user-refresh-token:2", "user-session:2").Return(redis.NewIntCmd(s.ctx))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			roleRepository := mocks.NewRoleRepository(s.T())
+			adminAuditLogRepository := mocks.NewAdminAuditLogRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, rc, s.passwordHasher, s.passwordPolicy, s.userProducer, nil, nil, nil,
+				mocks.NewFileStorage(s.T()), userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()), roleRepository,
+				adminAuditLogRepository, mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(tx, rc, userRepository, roleRepository, adminAuditLogRepository)
+
+			err := usecase.Suspend(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_Unsuspend() {
+	now := time.Now()
+	user := &entity.User{ID: 2, Username: "johndoe", Role: "user"}
+	suspendedUser := &entity.User{ID: 2, Username: "johndoe", Role: "user", SuspendedAt: &now}
+	userRole := &entity.Role{Name: "user", Permissions: []string{auth.PermissionTodoRead}}
+	request := &model.AdminUserActionRequest{ID: 2, ActorID: 1}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(tx *mocks.Transactioner, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error not suspended",
+			mockFunc: func(tx *mocks.Transactioner, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+			},
+			wantErrMsg: "user not suspended",
+		},
+		{
+			name: "error on unsuspend",
+			mockFunc: func(tx *mocks.Transactioner, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(suspendedUser, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("UnsuspendByID", mock.Anything, mock.Anything, uint64(2)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to unsuspend user: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(tx *mocks.Transactioner, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(suspendedUser, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("UnsuspendByID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+				ar.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(log *entity.AdminAuditLog) bool {
+					return log.ActorID == 1 && log.UserID == 2 && log.Action == entity.AdminActionUnsuspend && log.Reason == nil
+				})).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			roleRepository := mocks.NewRoleRepository(s.T())
+			adminAuditLogRepository := mocks.NewAdminAuditLogRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, mocks.NewRedisClient(s.T()), s.passwordHasher, s.passwordPolicy,
+				s.userProducer, nil, nil, nil, mocks.NewFileStorage(s.T()), userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()), roleRepository,
+				adminAuditLogRepository, mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(tx, userRepository, roleRepository, adminAuditLogRepository)
+
+			err := usecase.Unsuspend(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_ForcePasswordReset() {
+	user := &entity.User{ID: 2, Username: "johndoe", Role: "user"}
+	userRole := &entity.Role{Name: "user", Permissions: []string{auth.PermissionTodoRead}}
+	request := &model.AdminUserActionRequest{ID: 2, ActorID: 1}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	emptyCmd := func() *redis.StringSliceCmd {
+		return redis.NewStringSliceCmd(s.ctx)
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on require password reset",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("RequirePasswordResetByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(0), errors.New("something error"))
+			},
+			wantErrMsg: "failed to require password reset: something error",
+		},
+		{
+			name: "error on store audit log",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("RequirePasswordResetByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(3), nil)
+				ar.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store admin audit log: something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, ur *mocks.UserRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				ur.On("RequirePasswordResetByID", mock.Anything, mock.Anything, uint64(2), mock.Anything).Return(uint64(3), nil)
+				ar.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(log *entity.AdminAuditLog) bool {
+					return log.ActorID == 1 && log.UserID == 2 && log.Action == entity.AdminActionForcePasswordReset
+				})).Return(nil)
+				rc.On("SetEx", mock.Anything, "user-token-version:2", "3", auth.TokenVersionCacheTTL).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("SMembers", mock.Anything, "user-refresh-token:2").Return(emptyCmd())
+				rc.On("SMembers", mock.Anything, "user-session:2").Return(emptyCmd())
+				rc.On("Del", mock.Anything, "user-refresh-token:2", "user-session:2").Return(redis.NewIntCmd(s.ctx))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			roleRepository := mocks.NewRoleRepository(s.T())
+			adminAuditLogRepository := mocks.NewAdminAuditLogRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, rc, s.passwordHasher, s.passwordPolicy, s.userProducer, nil, nil, nil,
+				mocks.NewFileStorage(s.T()), userRepository, mocks.NewTodoRepository(s.T()),
+				mocks.NewNotificationRepository(s.T()), mocks.NewTOTPRepository(s.T()),
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewUserIdentityRepository(s.T()), roleRepository,
+				adminAuditLogRepository, mocks.NewSecurityEventRepository(s.T()))
+			tt.mockFunc(tx, rc, userRepository, roleRepository, adminAuditLogRepository)
+
+			err := usecase.ForcePasswordReset(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_AdminDeleteByID() {
+	user := &entity.User{ID: 2, Username: "johndoe", Role: "user"}
+	userRole := &entity.Role{Name: "user", Permissions: []string{auth.PermissionTodoRead}}
+	request := &model.AdminUserActionRequest{ID: 2, ActorID: 1, Reason: "spam"}
+	runTx := func(ctx context.Context, fn func(db.Executor) error) error {
+		return fn(nil)
+	}
+	emptyCmd := func() *redis.StringSliceCmd {
+		return redis.NewStringSliceCmd(s.ctx)
+	}
+	sessionsCmd := func() *redis.StringSliceCmd {
+		cmd := redis.NewStringSliceCmd(s.ctx)
+		cmd.SetVal([]string{"qwe-123"})
+		return cmd
+	}
+	sessionCmd := func() *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(fmt.Sprintf(`{"id":"qwe-123","user_id":"2","access_tokens":{"asd-456":%q}}`,
+			time.Now().Add(time.Minute).Format(time.RFC3339)))
+		return cmd
+	}
+	deleteData := func(ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository,
+		pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, ser *mocks.SecurityEventRepository) {
+		tr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		tr.On("UnassignByAssigneeID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		nr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		tp.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		pr.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		ir.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		ser.On("DeleteByUserID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+		ur.On("DeleteByID", mock.Anything, mock.Anything, uint64(2)).Return(nil)
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.AdminUserActionRequest
+		mockFunc   func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository, ser *mocks.SecurityEventRepository)
+		wantErrMsg string
+	}{
+		{
+			name:    "error delete self",
+			request: &model.AdminUserActionRequest{ID: 1, ActorID: 1},
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository, ser *mocks.SecurityEventRepository) {
+			},
+			wantErrMsg: "action not allowed on this user",
+		},
+		{
+			name:    "error on store audit log",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				deleteData(ur, tr, nr, tp, pr, ir, ser)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:2").Return(emptyCmd())
+				rc.On("SMembers", mock.Anything, "user-session:2").Return(emptyCmd())
+				rc.On("Del", mock.Anything, "user-refresh-token:2", "user-session:2").Return(redis.NewIntCmd(s.ctx))
+				ar.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to store admin audit log: something error",
+		},
+		{
+			name:    "error on drop token version",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				deleteData(ur, tr, nr, tp, pr, ir, ser)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:2").Return(emptyCmd())
+				rc.On("SMembers", mock.Anything, "user-session:2").Return(emptyCmd())
+				rc.On("Del", mock.Anything, "user-refresh-token:2", "user-session:2").Return(redis.NewIntCmd(s.ctx))
+				ar.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+				delCmd := redis.NewIntCmd(s.ctx)
+				delCmd.SetErr(errors.New("something error"))
+				rc.On("Del", mock.Anything, "user-token-version:2").Return(delCmd)
+			},
+			wantErrMsg: "failed to drop token version: something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(tx *mocks.Transactioner, rc *mocks.RedisClient, k *mocks.KafkaProducer, ur *mocks.UserRepository, tr *mocks.TodoRepository, nr *mocks.NotificationRepository, tp *mocks.TOTPRepository, pr *mocks.PersonalAccessTokenRepository, ir *mocks.UserIdentityRepository, rr *mocks.RoleRepository, ar *mocks.AdminAuditLogRepository, ser *mocks.SecurityEventRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2)).Return(user, nil)
+				rr.On("FindByName", mock.Anything, "user").Return(userRole, nil)
+				tx.On("Do", mock.Anything, mock.Anything).Return(runTx)
+				deleteData(ur, tr, nr, tp, pr, ir, ser)
+				rc.On("SMembers", mock.Anything, "user-refresh-token:2").Return(emptyCmd())
+				rc.On("SMembers", mock.Anything, "user-session:2").Return(sessionsCmd())
+				rc.On("Get", mock.Anything, "session:qwe-123").Return(sessionCmd())
+				rc.On("SetEx", mock.Anything, "revoke-jwt-token:asd-456", "true", mock.Anything).
+					Return(redis.NewStatusCmd(s.ctx))
+				rc.On("Del", mock.Anything, "session:qwe-123", "user-refresh-token:2", "user-session:2").
+					Return(redis.NewIntCmd(s.ctx))
+				ar.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(log *entity.AdminAuditLog) bool {
+					return log.ActorID == 1 && log.UserID == 2 && log.Action == entity.AdminActionDelete && *log.Reason == "spam"
+				})).Return(nil)
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Del", mock.Anything, "user-token-version:2").Return(redis.NewIntCmd(s.ctx))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tx := mocks.NewTransactioner(s.T())
+			rc := mocks.NewRedisClient(s.T())
+			kafka := mocks.NewKafkaProducer(s.T())
+			userDeletedProducer := messaging.NewUserDeletedProducer(s.log, kafka, "user-deleted")
+			userRepository := mocks.NewUserRepository(s.T())
+			todoRepository := mocks.NewTodoRepository(s.T())
+			notificationRepository := mocks.NewNotificationRepository(s.T())
+			totpRepository := mocks.NewTOTPRepository(s.T())
+			personalAccessTokenRepository := mocks.NewPersonalAccessTokenRepository(s.T())
+			userIdentityRepository := mocks.NewUserIdentityRepository(s.T())
+			roleRepository := mocks.NewRoleRepository(s.T())
+			adminAuditLogRepository := mocks.NewAdminAuditLogRepository(s.T())
+			securityEventRepository := mocks.NewSecurityEventRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, tx, rc, s.passwordHasher, s.passwordPolicy, s.userProducer,
+				userDeletedProducer, nil, nil, mocks.NewFileStorage(s.T()), userRepository, todoRepository,
+				notificationRepository, totpRepository, personalAccessTokenRepository, userIdentityRepository, roleRepository,
+				adminAuditLogRepository, securityEventRepository)
+			tt.mockFunc(tx, rc, kafka, userRepository, todoRepository, notificationRepository, totpRepository,
+				personalAccessTokenRepository, userIdentityRepository, roleRepository, adminAuditLogRepository, securityEventRepository)
+
+			err := usecase.AdminDeleteByID(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func TestUserUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(UserUsecaseSuite))
 }
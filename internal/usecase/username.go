@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"errors"
+	"go-api-example/internal/auth"
+	"go-api-example/internal/model"
+)
+
+func normalizeUsername(username string) (string, error) {
+	username = auth.NormalizeUsername(username)
+	err := auth.ValidateUsername(username)
+	switch {
+	case errors.Is(err, auth.ErrUsernameInvalid):
+		return "", model.ErrInvalidUsername
+	case errors.Is(err, auth.ErrUsernameReserved):
+		return "", model.ErrUsernameReserved
+	}
+
+	return username, err
+}
@@ -11,8 +11,18 @@ import (
 )
 
 func NewAuthMiddleware(userID uint64) gin.HandlerFunc {
+	return newAuthMiddleware(userID, nil)
+}
+
+// NewImpersonationAuthMiddleware sets the claims of an impersonation token the actor holds for the user
+func NewImpersonationAuthMiddleware(userID uint64, actorID uint64) gin.HandlerFunc {
+	return newAuthMiddleware(userID, &auth.Actor{UserID: fmt.Sprint(actorID)})
+}
+
+func newAuthMiddleware(userID uint64, actor *auth.Actor) gin.HandlerFunc {
 	now := time.Now()
 	claims := &auth.JWTClaims{
+		Actor:  actor,
 		UserID: fmt.Sprint(userID),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),